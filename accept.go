@@ -0,0 +1,108 @@
+package fuego
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// MediaOffer is a media range of an Accept header, like "text/html;level=1;q=0.8".
+type MediaOffer struct {
+	// Type is the lowercased type, like "text", or "*".
+	Type string
+	// Subtype is the lowercased subtype, like "html", or "*".
+	Subtype string
+	// Quality is the q parameter, between 0 and 1. A quality of 0 means "not acceptable".
+	Quality float64
+	// Params are the media type parameters, like level=1, without the quality and the accept extensions after it.
+	// Names are lowercased.
+	Params map[string]string
+}
+
+// MediaType returns the media range without parameters, like "text/html".
+func (o MediaOffer) MediaType() string {
+	return o.Type + "/" + o.Subtype
+}
+
+// specificity ranks the media ranges, "*/*" < "text/*" < "text/html" < "text/html;level=1".
+func (o MediaOffer) specificity() int {
+	switch {
+	case o.Type == "*":
+		return 0
+	case o.Subtype == "*":
+		return 1
+	default:
+		return 2 + len(o.Params)
+	}
+}
+
+// ParseAccept parses the Accept header of the request (RFC 9110), and returns the media ranges
+// by order of preference: highest quality first, then most specific first, then in the order of the header.
+// Invalid media ranges are ignored. It returns nil if the header is missing.
+//
+//	Accept: text/*;q=0.5, application/json, text/html;level=1
+//	-> application/json (q=1), text/html;level=1 (q=1), text/* (q=0.5)
+func ParseAccept(header http.Header) []MediaOffer {
+	var offers []MediaOffer
+	for _, line := range header.Values("Accept") {
+		for part := range strings.SplitSeq(line, ",") {
+			if offer, ok := parseMediaOffer(part); ok {
+				offers = append(offers, offer)
+			}
+		}
+	}
+
+	slices.SortStableFunc(offers, func(a, b MediaOffer) int {
+		if c := cmp.Compare(b.Quality, a.Quality); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.specificity(), a.specificity())
+	})
+	return offers
+}
+
+func parseMediaOffer(mediaRange string) (MediaOffer, bool) {
+	mediaType, params, _ := strings.Cut(mediaRange, ";")
+	typ, subtype, found := strings.Cut(strings.ToLower(strings.TrimSpace(mediaType)), "/")
+	typ, subtype = strings.TrimSpace(typ), strings.TrimSpace(subtype)
+	if !found || typ == "" || subtype == "" || (typ == "*" && subtype != "*") {
+		return MediaOffer{}, false
+	}
+
+	offer := MediaOffer{Type: typ, Subtype: subtype, Quality: 1}
+	for param := range strings.SplitSeq(params, ";") {
+		name, value, _ := strings.Cut(param, "=")
+		name = strings.ToLower(strings.TrimSpace(name))
+		value = strings.TrimSpace(value)
+		if name == "" {
+			continue
+		}
+
+		if name == "q" {
+			quality, err := strconv.ParseFloat(value, 64)
+			if err != nil || quality < 0 || quality > 1 {
+				return MediaOffer{}, false
+			}
+			offer.Quality = quality
+			// The parameters after the quality are accept extensions.
+			break
+		}
+
+		if unquoted, err := strconv.Unquote(value); err == nil && strings.HasPrefix(value, `"`) {
+			value = unquoted
+		}
+		if offer.Params == nil {
+			offer.Params = make(map[string]string)
+		}
+		offer.Params[name] = value
+	}
+	return offer, true
+}
+
+// AcceptOffers returns the media ranges of the Accept header by order of preference, see [ParseAccept].
+func (c netHttpContext[B, P]) AcceptOffers() []MediaOffer {
+	AddVary(c.Res.Header(), "Accept")
+	return ParseAccept(c.Req.Header)
+}
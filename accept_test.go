@@ -0,0 +1,57 @@
+package fuego
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAccept(t *testing.T) {
+	t.Run("sorted by quality then specificity", func(t *testing.T) {
+		header := http.Header{"Accept": {`text/*;q=0.3, text/html;q=0.7, text/html;level=1, */*;q=0.5`, "application/json"}}
+
+		require.Equal(t, []MediaOffer{
+			{Type: "text", Subtype: "html", Quality: 1, Params: map[string]string{"level": "1"}},
+			{Type: "application", Subtype: "json", Quality: 1},
+			{Type: "text", Subtype: "html", Quality: 0.7},
+			{Type: "*", Subtype: "*", Quality: 0.5},
+			{Type: "text", Subtype: "*", Quality: 0.3},
+		}, ParseAccept(header))
+	})
+
+	t.Run("parameters", func(t *testing.T) {
+		header := http.Header{"Accept": {`Application/Vnd.API+JSON; Version="2"; Charset=utf-8; q=0.9; ext=ignored`}}
+
+		require.Equal(t, []MediaOffer{
+			{Type: "application", Subtype: "vnd.api+json", Quality: 0.9, Params: map[string]string{"version": "2", "charset": "utf-8"}},
+		}, ParseAccept(header))
+	})
+
+	t.Run("not acceptable and invalid ranges", func(t *testing.T) {
+		header := http.Header{"Accept": {"image/png;q=0, html, */json, text/plain;q=2, text/csv;q=abc, , image/webp"}}
+
+		offers := ParseAccept(header)
+		require.Len(t, offers, 2)
+		require.Equal(t, "image/webp", offers[0].MediaType())
+		require.Equal(t, "image/png", offers[1].MediaType())
+		require.Zero(t, offers[1].Quality)
+	})
+
+	t.Run("missing header", func(t *testing.T) {
+		require.Nil(t, ParseAccept(http.Header{}))
+	})
+}
+
+func TestContextAcceptOffers(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept", "application/xml;q=0.5, application/json")
+	w := httptest.NewRecorder()
+	c := NewNetHTTPContext[any, any](BaseRoute{}, w, r, readOptions{})
+
+	offers := c.AcceptOffers()
+	require.Len(t, offers, 2)
+	require.Equal(t, "application/json", offers[0].MediaType())
+	require.Equal(t, "Accept", w.Header().Get("Vary"))
+}
@@ -0,0 +1,45 @@
+package fuego
+
+import (
+	"log/slog"
+	"net/http"
+	"runtime"
+	"strconv"
+)
+
+// WithAllocationReport adds the memory allocated by each controller to the response headers,
+// to spot allocation-heavy handlers during development:
+//   - X-Alloc-Bytes: the number of bytes allocated
+//   - X-Alloc-Objects: the number of objects allocated
+//
+// Development only: reading [runtime.MemStats] stops the world twice per request,
+// and, as the counters are global, concurrent requests are counted too. Disabled by default.
+//
+//	s := fuego.NewServer(
+//		fuego.WithEngineOptions(fuego.WithAllocationReport()),
+//	)
+func WithAllocationReport() func(*Engine) {
+	return func(e *Engine) {
+		slog.Warn("Allocation report enabled: it slows down every request, do not use it in production")
+		e.allocationReport = true
+	}
+}
+
+// allocMeter measures the memory allocated since it was started, see [WithAllocationReport].
+type allocMeter struct {
+	start runtime.MemStats
+}
+
+func startAllocMeter() *allocMeter {
+	m := &allocMeter{}
+	runtime.ReadMemStats(&m.start)
+	return m
+}
+
+// report sets the allocation headers with the memory allocated since the meter was started.
+func (m *allocMeter) report(header http.Header) {
+	var end runtime.MemStats
+	runtime.ReadMemStats(&end)
+	header.Set("X-Alloc-Bytes", strconv.FormatUint(end.TotalAlloc-m.start.TotalAlloc, 10))
+	header.Set("X-Alloc-Objects", strconv.FormatUint(end.Mallocs-m.start.Mallocs, 10))
+}
@@ -0,0 +1,63 @@
+package fuego
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var allocSink [][]byte
+
+func TestWithAllocationReport(t *testing.T) {
+	controller := func(c ContextNoBody) (string, error) {
+		for range 10 {
+			allocSink = append(allocSink, make([]byte, 64<<10))
+		}
+		allocSink = nil
+		return "ok", nil
+	}
+
+	t.Run("enabled", func(t *testing.T) {
+		s := NewServer(WithEngineOptions(WithAllocationReport()))
+		Get(s, "/alloc", controller)
+
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/alloc", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		allocBytes, err := strconv.ParseUint(w.Header().Get("X-Alloc-Bytes"), 10, 64)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, allocBytes, uint64(10*64<<10))
+		allocObjects, err := strconv.ParseUint(w.Header().Get("X-Alloc-Objects"), 10, 64)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, allocObjects, uint64(10))
+	})
+
+	t.Run("also on errors", func(t *testing.T) {
+		s := NewServer(WithEngineOptions(WithAllocationReport()))
+		Get(s, "/error", func(c ContextNoBody) (string, error) {
+			return "", BadRequestError{}
+		})
+
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/error", nil))
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.NotEmpty(t, w.Header().Get("X-Alloc-Bytes"))
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		s := NewServer()
+		Get(s, "/alloc", controller)
+
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/alloc", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Empty(t, w.Header().Get("X-Alloc-Bytes"))
+		require.Empty(t, w.Header().Get("X-Alloc-Objects"))
+	})
+}
@@ -0,0 +1,178 @@
+package fuego
+
+import (
+	"cmp"
+	"mime"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// APIVersionSource is a part of the request the API version is read from, see [APIVersionConfig].
+type APIVersionSource string
+
+const (
+	// APIVersionFromPath reads the version from the first segment of the path, like /v2/recipes.
+	APIVersionFromPath APIVersionSource = "path"
+	// APIVersionFromHeader reads the version from a header, like X-Api-Version: 2.
+	APIVersionFromHeader APIVersionSource = "header"
+	// APIVersionFromAccept reads the version from a vendor media type of the Accept header, like application/vnd.myapp.v2+json.
+	APIVersionFromAccept APIVersionSource = "accept"
+)
+
+// APIVersionConfig configures how [Context.APIVersion] resolves the API version of a request.
+// The zero value reads the version from the path, the X-Api-Version header then the Accept header, and does not require it.
+type APIVersionConfig struct {
+	// Sources of the version, by order of precedence. Defaults to path, header then Accept.
+	Sources []APIVersionSource
+	// Header containing the version. Defaults to X-Api-Version.
+	Header string
+	// Vendor of the media types, "myapp" for application/vnd.myapp.v2+json. If empty, any vendor is accepted.
+	Vendor string
+	// Default version, returned when the request does not have one.
+	Default string
+	// If true, an [APIVersionError] is returned when the request does not have a version and there is no default.
+	Required bool
+	// Supported versions, without the "v" prefix. If empty, any version is accepted.
+	Supported []string
+}
+
+// WithAPIVersioning sets how [Context.APIVersion] resolves the API version of the requests.
+//
+//	s := fuego.NewServer(
+//		fuego.WithAPIVersioning(fuego.APIVersionConfig{
+//			Vendor:    "myapp",
+//			Required:  true,
+//			Supported: []string{"1", "2"},
+//		}),
+//	)
+func WithAPIVersioning(config APIVersionConfig) func(*Server) {
+	return func(s *Server) { s.apiVersioning = config }
+}
+
+// APIVersionFromRequest returns the API version of the request, without the "v" prefix: "2" for /v2/recipes.
+// The sources of the config are read by order of precedence, see [APIVersionConfig].
+// It returns an [APIVersionError] if the version is required but absent, or not supported.
+func APIVersionFromRequest(r *http.Request, config APIVersionConfig) (string, error) {
+	sources := config.Sources
+	if len(sources) == 0 {
+		sources = []APIVersionSource{APIVersionFromPath, APIVersionFromHeader, APIVersionFromAccept}
+	}
+
+	version := ""
+	for _, source := range sources {
+		switch source {
+		case APIVersionFromPath:
+			version = pathAPIVersion(r.URL.Path)
+		case APIVersionFromHeader:
+			version = trimVersionPrefix(strings.TrimSpace(r.Header.Get(cmp.Or(config.Header, "X-Api-Version"))))
+		case APIVersionFromAccept:
+			version = acceptAPIVersion(r.Header.Values("Accept"), config.Vendor)
+		}
+		if version != "" {
+			break
+		}
+	}
+
+	if version == "" {
+		version = config.Default
+	}
+	if version == "" {
+		if config.Required {
+			return "", APIVersionError{
+				Title:  "Missing API version",
+				Detail: "the request must specify an API version, like /v1/... or " + cmp.Or(config.Header, "X-Api-Version") + ": 1",
+			}
+		}
+		return "", nil
+	}
+
+	if len(config.Supported) > 0 && !slices.Contains(config.Supported, version) {
+		return "", APIVersionError{
+			Title:  "Unsupported API version",
+			Detail: "API version " + version + " is not supported, supported versions: " + strings.Join(config.Supported, ", "),
+		}
+	}
+	return version, nil
+}
+
+// pathAPIVersion returns the version of a path starting with a version segment, like /v2/recipes.
+func pathAPIVersion(path string) string {
+	segment, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	version := strings.TrimPrefix(segment, "v")
+	if version == segment || !isAPIVersionNumber(version) {
+		return ""
+	}
+	return version
+}
+
+// acceptAPIVersion returns the version of the first vendor media type with a version, like application/vnd.myapp.v2+json.
+func acceptAPIVersion(accept []string, vendor string) string {
+	for _, header := range accept {
+		for mediaRange := range strings.SplitSeq(header, ",") {
+			mediaType, _, err := mime.ParseMediaType(mediaRange)
+			if err != nil {
+				continue
+			}
+			subtype, ok := strings.CutPrefix(mediaType, "application/vnd.")
+			if !ok {
+				continue
+			}
+			subtype, _, _ = strings.Cut(subtype, "+") // +json suffix
+			i := strings.LastIndex(subtype, ".v")
+			if i < 0 || (vendor != "" && subtype[:i] != vendor) {
+				continue
+			}
+			if version := subtype[i+len(".v"):]; isAPIVersionNumber(version) {
+				return version
+			}
+		}
+	}
+	return ""
+}
+
+// apiVersionHeaders returns the request headers the API version is read from, for the Vary header.
+func apiVersionHeaders(config APIVersionConfig) []string {
+	sources := config.Sources
+	if len(sources) == 0 {
+		sources = []APIVersionSource{APIVersionFromPath, APIVersionFromHeader, APIVersionFromAccept}
+	}
+
+	var headers []string
+	for _, source := range sources {
+		switch source {
+		case APIVersionFromHeader:
+			headers = append(headers, cmp.Or(config.Header, "X-Api-Version"))
+		case APIVersionFromAccept:
+			headers = append(headers, "Accept")
+		}
+	}
+	return headers
+}
+
+// isAPIVersionNumber reports whether the version is made of dot-separated numbers, like 2 or 2.1.
+func isAPIVersionNumber(version string) bool {
+	if version == "" {
+		return false
+	}
+	for part := range strings.SplitSeq(version, ".") {
+		if part == "" || strings.Trim(part, "0123456789") != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// trimVersionPrefix removes the "v" prefix of a version number: v2 becomes 2.
+func trimVersionPrefix(version string) string {
+	if trimmed, ok := strings.CutPrefix(strings.ToLower(version), "v"); ok && isAPIVersionNumber(trimmed) {
+		return trimmed
+	}
+	return version
+}
+
+// APIVersion returns the API version of the request, see [APIVersionFromRequest].
+func (c netHttpContext[B, P]) APIVersion() (string, error) {
+	AddVary(c.Res.Header(), apiVersionHeaders(c.apiVersioning)...)
+	return APIVersionFromRequest(c.Req, c.apiVersioning)
+}
@@ -0,0 +1,144 @@
+package fuego
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIVersionFromRequest(t *testing.T) {
+	newRequest := func(target string, header http.Header) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, target, nil)
+		for key, values := range header {
+			r.Header[key] = values
+		}
+		return r
+	}
+
+	t.Run("reads each source", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			request  *http.Request
+			config   APIVersionConfig
+			expected string
+		}{
+			{"path", newRequest("/v2/recipes", nil), APIVersionConfig{}, "2"},
+			{"path with minor version", newRequest("/v2.1", nil), APIVersionConfig{}, "2.1"},
+			{"path not starting with a version", newRequest("/recipes/v2", nil), APIVersionConfig{}, ""},
+			{"path with a non-numeric segment", newRequest("/videos/1", nil), APIVersionConfig{}, ""},
+			{"header", newRequest("/recipes", http.Header{"X-Api-Version": {"3"}}), APIVersionConfig{}, "3"},
+			{"header with prefix", newRequest("/recipes", http.Header{"X-Api-Version": {"v3"}}), APIVersionConfig{}, "3"},
+			{"header with date version", newRequest("/recipes", http.Header{"X-Api-Version": {"2024-01-01"}}), APIVersionConfig{}, "2024-01-01"},
+			{"custom header", newRequest("/recipes", http.Header{"Api-Version": {"3"}}), APIVersionConfig{Header: "Api-Version"}, "3"},
+			{"accept", newRequest("/recipes", http.Header{"Accept": {"text/html, application/vnd.myapp.v4+json;q=0.9"}}), APIVersionConfig{}, "4"},
+			{"accept without suffix", newRequest("/recipes", http.Header{"Accept": {"application/vnd.myapp.v4"}}), APIVersionConfig{}, "4"},
+			{"accept with matching vendor", newRequest("/recipes", http.Header{"Accept": {"application/vnd.other.v1+json, application/vnd.myapp.v4+json"}}), APIVersionConfig{Vendor: "myapp"}, "4"},
+			{"accept with other vendor", newRequest("/recipes", http.Header{"Accept": {"application/vnd.other.v1+json"}}), APIVersionConfig{Vendor: "myapp"}, ""},
+			{"default", newRequest("/recipes", nil), APIVersionConfig{Default: "1"}, "1"},
+			{"none", newRequest("/recipes", nil), APIVersionConfig{}, ""},
+		}
+
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				version, err := APIVersionFromRequest(tc.request, tc.config)
+				require.NoError(t, err)
+				require.Equal(t, tc.expected, version)
+			})
+		}
+	})
+
+	t.Run("precedence order", func(t *testing.T) {
+		r := newRequest("/v2/recipes", http.Header{
+			"X-Api-Version": {"3"},
+			"Accept":        {"application/vnd.myapp.v4+json"},
+		})
+
+		version, err := APIVersionFromRequest(r, APIVersionConfig{})
+		require.NoError(t, err)
+		require.Equal(t, "2", version, "path first by default")
+
+		version, err = APIVersionFromRequest(r, APIVersionConfig{Sources: []APIVersionSource{APIVersionFromAccept, APIVersionFromHeader}})
+		require.NoError(t, err)
+		require.Equal(t, "4", version)
+
+		version, err = APIVersionFromRequest(r, APIVersionConfig{Sources: []APIVersionSource{APIVersionFromHeader, APIVersionFromPath}})
+		require.NoError(t, err)
+		require.Equal(t, "3", version)
+
+		version, err = APIVersionFromRequest(newRequest("/recipes", http.Header{"Accept": {"application/vnd.myapp.v4+json"}}), APIVersionConfig{})
+		require.NoError(t, err)
+		require.Equal(t, "4", version, "next source when absent from the first ones")
+
+		version, err = APIVersionFromRequest(r, APIVersionConfig{Sources: []APIVersionSource{APIVersionFromHeader}, Header: "Api-Version", Default: "1"})
+		require.NoError(t, err)
+		require.Equal(t, "1", version, "other sources are not read")
+	})
+
+	t.Run("missing required version", func(t *testing.T) {
+		_, err := APIVersionFromRequest(newRequest("/recipes", nil), APIVersionConfig{Required: true})
+
+		var versionErr APIVersionError
+		require.ErrorAs(t, err, &versionErr)
+		require.Equal(t, "Missing API version", versionErr.Title)
+		require.Equal(t, http.StatusBadRequest, versionErr.StatusCode())
+
+		version, err := APIVersionFromRequest(newRequest("/recipes", nil), APIVersionConfig{Required: true, Default: "1"})
+		require.NoError(t, err)
+		require.Equal(t, "1", version)
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		config := APIVersionConfig{Supported: []string{"1", "2"}}
+
+		version, err := APIVersionFromRequest(newRequest("/v2/recipes", nil), config)
+		require.NoError(t, err)
+		require.Equal(t, "2", version)
+
+		_, err = APIVersionFromRequest(newRequest("/v3/recipes", nil), config)
+		var versionErr APIVersionError
+		require.ErrorAs(t, err, &versionErr)
+		require.Equal(t, "Unsupported API version", versionErr.Title)
+	})
+}
+
+func TestContextAPIVersion(t *testing.T) {
+	s := NewServer(WithAPIVersioning(APIVersionConfig{Required: true}))
+	Get(s, "/v1/recipes", func(c ContextNoBody) (string, error) {
+		return c.APIVersion()
+	})
+	Get(s, "/recipes", func(c ContextNoBody) (string, error) {
+		return c.APIVersion()
+	})
+
+	t.Run("returns the version", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/recipes", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "1", w.Body.String())
+	})
+
+	t.Run("returns a 400 when the version is required", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/recipes", nil))
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Contains(t, w.Body.String(), "Missing API version")
+	})
+
+	t.Run("mock context", func(t *testing.T) {
+		c := NewMockContextNoBody()
+		c.Headers.Set("X-Api-Version", "2")
+
+		version, err := c.APIVersion()
+		require.NoError(t, err)
+		require.Equal(t, "2", version)
+
+		c.APIVersioning = APIVersionConfig{Sources: []APIVersionSource{APIVersionFromPath}, Required: true}
+		_, err = c.APIVersion()
+		require.True(t, errors.As(err, &APIVersionError{}))
+	})
+}
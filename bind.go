@@ -0,0 +1,471 @@
+package fuego
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// ContextWithQueryParams is the subset of [Context] needed to bind query parameters.
+type ContextWithQueryParams interface {
+	QueryParams() url.Values
+}
+
+// BindableCtx is the subset of [Context] needed to bind parameters, see [BindParams].
+type BindableCtx interface {
+	ContextWithQueryParams
+	GetOpenAPIParams() map[string]OpenAPIParam
+	PathParam(name string) string
+	Header(key string) string
+	Cookie(name string) (*http.Cookie, error)
+	Method() string
+}
+
+// BindParams binds the parameters of the request into the exported fields of P, a struct, according to their tags:
+//   - `query:"name"` for query params. Several names can be given for renamed params (`query:"page_size,limit"`),
+//     slices receive all the values (?tags=a&tags=b), map[string]string fields receive prefixed params
+//     (?filter[status]=open for `query:"filter"`), struct fields receive object params sent with the OpenAPI
+//     deepObject style (?range[min]=1&range[max]=10), and a map[string]string tagged `query:"*"` receives
+//     the params not bound to another field.
+//   - `header:"Name"` for headers. Slices receive the comma-separated values.
+//   - `path:"name"` for path params, like {name} in the route path.
+//   - `cookie:"name"` for cookie values.
+//   - `method:""` for the HTTP method of the request, like "GET", in a string field.
+//     Useful for handlers shared by several routes.
+//
+// Fields can be strings, booleans, integers, floats, or slices of them.
+// When a parameter is absent or empty, the `default:"value"` tag of the field is used, then the default
+// declared for the route, see [ParamDefault]. A missing parameter without default fails if the field is tagged
+// `required:"true"`, otherwise the field is left to its zero value.
+// It returns a [BadRequestError] if a required parameter is missing or a value cannot be converted,
+// and a plain error if P is not a struct or has unsupported field types.
+// The `default`, `required` and `description` tags are also used to document the parameters in the OpenAPI spec.
+//
+//	type RecipeParams struct {
+//		ID    int      `path:"id"`
+//		Page  int      `query:"page" default:"1"`
+//		Tags  []string `query:"tags"`
+//		Token string   `header:"X-Token" required:"true"`
+//	}
+//
+//	fuego.Get(s, "/recipes/{id}", func(c fuego.ContextWithParams[RecipeParams]) (Recipe, error) {
+//		params, err := fuego.BindParams[RecipeParams](c) // or c.Params()
+//		...
+//	})
+func BindParams[P any](c BindableCtx) (P, error) {
+	p := new(P)
+
+	paramsType := reflect.TypeOf(p).Elem()
+	if paramsType.Kind() != reflect.Struct {
+		return *p, fmt.Errorf("params must be a struct, got %T", *p)
+	}
+	paramsValue := reflect.ValueOf(p).Elem()
+
+	for i := range paramsType.NumField() {
+		field := paramsType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if _, ok := field.Tag.Lookup("method"); ok {
+			if field.Type.Kind() != reflect.String {
+				return *p, fmt.Errorf("unsupported type %s for the method of field %s, expected a string", field.Type, field.Name)
+			}
+			paramsValue.Field(i).SetString(c.Method())
+			continue
+		}
+		if err := bindParam(c, paramsType, field, paramsValue.Field(i)); err != nil {
+			return *p, err
+		}
+	}
+
+	return *p, nil
+}
+
+// bindParam binds the parameter of the given field, see [BindParams].
+func bindParam(c BindableCtx, paramsType reflect.Type, field reflect.StructField, fieldValue reflect.Value) error {
+	location, tag := paramLocation(field)
+	if location == "" {
+		return nil
+	}
+
+	if field.Type.Kind() == reflect.Map {
+		if location != QueryParamType || !isQueryParamsMap(field.Type) {
+			return fmt.Errorf("unsupported type %s for %s param %s", field.Type, location, tag)
+		}
+		setQueryParamsMap(fieldValue, tag, c.QueryParams(), paramsType)
+		return nil
+	}
+	if field.Type.Kind() == reflect.Struct {
+		if location != QueryParamType || !isQueryParamsObject(field.Type) {
+			return fmt.Errorf("unsupported type %s for %s param %s", field.Type, location, tag)
+		}
+		return bindQueryParamsObject(c, field, fieldValue, tag)
+	}
+	if !isSupportedParamType(field.Type) {
+		return fmt.Errorf("unsupported type %s for %s param %s", field.Type, location, tag)
+	}
+
+	name := tag
+	var paramValues []string
+	switch location {
+	case QueryParamType:
+		aliases := queryParamAliases(tag)
+		name = aliases[0]
+		paramValues, _ = lookupQueryParam(c.QueryParams(), aliases)
+	case HeaderParamType:
+		if header := c.Header(tag); header != "" {
+			paramValues = []string{header}
+			if field.Type.Kind() == reflect.Slice {
+				paramValues = splitHeaderValues(header)
+			}
+		}
+	case PathParamType:
+		if param := c.PathParam(tag); param != "" {
+			paramValues = []string{param}
+		}
+	case CookieParamType:
+		if cookie, err := c.Cookie(tag); err == nil && cookie.Value != "" {
+			paramValues = []string{cookie.Value}
+		}
+	}
+
+	if len(paramValues) == 0 || (len(paramValues) == 1 && paramValues[0] == "") {
+		defaultValue, ok := paramDefault(c, field, name)
+		if !ok {
+			if field.Tag.Get("required") == "true" {
+				err := fmt.Errorf("%s is a required %s param", name, location)
+				return BadRequestError{
+					Title:  "Missing Parameter",
+					Err:    err,
+					Detail: "cannot parse request parameter: " + err.Error(),
+				}
+			}
+			return nil
+		}
+		paramValues = []string{defaultValue}
+		if field.Type.Kind() == reflect.Slice {
+			paramValues = splitHeaderValues(defaultValue)
+		}
+	}
+
+	var err error
+	switch field.Type.Kind() {
+	case reflect.Slice:
+		err = setSliceParamValue(fieldValue, paramValues)
+	default:
+		err = setParamValue(fieldValue, paramValues[0], field.Type.Kind())
+	}
+	if err != nil {
+		return BadRequestError{
+			Title:  "Invalid Parameter",
+			Err:    err,
+			Detail: fmt.Sprintf("%s param %s=%s is not of type %s", location, name, strings.Join(paramValues, ","), field.Type),
+		}
+	}
+	return nil
+}
+
+// bindQueryParamsObject binds a struct field from the prefix[property] query params, see [setQueryParamsObject].
+func bindQueryParamsObject(c BindableCtx, field reflect.StructField, fieldValue reflect.Value, tag string) error {
+	found, err := setQueryParamsObject(fieldValue, queryParamAliases(tag), c.QueryParams())
+	if err != nil {
+		return BadRequestError{
+			Title:  "Invalid Parameter",
+			Err:    err,
+			Detail: "query param " + err.Error(),
+		}
+	}
+	if !found && field.Tag.Get("required") == "true" {
+		err := fmt.Errorf("%s is a required query param", queryParamAliases(tag)[0])
+		return BadRequestError{
+			Title:  "Missing Parameter",
+			Err:    err,
+			Detail: "cannot parse request parameter: " + err.Error(),
+		}
+	}
+	return nil
+}
+
+// isSupportedParamType reports whether a parameter can be converted to the type, see [setParamValue].
+func isSupportedParamType(t reflect.Type) bool {
+	if t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// paramLocation returns the location of the parameter bound to the field, and the tag giving its name.
+func paramLocation(field reflect.StructField) (ParamType, string) {
+	for _, location := range []ParamType{QueryParamType, HeaderParamType, PathParamType, CookieParamType} {
+		if tag := field.Tag.Get(string(location)); tag != "" {
+			return location, tag
+		}
+	}
+	return "", ""
+}
+
+// paramDefault returns the default value of a parameter: the default tag of the field, or the default declared for the route.
+func paramDefault(c BindableCtx, field reflect.StructField, name string) (string, bool) {
+	if defaultValue, ok := field.Tag.Lookup("default"); ok {
+		return defaultValue, true
+	}
+	if param, ok := c.GetOpenAPIParams()[name]; ok && param.Default != nil {
+		return fmt.Sprint(param.Default), true
+	}
+	return "", false
+}
+
+// splitHeaderValues splits a comma-separated list of values, like "a, b,c".
+func splitHeaderValues(header string) []string {
+	values := strings.Split(header, ",")
+	for i := range values {
+		values[i] = strings.TrimSpace(values[i])
+	}
+	return values
+}
+
+// queryParamAliases returns the names of a query tag. Several names can be given for backward compatibility
+// when renaming a parameter, like `query:"page_size,perPage,limit"`. The first name is the documented one.
+func queryParamAliases(tag string) []string {
+	aliases := strings.Split(tag, ",")
+	for i := range aliases {
+		aliases[i] = strings.TrimSpace(aliases[i])
+	}
+	return aliases
+}
+
+// lookupQueryParam returns the values of the first of the given names present in the query parameters.
+func lookupQueryParam(queryParams url.Values, aliases []string) ([]string, bool) {
+	for _, alias := range aliases {
+		if values, ok := queryParams[alias]; ok && alias != "" {
+			return values, true
+		}
+	}
+	return nil, false
+}
+
+// queryCatchAllTag is the query tag of a map[string]string field receiving all the query parameters
+// not bound to another field, like `query:"*"`.
+const queryCatchAllTag = "*"
+
+// isQueryParamsMap reports whether the type can receive several query parameters, i.e. is a map[string]string.
+// A map field tagged `query:"filter"` receives the parameters named filter[...], keyed by the bracket content:
+// ?filter[status]=open&filter[author]=ewen gives map[status:open author:ewen].
+func isQueryParamsMap(t reflect.Type) bool {
+	return t.Kind() == reflect.Map && t.Key().Kind() == reflect.String && t.Elem().Kind() == reflect.String
+}
+
+// bracketedQueryParamKey returns the key of a query parameter named prefix[key].
+func bracketedQueryParamKey(name, prefix string) (string, bool) {
+	key, ok := strings.CutPrefix(name, prefix+"[")
+	if !ok || !strings.HasSuffix(key, "]") {
+		return "", false
+	}
+	return strings.TrimSuffix(key, "]"), true
+}
+
+// isBoundQueryParam reports whether the query parameter is bound to a field of the struct type,
+// other than the catch-all field.
+func isBoundQueryParam(paramsType reflect.Type, name string) bool {
+	for i := range paramsType.NumField() {
+		field := paramsType.Field(i)
+		tag := field.Tag.Get("query")
+		if tag == "" || tag == queryCatchAllTag {
+			continue
+		}
+		for _, alias := range queryParamAliases(tag) {
+			if isQueryParamsMap(field.Type) || isQueryParamsObject(field.Type) {
+				if _, ok := bracketedQueryParamKey(name, alias); ok {
+					return true
+				}
+			} else if name == alias {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// setQueryParamsMap sets the query parameters matching the tag to a map[string]string field:
+// the filter[...] parameters for `query:"filter"`, or the parameters not bound to another field of the struct type
+// for `query:"*"`. Only the first value of each parameter is kept. The map is left nil if no parameter matches.
+func setQueryParamsMap(value reflect.Value, tag string, queryParams url.Values, paramsType reflect.Type) {
+	matches := reflect.MakeMap(value.Type())
+	for name, paramValues := range queryParams {
+		if len(paramValues) == 0 {
+			continue
+		}
+
+		key := name
+		if tag == queryCatchAllTag {
+			if isBoundQueryParam(paramsType, name) {
+				continue
+			}
+		} else {
+			var ok bool
+			for _, alias := range queryParamAliases(tag) {
+				if key, ok = bracketedQueryParamKey(name, alias); ok {
+					break
+				}
+			}
+			if !ok {
+				continue
+			}
+		}
+		matches.SetMapIndex(reflect.ValueOf(key).Convert(value.Type().Key()), reflect.ValueOf(paramValues[0]).Convert(value.Type().Elem()))
+	}
+
+	if matches.Len() > 0 {
+		value.Set(matches)
+	}
+}
+
+// isQueryParamsObject reports whether the type can receive an object query parameter, sent with the OpenAPI
+// deepObject style: a struct whose exported fields are all supported param types, see [setQueryParamsObject].
+func isQueryParamsObject(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	exported := 0
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if !isSupportedParamType(field.Type) {
+			return false
+		}
+		exported++
+	}
+	return exported > 0
+}
+
+// queryObjectPropertyName returns the name of the property bound to a field of an object query parameter:
+// its query tag, else its JSON name, else its Go name.
+func queryObjectPropertyName(field reflect.StructField) string {
+	if name := field.Tag.Get("query"); name != "" {
+		return name
+	}
+	if name, _, _ := strings.Cut(field.Tag.Get("json"), ","); name != "" && name != "-" {
+		return name
+	}
+	return field.Name
+}
+
+// setQueryParamsObject sets the fields of a struct from the query parameters named prefix[property],
+// as sent with the OpenAPI deepObject style: ?filter[name]=x&filter[age]=1 for `query:"filter"`.
+// All the fields are set even if one cannot be converted: the first conversion error is returned.
+// It reports whether a property of the object was found in the query.
+func setQueryParamsObject(value reflect.Value, aliases []string, queryParams url.Values) (bool, error) {
+	found := false
+	var firstErr error
+	for i := range value.NumField() {
+		field := value.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		property := queryObjectPropertyName(field)
+		var paramValues []string
+		var name string
+		for _, alias := range aliases {
+			name = alias + "[" + property + "]"
+			if paramValues = queryParams[name]; len(paramValues) > 0 {
+				break
+			}
+		}
+		if len(paramValues) == 0 {
+			continue
+		}
+		found = true
+
+		var err error
+		if field.Type.Kind() == reflect.Slice {
+			err = setSliceParamValue(value.Field(i), paramValues)
+		} else {
+			err = setParamValue(value.Field(i), paramValues[0], field.Type.Kind())
+		}
+		if err != nil {
+			value.Field(i).SetZero()
+			if firstErr == nil {
+				firstErr = fmt.Errorf("%s=%s is not of type %s", name, strings.Join(paramValues, ","), field.Type)
+			}
+		}
+	}
+	return found, firstErr
+}
+
+// BindQueryOrDefault binds the query parameters of the request into the fields of T tagged with `query:"name"`.
+// Contrary to [Context.Params], it never fails: a field whose value cannot be converted
+// (for example ?page=abc for an int) is left to its zero value, and the other fields are still bound.
+// Useful for lenient public APIs that prefer ignoring garbage sent by clients over returning a 400.
+// If T is not a struct, the zero value of T is returned.
+//
+//	type Filters struct {
+//		Page   int               `query:"page"`
+//		Tags   []string          `query:"tags"`
+//		Filter map[string]string `query:"filter"` // ?filter[status]=open
+//		Range  struct {
+//			Min int `query:"min"`
+//			Max int `query:"max"`
+//		} `query:"range"` // ?range[min]=1&range[max]=10
+//		Others map[string]string `query:"*"` // all the other query params
+//	}
+//
+//	fuego.Get(s, "/recipes", func(c fuego.ContextNoBody) ([]Recipe, error) {
+//		filters := fuego.BindQueryOrDefault[Filters](c)
+//		...
+//	})
+func BindQueryOrDefault[T any](c ContextWithQueryParams) T {
+	var params T
+
+	paramsValue := reflect.ValueOf(&params).Elem()
+	if paramsValue.Kind() != reflect.Struct {
+		return params
+	}
+
+	queryParams := c.QueryParams()
+	for i := range paramsValue.NumField() {
+		field := paramsValue.Type().Field(i)
+		tag := field.Tag.Get("query")
+		if tag == "" || !field.IsExported() {
+			continue
+		}
+
+		fieldValue := paramsValue.Field(i)
+		if isQueryParamsMap(field.Type) {
+			setQueryParamsMap(fieldValue, tag, queryParams, paramsValue.Type())
+			continue
+		}
+		if isQueryParamsObject(field.Type) {
+			_, _ = setQueryParamsObject(fieldValue, queryParamAliases(tag), queryParams)
+			continue
+		}
+
+		paramValues, _ := lookupQueryParam(queryParams, queryParamAliases(tag))
+		if len(paramValues) == 0 {
+			continue
+		}
+
+		var err error
+		if field.Type.Kind() == reflect.Slice {
+			err = setSliceParamValue(fieldValue, paramValues)
+		} else {
+			err = setParamValue(fieldValue, paramValues[0], field.Type.Kind())
+		}
+		if err != nil {
+			fieldValue.SetZero()
+		}
+	}
+
+	return params
+}
@@ -0,0 +1,344 @@
+package fuego
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type lenientParams struct {
+	Name    string   `query:"name"`
+	Page    int      `query:"page"`
+	Ratio   float64  `query:"ratio"`
+	Active  bool     `query:"active"`
+	IDs     []int    `query:"ids"`
+	Tags    []string `query:"tags"`
+	NotInQs string
+}
+
+func TestBindQueryOrDefault(t *testing.T) {
+	t.Run("binds valid params", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/?name=john&page=2&ratio=0.5&active=true&ids=1&ids=2&tags=a&tags=b", nil)
+		c := NewNetHTTPContext[any, any](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+
+		params := BindQueryOrDefault[lenientParams](c)
+		require.Equal(t, lenientParams{
+			Name:   "john",
+			Page:   2,
+			Ratio:  0.5,
+			Active: true,
+			IDs:    []int{1, 2},
+			Tags:   []string{"a", "b"},
+		}, params)
+	})
+
+	t.Run("binds aliases", func(t *testing.T) {
+		type aliasedParams struct {
+			PageSize int `query:"page_size,perPage,limit"`
+		}
+
+		r := httptest.NewRequest("GET", "/?limit=50", nil)
+		c := NewNetHTTPContext[any, any](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+		require.Equal(t, 50, BindQueryOrDefault[aliasedParams](c).PageSize)
+
+		r = httptest.NewRequest("GET", "/?limit=50&perPage=20", nil)
+		c = NewNetHTTPContext[any, any](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+		require.Equal(t, 20, BindQueryOrDefault[aliasedParams](c).PageSize)
+	})
+
+	t.Run("binds prefixed params and the other params into maps", func(t *testing.T) {
+		type filteredParams struct {
+			Page    int               `query:"page"`
+			Filters map[string]string `query:"filter"`
+			Others  map[string]string `query:"*"`
+		}
+
+		r := httptest.NewRequest("GET", "/?page=abc&filter[status]=open&filter[status]=closed&filter[tag]=go&search=pizza", nil)
+		c := NewNetHTTPContext[any, any](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+
+		params := BindQueryOrDefault[filteredParams](c)
+		require.Equal(t, filteredParams{
+			Filters: map[string]string{"status": "open", "tag": "go"},
+			Others:  map[string]string{"search": "pizza"},
+		}, params)
+	})
+
+	t.Run("leaves invalid params to their zero value", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/?name=john&page=abc&ratio=0.5&active=maybe&ids=1&ids=two&tags=a", nil)
+		c := NewNetHTTPContext[any, any](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+
+		params := BindQueryOrDefault[lenientParams](c)
+		require.Equal(t, lenientParams{
+			Name:  "john",
+			Ratio: 0.5,
+			Tags:  []string{"a"},
+		}, params)
+	})
+
+	t.Run("strict Params fails on the same request", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/?name=john&page=abc", nil)
+		c := NewNetHTTPContext[any, lenientParams](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+
+		_, err := c.Params()
+		require.Error(t, err)
+
+		params := BindQueryOrDefault[lenientParams](c)
+		require.Equal(t, "john", params.Name)
+		require.Zero(t, params.Page)
+	})
+
+	t.Run("works with the mock context", func(t *testing.T) {
+		c := NewMockContextNoBody()
+		c.SetQueryParam("name", "john").SetQueryParam("page", "-")
+
+		params := BindQueryOrDefault[lenientParams](c)
+		require.Equal(t, lenientParams{Name: "john"}, params)
+	})
+
+	t.Run("returns the zero value for non-struct types", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/?name=john", nil)
+		c := NewNetHTTPContext[any, any](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+
+		require.Empty(t, BindQueryOrDefault[string](c))
+		require.Nil(t, BindQueryOrDefault[*lenientParams](c))
+	})
+}
+
+type boundParams struct {
+	ID      int               `path:"id"`
+	Page    int               `query:"page" default:"1"`
+	Limit   int               `query:"page_size,limit"`
+	Tags    []string          `query:"tags"`
+	Filters map[string]string `query:"filter"`
+	Token   string            `header:"X-Token" required:"true"`
+	Langs   []string          `header:"Accept-Language"`
+	Session string            `cookie:"session"`
+	Sort    string            `query:"sort"`
+	hidden  string            `query:"hidden"`
+}
+
+func TestBindParams(t *testing.T) {
+	bind := func(t *testing.T, url string, setup func(r *http.Request)) (boundParams, error) {
+		t.Helper()
+		s := NewServer()
+		var params boundParams
+		var err error
+		Get(s, "/recipes/{id}", func(c ContextWithParams[boundParams]) (any, error) {
+			params, err = BindParams[boundParams](c)
+			return nil, nil
+		})
+
+		r := httptest.NewRequest(http.MethodGet, url, nil)
+		if setup != nil {
+			setup(r)
+		}
+		s.Mux.ServeHTTP(httptest.NewRecorder(), r)
+		return params, err
+	}
+
+	withToken := func(r *http.Request) { r.Header.Set("X-Token", "secret") }
+
+	t.Run("binds all tag types", func(t *testing.T) {
+		params, err := bind(t, "/recipes/42?page=3&limit=20&tags=a&tags=b&filter[status]=open&sort=date&hidden=x", func(r *http.Request) {
+			withToken(r)
+			r.Header.Set("Accept-Language", "fr, en")
+			r.AddCookie(&http.Cookie{Name: "session", Value: "abc"})
+		})
+		require.NoError(t, err)
+		require.Equal(t, boundParams{
+			ID:      42,
+			Page:    3,
+			Limit:   20,
+			Tags:    []string{"a", "b"},
+			Filters: map[string]string{"status": "open"},
+			Token:   "secret",
+			Langs:   []string{"fr", "en"},
+			Session: "abc",
+			Sort:    "date",
+		}, params)
+	})
+
+	t.Run("uses the default tag, then the route default", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		withToken(r)
+		route := BaseRoute{Params: map[string]OpenAPIParam{
+			"page": {Name: "page", Default: 10},
+			"sort": {Name: "sort", Default: "name"},
+		}}
+		c := NewNetHTTPContext[any, boundParams](route, httptest.NewRecorder(), r, readOptions{})
+		params, err := BindParams[boundParams](c)
+		require.NoError(t, err)
+		require.Equal(t, 1, params.Page)
+		require.Equal(t, "name", params.Sort)
+		require.Zero(t, params.Limit)
+		require.Empty(t, params.Session)
+	})
+
+	t.Run("missing required param", func(t *testing.T) {
+		c := NewNetHTTPContext[any, boundParams](BaseRoute{}, httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil), readOptions{})
+		_, err := BindParams[boundParams](c)
+		var badRequest BadRequestError
+		require.ErrorAs(t, err, &badRequest)
+		require.Equal(t, "Missing Parameter", badRequest.Title)
+		require.Contains(t, badRequest.Detail, "X-Token is a required header param")
+	})
+
+	t.Run("documents the default and required tags", func(t *testing.T) {
+		s := NewServer()
+		route := Get(s, "/recipes/{id}", func(c ContextWithParams[boundParams]) (any, error) { return nil, nil })
+		require.Equal(t, 1, route.Params["page"].Default)
+		require.True(t, route.Params["X-Token"].Required)
+		require.False(t, route.Params["tags"].Required)
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		_, err := bind(t, "/recipes/1?page=abc", withToken)
+		var badRequest BadRequestError
+		require.ErrorAs(t, err, &badRequest)
+		require.Equal(t, "Invalid Parameter", badRequest.Title)
+		require.Equal(t, "query param page=abc is not of type int", badRequest.Detail)
+	})
+
+	t.Run("invalid path param", func(t *testing.T) {
+		_, err := bind(t, "/recipes/abc", withToken)
+		var badRequest BadRequestError
+		require.ErrorAs(t, err, &badRequest)
+		require.Equal(t, "path param id=abc is not of type int", badRequest.Detail)
+	})
+
+	t.Run("Params delegates to BindParams", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/?page=2&tags=x", nil)
+		r.Header.Set("X-Token", "secret")
+		c := NewNetHTTPContext[any, boundParams](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+		params, err := c.Params()
+		require.NoError(t, err)
+		require.Equal(t, 2, params.Page)
+		require.Equal(t, []string{"x"}, params.Tags)
+		require.Equal(t, "secret", params.Token)
+	})
+
+	t.Run("not a struct", func(t *testing.T) {
+		c := NewNetHTTPContext[any, string](BaseRoute{}, httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil), readOptions{})
+		_, err := BindParams[string](c)
+		require.EqualError(t, err, "params must be a struct, got string")
+	})
+
+	t.Run("unsupported field type", func(t *testing.T) {
+		type unsupported struct {
+			Since struct{} `query:"since"`
+		}
+		c := NewNetHTTPContext[any, unsupported](BaseRoute{}, httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/?since=x", nil), readOptions{})
+		_, err := BindParams[unsupported](c)
+		require.Error(t, err)
+		require.NotErrorAs(t, err, &BadRequestError{})
+	})
+}
+
+type priceRange struct {
+	Min int `query:"min"`
+	Max int `json:"max"`
+}
+
+type objectParams struct {
+	Range  priceRange        `query:"range" required:"true"`
+	Others map[string]string `query:"*"`
+}
+
+func TestBindParams_DeepObject(t *testing.T) {
+	bind := func(t *testing.T, query string) (objectParams, error) {
+		t.Helper()
+		c := NewNetHTTPContext[any, objectParams](BaseRoute{}, httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/"+query, nil), readOptions{})
+		return BindParams[objectParams](c)
+	}
+
+	t.Run("binds a two-field object", func(t *testing.T) {
+		params, err := bind(t, "?range[min]=1&range[max]=10&sort=price")
+		require.NoError(t, err)
+		require.Equal(t, priceRange{Min: 1, Max: 10}, params.Range)
+		require.Equal(t, map[string]string{"sort": "price"}, params.Others)
+	})
+
+	t.Run("partial object", func(t *testing.T) {
+		params, err := bind(t, "?range[max]=10")
+		require.NoError(t, err)
+		require.Equal(t, priceRange{Max: 10}, params.Range)
+	})
+
+	t.Run("invalid property", func(t *testing.T) {
+		_, err := bind(t, "?range[min]=abc&range[max]=10")
+		var badRequest BadRequestError
+		require.ErrorAs(t, err, &badRequest)
+		require.Equal(t, "query param range[min]=abc is not of type int", badRequest.Detail)
+	})
+
+	t.Run("missing required object", func(t *testing.T) {
+		_, err := bind(t, "?range=1")
+		var badRequest BadRequestError
+		require.ErrorAs(t, err, &badRequest)
+		require.Equal(t, "Missing Parameter", badRequest.Title)
+	})
+
+	t.Run("lenient binding", func(t *testing.T) {
+		c := NewNetHTTPContext[any, any](BaseRoute{}, httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/?range[min]=abc&range[max]=10", nil), readOptions{})
+		params := BindQueryOrDefault[objectParams](c)
+		require.Equal(t, priceRange{Max: 10}, params.Range)
+		require.Nil(t, params.Others)
+	})
+
+	t.Run("registered as a deepObject in the spec", func(t *testing.T) {
+		s := NewServer()
+		route := Get(s, "/products", func(c ContextWithParams[objectParams]) (any, error) {
+			return c.Params()
+		})
+
+		param := route.Operation.Parameters.GetByInAndName("query", "range")
+		require.NotNil(t, param)
+		require.Equal(t, "deepObject", param.Style)
+		require.True(t, *param.Explode)
+		require.True(t, param.Required)
+		require.True(t, param.Schema.Value.Type.Is("object"))
+		require.True(t, param.Schema.Value.Properties["min"].Value.Type.Is("integer"))
+		require.True(t, param.Schema.Value.Properties["max"].Value.Type.Is("integer"))
+
+		r := httptest.NewRequest(http.MethodGet, "/products?range[min]=1&range[max]=10", nil)
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.JSONEq(t, `{"Range":{"Min":1,"max":10},"Others":null}`, w.Body.String())
+	})
+}
+
+func TestBindParams_Method(t *testing.T) {
+	type sharedParams struct {
+		Method string `method:""`
+		ID     string `path:"id"`
+	}
+
+	s := NewServer()
+	handler := func(c ContextWithParams[sharedParams]) (string, error) {
+		params, err := c.Params()
+		return params.Method + " " + params.ID + " " + c.Method(), err
+	}
+	Get(s, "/items/{id}", handler)
+	Delete(s, "/items/{id}", handler)
+
+	for _, method := range []string{http.MethodGet, http.MethodDelete} {
+		r := httptest.NewRequest(method, "/items/42", nil)
+		r.Header.Set("Accept", "text/plain")
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.Equal(t, method+" 42 "+method, w.Body.String())
+	}
+
+	t.Run("not a string", func(t *testing.T) {
+		c := NewNetHTTPContext[any, any](BaseRoute{}, httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil), readOptions{})
+		_, err := BindParams[struct {
+			Method int `method:""`
+		}](c)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,101 @@
+package fuego
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ReadBodyAny reads the request body without knowing its type at compile time, based on the Content-Type:
+//   - JSON (application/json, application/*+json): map[string]any, []any, string, float64, bool or nil
+//   - YAML (application/yaml, application/x-yaml, text/yaml, application/*+yaml): same types as JSON
+//   - text/*, XML (application/xml, application/*+xml): string, as XML has no generic representation
+//   - application/x-www-form-urlencoded: [url.Values]
+//   - other types (application/octet-stream, image/png...): []byte
+//
+// Without Content-Type, the body is sniffed: JSON if it is valid JSON, string if it is valid UTF-8, []byte otherwise.
+// An empty body is nil. The body is buffered, so it can still be read afterwards.
+// Useful for proxies, debug or echo endpoints.
+// Customizable by modifying ReadOptions.
+func ReadBodyAny(r *http.Request) (any, error) {
+	return readBodyAny(r, ReadOptions)
+}
+
+func readBodyAny(r *http.Request, options readOptions) (any, error) {
+	body, err := bufferBody(r, options)
+	if err != nil {
+		return nil, err
+	}
+	if len(body) == 0 {
+		return nil, nil
+	}
+
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "" {
+		return sniffBody(body), nil
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return body, nil
+	}
+
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		return decodeBodyAny(json.NewDecoder(bytes.NewReader(body)))
+	case mediaType == "application/yaml" || mediaType == "application/x-yaml" || mediaType == "text/yaml" || strings.HasSuffix(mediaType, "+yaml"):
+		return decodeBodyAny(yaml.NewDecoder(bytes.NewReader(body)))
+	case strings.HasPrefix(mediaType, "text/") || mediaType == "application/xml" || strings.HasSuffix(mediaType, "+xml"):
+		return string(body), nil
+	case mediaType == "application/x-www-form-urlencoded":
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, BadRequestError{
+				Title:  "Decoding Failed",
+				Err:    err,
+				Detail: "cannot decode request body: " + err.Error(),
+			}
+		}
+		return values, nil
+	default:
+		return body, nil
+	}
+}
+
+func decodeBodyAny(dec decoder) (any, error) {
+	var body any
+	if err := dec.Decode(&body); err != nil && !errors.Is(err, io.EOF) {
+		return nil, BadRequestError{
+			Title:  "Decoding Failed",
+			Err:    err,
+			Detail: "cannot decode request body: " + err.Error(),
+		}
+	}
+	return body, nil
+}
+
+// sniffBody decodes a body without Content-Type, see [ReadBodyAny].
+func sniffBody(body []byte) any {
+	if json.Valid(body) {
+		var decoded any
+		if err := json.Unmarshal(body, &decoded); err == nil {
+			return decoded
+		}
+	}
+	if utf8.Valid(body) && !strings.ContainsRune(string(body), 0) {
+		return string(body)
+	}
+	return body
+}
+
+// BodyAny reads the request body without a compile-time type, see [ReadBodyAny].
+func (c netHttpContext[B, P]) BodyAny() (any, error) {
+	return readBodyAny(c.Req, c.readOptions)
+}
@@ -0,0 +1,88 @@
+package fuego
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadBodyAny(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		expected    any
+	}{
+		{"JSON object", "application/json", `{"name":"pizza","tags":["a"],"price":9.5}`, map[string]any{"name": "pizza", "tags": []any{"a"}, "price": 9.5}},
+		{"JSON array", "application/json; charset=utf-8", `[1,"two"]`, []any{1.0, "two"}},
+		{"JSON suffix", "application/problem+json", `{"title":"Not Found"}`, map[string]any{"title": "Not Found"}},
+		{"YAML", "application/x-yaml", "name: pizza\ntags:\n  - a\n", map[string]any{"name": "pizza", "tags": []any{"a"}}},
+		{"YAML array", "application/yaml", "- 1\n- two\n", []any{1, "two"}},
+		{"text", "text/plain", "hello", "hello"},
+		{"CSV", "text/csv", "a,b\n1,2\n", "a,b\n1,2\n"},
+		{"XML", "application/xml", "<recipe/>", "<recipe/>"},
+		{"form", "application/x-www-form-urlencoded", "name=pizza&tags=a&tags=b", url.Values{"name": {"pizza"}, "tags": {"a", "b"}}},
+		{"binary", "application/octet-stream", "\x00\x01\x02", []byte("\x00\x01\x02")},
+		{"image", "image/png", "\x89PNG", []byte("\x89PNG")},
+		{"sniffed JSON", "", `{"name":"pizza"}`, map[string]any{"name": "pizza"}},
+		{"sniffed text", "", "hello", "hello"},
+		{"sniffed binary", "", "\xff\x00", []byte("\xff\x00")},
+		{"empty body", "application/json", "", nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tc.body))
+			if tc.contentType != "" {
+				r.Header.Set("Content-Type", tc.contentType)
+			}
+
+			body, err := ReadBodyAny(r)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, body)
+		})
+	}
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":`))
+		r.Header.Set("Content-Type", "application/json")
+
+		_, err := ReadBodyAny(r)
+		require.ErrorAs(t, err, &BadRequestError{})
+	})
+
+	t.Run("body too large", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":"pizza"}`))
+		r.Header.Set("Content-Type", "application/json")
+
+		_, err := readBodyAny(r, readOptions{MaxBodySize: 5})
+		require.ErrorAs(t, err, &BadRequestError{})
+	})
+}
+
+func TestContextBodyAny(t *testing.T) {
+	s := NewServer()
+	Post(s, "/echo", func(c ContextNoBody) (any, error) {
+		return c.BodyAny()
+	})
+
+	r := httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader("name: pizza\n"))
+	r.Header.Set("Content-Type", "application/x-yaml")
+	w := httptest.NewRecorder()
+	s.Mux.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"name":"pizza"}`, w.Body.String())
+
+	t.Run("mock context without request", func(t *testing.T) {
+		c := NewMockContext[map[string]string, any](map[string]string{"name": "pizza"}, nil)
+
+		body, err := c.BodyAny()
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"name": "pizza"}, body)
+	})
+}
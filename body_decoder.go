@@ -0,0 +1,68 @@
+package fuego
+
+import (
+	"mime"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// BodyDecoder decodes the request body into v, a pointer to the body type of the route.
+// The decoded body is then transformed and validated as usual.
+type BodyDecoder func(r *http.Request, v any) error
+
+// OptionRequestDecoder registers a decoder for the given content type on the route.
+// The route's decoders are consulted before the built-in ones (JSON, XML, YAML...),
+// which allows accepting custom or versioned media types per endpoint.
+// The content type is added to the accepted content types documented in the OpenAPI spec.
+//
+//	fuego.Post(s, "/v2/recipes", createRecipeV2,
+//		option.RequestDecoder("application/vnd.recipes.v2+json", func(r *http.Request, v any) error {
+//			return json.NewDecoder(r.Body).Decode(v)
+//		}),
+//	)
+func OptionRequestDecoder(contentType string, decoder BodyDecoder) func(*BaseRoute) {
+	return func(r *BaseRoute) {
+		mediaType := normalizeMediaType(contentType)
+		if r.RequestDecoders == nil {
+			r.RequestDecoders = make(map[string]BodyDecoder)
+		}
+		r.RequestDecoders[mediaType] = decoder
+
+		if !slices.Contains(r.RequestContentTypes, contentType) {
+			r.RequestContentTypes = append(r.RequestContentTypes, contentType)
+		}
+	}
+}
+
+// normalizeMediaType returns the lowercased media type of a Content-Type, without parameters.
+func normalizeMediaType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType, _, _ = strings.Cut(contentType, ";")
+	}
+	return strings.ToLower(strings.TrimSpace(mediaType))
+}
+
+// requestDecoder returns the decoder registered for the Content-Type of the request, if any.
+func requestDecoder(decoders map[string]BodyDecoder, r *http.Request) (BodyDecoder, bool) {
+	if len(decoders) == 0 {
+		return nil, false
+	}
+	decoder, ok := decoders[normalizeMediaType(r.Header.Get("Content-Type"))]
+	return decoder, ok
+}
+
+// readWithDecoder reads the request body with the given decoder.
+func readWithDecoder[B any](r *http.Request, decoder BodyDecoder) (B, error) {
+	var body B
+	if err := decoder(r, &body); err != nil {
+		return body, BadRequestError{
+			Title:  "Decoding Failed",
+			Err:    err,
+			Detail: "cannot decode request body: " + err.Error(),
+		}
+	}
+
+	return TransformAndValidate(r.Context(), body)
+}
@@ -0,0 +1,108 @@
+package fuego
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type recipeV1 struct {
+	Name string `json:"name"`
+}
+
+type recipeV2 struct {
+	Title string `json:"title"`
+}
+
+func TestOptionRequestDecoder(t *testing.T) {
+	s := NewServer()
+
+	Post(s, "/v1/recipes", func(c ContextWithBody[recipeV1]) (string, error) {
+		body, err := c.Body()
+		return body.Name, err
+	}, OptionRequestDecoder("application/vnd.recipes.v1+csv", func(r *http.Request, v any) error {
+		// name is the first column
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			return err
+		}
+		name, _, _ := strings.Cut(string(data), ",")
+		v.(*recipeV1).Name = name
+		return nil
+	}))
+
+	Post(s, "/v2/recipes", func(c ContextWithBody[recipeV2]) (string, error) {
+		body, err := c.Body()
+		return body.Title, err
+	}, OptionRequestDecoder("application/vnd.recipes.v2+json", func(r *http.Request, v any) error {
+		return json.NewDecoder(r.Body).Decode(v)
+	}))
+
+	t.Run("each route decodes its own media type", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/v1/recipes", strings.NewReader("pizza,italian"))
+		r.Header.Set("Content-Type", "application/vnd.recipes.v1+csv")
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+		require.Equal(t, "pizza", w.Body.String())
+
+		r = httptest.NewRequest(http.MethodPost, "/v2/recipes", strings.NewReader(`{"title":"pasta"}`))
+		r.Header.Set("Content-Type", "application/vnd.recipes.v2+json; charset=utf-8")
+		w = httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+		require.Equal(t, "pasta", w.Body.String())
+	})
+
+	t.Run("media type of another route falls back to the built-in decoders", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/v2/recipes", strings.NewReader("pizza,italian"))
+		r.Header.Set("Content-Type", "application/vnd.recipes.v1+csv")
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("built-in decoders still work", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/v2/recipes", strings.NewReader(`{"title":"pasta"}`))
+		r.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, "pasta", w.Body.String())
+	})
+
+	t.Run("decoder errors are bad requests", func(t *testing.T) {
+		s := NewServer()
+		Post(s, "/recipes", func(c ContextWithBody[recipeV1]) (recipeV1, error) {
+			return c.Body()
+		}, OptionRequestDecoder("application/vnd.broken", func(r *http.Request, v any) error {
+			return errors.New("broken")
+		}))
+
+		r := httptest.NewRequest(http.MethodPost, "/recipes", strings.NewReader("data"))
+		r.Header.Set("Content-Type", "application/vnd.broken")
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("media types are documented", func(t *testing.T) {
+		route := Post(s, "/v3/recipes", func(c ContextWithBody[recipeV2]) (string, error) {
+			return "", nil
+		},
+			OptionRequestDecoder("application/vnd.recipes.v2+json", func(r *http.Request, v any) error { return nil }),
+			OptionRequestDecoder("application/vnd.recipes.v3+json", func(r *http.Request, v any) error { return nil }),
+		)
+
+		require.Equal(t, []string{"application/vnd.recipes.v2+json", "application/vnd.recipes.v3+json"}, route.RequestContentTypes)
+		content := route.Operation.RequestBody.Value.Content
+		require.Contains(t, content, "application/vnd.recipes.v2+json")
+		require.Contains(t, content, "application/vnd.recipes.v3+json")
+	})
+}
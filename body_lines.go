@@ -0,0 +1,65 @@
+package fuego
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"iter"
+	"net/http"
+	"strings"
+)
+
+// ReadLines returns an iterator over the lines of the request body, read as they are iterated,
+// without loading the whole body into memory: useful for large text uploads like logs.
+// Lines are yielded without their line ending ("\n" or "\r\n"), and can be of any length.
+// The last line is yielded even without a trailing newline. A byte-order mark is removed.
+// If the body cannot be read, or exceeds the maximum body size, a [BadRequestError] is yielded and the iteration stops.
+// Customizable by modifying ReadOptions.
+//
+//	for line, err := range fuego.ReadLines(r) {
+//		if err != nil {
+//			return err
+//		}
+//		process(line)
+//	}
+func ReadLines(r *http.Request) iter.Seq2[string, error] {
+	return readLines(r, ReadOptions)
+}
+
+func readLines(r *http.Request, options readOptions) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		if r.Body == nil {
+			return
+		}
+		body := r.Body
+		if options.MaxBodySize != 0 {
+			body = http.MaxBytesReader(nil, body, options.MaxBodySize)
+		}
+		reader := bufio.NewReader(newBOMReader(body))
+
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil && !errors.Is(err, io.EOF) {
+				yield("", BadRequestError{
+					Err:    err,
+					Detail: "cannot read request body: " + err.Error(),
+				})
+				return
+			}
+			if line != "" {
+				line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+				if !yield(line, nil) {
+					return
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+// BodyLines returns an iterator over the lines of the request body, see [ReadLines].
+func (c netHttpContext[B, P]) BodyLines() iter.Seq2[string, error] {
+	return readLines(c.Req, c.readOptions)
+}
@@ -0,0 +1,96 @@
+package fuego
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBodyLines(t *testing.T) {
+	readAll := func(t *testing.T, body string, options readOptions) ([]string, error) {
+		t.Helper()
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		c := NewNetHTTPContext[string, any](BaseRoute{}, httptest.NewRecorder(), r, options)
+		var lines []string
+		for line, err := range c.BodyLines() {
+			if err != nil {
+				return lines, err
+			}
+			lines = append(lines, line)
+		}
+		return lines, nil
+	}
+
+	t.Run("multi-line body", func(t *testing.T) {
+		lines, err := readAll(t, "first\nsecond\r\n\nlast without newline", readOptions{})
+		require.NoError(t, err)
+		require.Equal(t, []string{"first", "second", "", "last without newline"}, lines)
+	})
+
+	t.Run("trailing newline", func(t *testing.T) {
+		lines, err := readAll(t, "a\nb\n", readOptions{})
+		require.NoError(t, err)
+		require.Equal(t, []string{"a", "b"}, lines)
+	})
+
+	t.Run("empty body", func(t *testing.T) {
+		lines, err := readAll(t, "", readOptions{})
+		require.NoError(t, err)
+		require.Empty(t, lines)
+	})
+
+	t.Run("very long line", func(t *testing.T) {
+		long := strings.Repeat("x", 1<<20)
+		lines, err := readAll(t, "short\n"+long+"\nend", readOptions{})
+		require.NoError(t, err)
+		require.Equal(t, []string{"short", long, "end"}, lines)
+	})
+
+	t.Run("byte-order mark", func(t *testing.T) {
+		lines, err := readAll(t, "\xef\xbb\xbfhello\nworld", readOptions{})
+		require.NoError(t, err)
+		require.Equal(t, []string{"hello", "world"}, lines)
+	})
+
+	t.Run("honors the maximum body size", func(t *testing.T) {
+		lines, err := readAll(t, "0123456789\n0123456789\n0123456789\n", readOptions{MaxBodySize: 15})
+		var badRequest BadRequestError
+		require.ErrorAs(t, err, &badRequest)
+		require.Equal(t, []string{"0123456789"}, lines)
+	})
+
+	t.Run("stops when the loop breaks", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("a\nb\nc"))
+		for line := range ReadLines(r) {
+			require.Equal(t, "a", line)
+			break
+		}
+	})
+
+	t.Run("through a route", func(t *testing.T) {
+		s := NewServer()
+		Post(s, "/logs", func(c ContextNoBody) (int, error) {
+			count := 0
+			for line, err := range c.BodyLines() {
+				if err != nil {
+					return 0, err
+				}
+				if strings.Contains(line, "ERROR") {
+					count++
+				}
+			}
+			return count, nil
+		})
+
+		r := httptest.NewRequest(http.MethodPost, "/logs", strings.NewReader("INFO ok\nERROR one\nERROR two"))
+		r.Header.Set("Content-Type", "text/plain")
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "2", strings.TrimSpace(w.Body.String()))
+	})
+}
@@ -0,0 +1,25 @@
+package fuego
+
+import "io"
+
+// WithBodySizeReport sets the X-Body-Bytes response header to the number of bytes read
+// from the request body when it is deserialized, for monitoring by clients and proxies.
+// Disabled by default.
+//
+//	s := fuego.NewServer(fuego.WithBodySizeReport())
+//	// POST /recipes {"name":"pasta"} -> X-Body-Bytes: 16
+func WithBodySizeReport() func(*Server) {
+	return func(s *Server) { s.reportBodySize = true }
+}
+
+// countingReader counts the bytes read from the request body, see [WithBodySizeReport].
+type countingReader struct {
+	io.ReadCloser
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.n += int64(n)
+	return n, err
+}
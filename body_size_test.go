@@ -0,0 +1,58 @@
+package fuego
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithBodySizeReport(t *testing.T) {
+	type recipe struct {
+		Name string `json:"name"`
+	}
+	handler := func(c ContextWithBody[recipe]) (recipe, error) {
+		return c.Body()
+	}
+
+	t.Run("reports the size of a known payload", func(t *testing.T) {
+		s := NewServer(WithBodySizeReport())
+		Post(s, "/recipes", handler)
+
+		r := httptest.NewRequest(http.MethodPost, "/recipes", strings.NewReader(`{"name":"pasta"}`))
+		r.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "16", w.Header().Get("X-Body-Bytes"))
+	})
+
+	t.Run("reports the bytes read from a plain text body", func(t *testing.T) {
+		s := NewServer(WithBodySizeReport())
+		Post(s, "/text", func(c ContextWithBody[string]) (string, error) {
+			return c.Body()
+		})
+
+		r := httptest.NewRequest(http.MethodPost, "/text", strings.NewReader(strings.Repeat("a", 1000)))
+		r.Header.Set("Content-Type", "text/plain")
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, "1000", w.Header().Get("X-Body-Bytes"))
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		s := NewServer()
+		Post(s, "/recipes", handler)
+
+		r := httptest.NewRequest(http.MethodPost, "/recipes", strings.NewReader(`{"name":"pasta"}`))
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Empty(t, w.Header().Get("X-Body-Bytes"))
+	})
+}
@@ -0,0 +1,50 @@
+package fuego
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// OptionBodyUnwrap decodes the JSON request bodies of the route from the object under key,
+// for clients sending envelopes like {"data": {...}}: the envelope is decoded first, then the nested object
+// into the body type, with the usual options (unknown fields, transformers...). Other fields of the envelope are ignored.
+// Requests without the key are rejected with a 400. The OpenAPI request body is documented with the envelope.
+// See [WithResponseEnvelope] to wrap the responses.
+//
+//	fuego.Post(s, "/recipes", createRecipe,
+//		option.BodyUnwrap("data"),
+//	)
+func OptionBodyUnwrap(key string) func(*BaseRoute) {
+	return func(r *BaseRoute) {
+		r.BodyUnwrapKey = key
+	}
+}
+
+// unwrapJSONBody returns the JSON value under the key of the envelope read from input, see [OptionBodyUnwrap].
+func unwrapJSONBody(input io.Reader, key string) ([]byte, error) {
+	var envelope map[string]json.RawMessage
+	if err := json.NewDecoder(input).Decode(&envelope); err != nil {
+		if errors.Is(err, errInvalidUTF8) {
+			return nil, invalidUTF8Error(err)
+		}
+		title := "Decoding Failed"
+		if errors.Is(err, errJSONTooDeep) {
+			title = "JSON Too Deep"
+		}
+		return nil, BadRequestError{
+			Title:  title,
+			Err:    err,
+			Detail: "cannot decode request body envelope: " + err.Error(),
+		}
+	}
+
+	inner, ok := envelope[key]
+	if !ok {
+		return nil, BadRequestError{
+			Title:  "Missing Envelope Key",
+			Detail: "request body must be an object with the field " + key,
+		}
+	}
+	return inner, nil
+}
@@ -0,0 +1,58 @@
+package fuego
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptionBodyUnwrap(t *testing.T) {
+	type Recipe struct {
+		Name string `json:"name"`
+	}
+
+	s := NewServer()
+	route := Post(s, "/recipes", func(c ContextWithBody[Recipe]) (Recipe, error) {
+		return c.Body()
+	}, OptionBodyUnwrap("data"))
+
+	post := func(t *testing.T, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		r := httptest.NewRequest(http.MethodPost, "/recipes", strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("unwraps the data envelope", func(t *testing.T) {
+		w := post(t, `{"data":{"name":"pasta"},"meta":{"client":"mobile"}}`)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.JSONEq(t, `{"name":"pasta"}`, w.Body.String())
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		w := post(t, `{"name":"pasta"}`)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Contains(t, w.Body.String(), "Missing Envelope Key")
+	})
+
+	t.Run("envelope is not an object", func(t *testing.T) {
+		w := post(t, `[{"name":"pasta"}]`)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("unknown fields of the nested object are rejected", func(t *testing.T) {
+		w := post(t, `{"data":{"name":"pasta","price":3}}`)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("OpenAPI request body is the envelope", func(t *testing.T) {
+		schema := route.Operation.RequestBody.Value.Content["*/*"].Schema.Value
+		require.Equal(t, []string{"data"}, schema.Required)
+		require.Equal(t, "#/components/schemas/Recipe", schema.Properties["data"].Ref)
+	})
+}
@@ -0,0 +1,34 @@
+package fuego
+
+import (
+	"io"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/unicode"
+	texttransform "golang.org/x/text/transform"
+)
+
+// newBOMReader removes the byte-order mark at the start of a text body, as sent by some Windows clients.
+// UTF-16 (LE and BE) bodies are transcoded to UTF-8. Bodies without BOM are read as is.
+func newBOMReader(body io.ReadCloser) io.ReadCloser {
+	return struct {
+		io.Reader
+		io.Closer
+	}{
+		Reader: texttransform.NewReader(body, unicode.BOMOverride(encoding.Nop.NewDecoder())),
+		Closer: body,
+	}
+}
+
+// hasTextBody reports whether the built-in decoder of the media type reads text, where a BOM can be found.
+// Binary and form bodies are excluded.
+func hasTextBody(contentType string) bool {
+	switch contentType {
+	case "application/x-www-form-urlencoded", "multipart/form-data",
+		"application/x-protobuf", "application/protobuf",
+		"application/grpc-web", "application/grpc-web+proto",
+		"application/octet-stream":
+		return false
+	}
+	return true
+}
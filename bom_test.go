@@ -0,0 +1,75 @@
+package fuego
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/require"
+)
+
+// utf16WithBOM encodes the string in UTF-16 with a leading byte-order mark.
+func utf16WithBOM(s string, order binary.ByteOrder) []byte {
+	var buf bytes.Buffer
+	for _, unit := range utf16.Encode([]rune("\uFEFF" + s)) {
+		_ = binary.Write(&buf, order, unit)
+	}
+	return buf.Bytes()
+}
+
+func TestBodyWithBOM(t *testing.T) {
+	type recipe struct {
+		Name string `json:"name" xml:"name"`
+	}
+
+	readBody := func(t *testing.T, contentType string, body []byte) (recipe, error) {
+		t.Helper()
+		r := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+		r.Header.Set("Content-Type", contentType)
+		c := NewNetHTTPContext[recipe, any](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+		return c.Body()
+	}
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        []byte
+	}{
+		{name: "JSON without BOM", contentType: "application/json", body: []byte(`{"name":"Crêpe"}`)},
+		{name: "JSON with UTF-8 BOM", contentType: "application/json", body: []byte("\xEF\xBB\xBF" + `{"name":"Crêpe"}`)},
+		{name: "JSON with UTF-16LE BOM", contentType: "application/json", body: utf16WithBOM(`{"name":"Crêpe"}`, binary.LittleEndian)},
+		{name: "JSON with UTF-16BE BOM", contentType: "application/json", body: utf16WithBOM(`{"name":"Crêpe"}`, binary.BigEndian)},
+		{name: "XML with UTF-8 BOM", contentType: "application/xml", body: []byte("\xEF\xBB\xBF<recipe><name>Crêpe</name></recipe>")},
+		{name: "XML with UTF-16LE BOM", contentType: "application/xml", body: utf16WithBOM("<recipe><name>Crêpe</name></recipe>", binary.LittleEndian)},
+		{name: "YAML with UTF-8 BOM", contentType: "application/yaml", body: []byte("\xEF\xBB\xBFname: Crêpe")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := readBody(t, tt.contentType, tt.body)
+			require.NoError(t, err)
+			require.Equal(t, "Crêpe", body.Name)
+		})
+	}
+
+	t.Run("text with UTF-16BE BOM", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(utf16WithBOM("Crêpe", binary.BigEndian)))
+		r.Header.Set("Content-Type", "text/plain")
+		c := NewNetHTTPContext[string, any](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+		body, err := c.Body()
+		require.NoError(t, err)
+		require.Equal(t, "Crêpe", body)
+	})
+
+	t.Run("binary bodies are kept", func(t *testing.T) {
+		payload := []byte("\xFF\xFE\x00\x01")
+		r := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(payload))
+		r.Header.Set("Content-Type", "application/octet-stream")
+		c := NewNetHTTPContext[[]byte, any](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+		body, err := c.Body()
+		require.NoError(t, err)
+		require.Equal(t, payload, body)
+	})
+}
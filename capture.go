@@ -0,0 +1,83 @@
+package fuego
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+// ErrResponseCommitted is returned when committing a [CapturedResponse] twice.
+var ErrResponseCommitted = errors.New("captured response already committed")
+
+// CapturedResponse is a response written to a buffer by [CaptureResponse].
+// Its status, headers and body can be modified before sending it with [CapturedResponse.Commit].
+type CapturedResponse struct {
+	// Status is the status code written, 200 if none.
+	Status int
+	// Header contains the headers set while capturing the response.
+	Header http.Header
+	Body   []byte
+
+	w         http.ResponseWriter
+	committed bool
+}
+
+// Commit sends the captured response, with its modifications, to the client.
+// The captured headers replace the headers of the response with the same name.
+// If a Content-Length header was captured, it is updated to the length of the body.
+func (r *CapturedResponse) Commit() error {
+	if r.committed {
+		return ErrResponseCommitted
+	}
+	r.committed = true
+	if r.w == nil {
+		return nil
+	}
+
+	header := r.w.Header()
+	for key, values := range r.Header {
+		header[key] = append([]string(nil), values...)
+	}
+	if header.Get("Content-Length") != "" {
+		header.Set("Content-Length", strconv.Itoa(len(r.Body)))
+	}
+
+	status := r.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	r.w.WriteHeader(status)
+	_, err := r.w.Write(r.Body)
+	return err
+}
+
+// CaptureResponse runs fn with a buffering response writer instead of w,
+// and returns the captured status, headers and body. They can be inspected or modified,
+// for example to minify HTML, before sending them to w with [CapturedResponse.Commit].
+// Example:
+//
+//	captured, err := fuego.CaptureResponse(c.Response(), func(w http.ResponseWriter) error {
+//		return fuego.SendJSON(w, c.Request(), recipes)
+//	})
+//	if err != nil {
+//		return nil, err
+//	}
+//	captured.Body = bytes.ReplaceAll(captured.Body, []byte("secret"), []byte("******"))
+//	return nil, captured.Commit()
+func CaptureResponse(w http.ResponseWriter, fn func(w http.ResponseWriter) error) (*CapturedResponse, error) {
+	recorder := newRecordedResponse()
+	if err := fn(recorder); err != nil {
+		return nil, err
+	}
+
+	status := recorder.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	return &CapturedResponse{
+		Status: status,
+		Header: recorder.header,
+		Body:   recorder.body.Bytes(),
+		w:      w,
+	}, nil
+}
@@ -0,0 +1,113 @@
+package fuego
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCaptureResponse(t *testing.T) {
+	s := NewServer()
+	Get(s, "/recipes", func(c ContextNoBody) (any, error) {
+		captured, err := CaptureResponse(c.Response(), func(w http.ResponseWriter) error {
+			w.Header().Set("X-Recipes", "1")
+			w.WriteHeader(http.StatusAccepted)
+			return SendJSON(w, c.Request(), ans{Ans: "secret pizza"})
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if captured.Status != http.StatusAccepted || captured.Header.Get("Content-Type") != "application/json" {
+			return nil, errors.New("unexpected captured response")
+		}
+		captured.Body = bytes.ReplaceAll(captured.Body, []byte("secret"), []byte("******"))
+		captured.Header.Set("X-Rewritten", "true")
+		return nil, captured.Commit()
+	})
+	Get(s, "/content-length", func(c ContextNoBody) (any, error) {
+		captured, err := CaptureResponse(c.Response(), func(w http.ResponseWriter) error {
+			w.Header().Set("Content-Length", "5")
+			_, err := w.Write([]byte("hello"))
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		captured.Body = append(captured.Body, " world"...)
+		return nil, captured.Commit()
+	})
+	Get(s, "/error", func(c ContextNoBody) (any, error) {
+		return CaptureResponse(c.Response(), func(w http.ResponseWriter) error {
+			_, _ = w.Write([]byte("partial"))
+			return errors.New("cannot render")
+		})
+	})
+	Get(s, "/commit-twice", func(c ContextNoBody) (any, error) {
+		captured, err := CaptureResponse(c.Response(), func(w http.ResponseWriter) error { return nil })
+		if err != nil {
+			return nil, err
+		}
+		if err := captured.Commit(); err != nil {
+			return nil, err
+		}
+		if err := captured.Commit(); !errors.Is(err, ErrResponseCommitted) {
+			return nil, errors.New("expected ErrResponseCommitted")
+		}
+		return nil, nil
+	})
+
+	t.Run("captures and modifies a JSON response", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/recipes", nil))
+
+		require.Equal(t, http.StatusAccepted, w.Code)
+		require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		require.Equal(t, "1", w.Header().Get("X-Recipes"))
+		require.Equal(t, "true", w.Header().Get("X-Rewritten"))
+		require.JSONEq(t, `{"ans":"****** pizza"}`, w.Body.String())
+	})
+
+	t.Run("updates the Content-Length", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/content-length", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "11", w.Header().Get("Content-Length"))
+		require.Equal(t, "hello world", w.Body.String())
+	})
+
+	t.Run("discards the captured response on error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/error", nil))
+
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+		require.NotContains(t, w.Body.String(), "partial")
+	})
+
+	t.Run("commits once", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/commit-twice", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Empty(t, w.Body.String())
+	})
+
+	t.Run("does not write to the response before commit", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		captured, err := CaptureResponse(w, func(w http.ResponseWriter) error {
+			return SendJSON(w, httptest.NewRequest(http.MethodGet, "/", nil), ans{Ans: "pizza"})
+		})
+		require.NoError(t, err)
+		require.JSONEq(t, `{"ans":"pizza"}`, string(captured.Body))
+		require.Empty(t, w.Body.String())
+
+		require.NoError(t, captured.Commit())
+		require.JSONEq(t, `{"ans":"pizza"}`, w.Body.String())
+	})
+}
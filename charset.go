@@ -0,0 +1,109 @@
+package fuego
+
+import (
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/ianaindex"
+	texttransform "golang.org/x/text/transform"
+)
+
+const defaultCharset = "utf-8"
+
+// NegotiateCharset returns the charset to use for text responses,
+// from the Accept-Charset header of the request (RFC 9110).
+// The acceptable charset with the highest quality that can be encoded is chosen,
+// UTF-8 being used when the header is missing or accepts any charset ("*").
+// The returned name is lowercased, for example "utf-8" or "iso-8859-1".
+//
+//	Accept-Charset: iso-8859-1, utf-8;q=0.5
+//	-> iso-8859-1
+func NegotiateCharset(header http.Header) string {
+	type acceptedCharset struct {
+		name    string
+		quality float64
+	}
+
+	var accepted []acceptedCharset
+	for _, line := range header.Values("Accept-Charset") {
+		for part := range strings.SplitSeq(line, ",") {
+			name, params, _ := strings.Cut(part, ";")
+			name = strings.ToLower(strings.TrimSpace(name))
+			if name == "" {
+				continue
+			}
+
+			quality := 1.0
+			if q, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+				if parsed, err := strconv.ParseFloat(q, 64); err == nil {
+					quality = parsed
+				}
+			}
+			accepted = append(accepted, acceptedCharset{name: name, quality: quality})
+		}
+	}
+
+	slices.SortStableFunc(accepted, func(a, b acceptedCharset) int {
+		switch {
+		case a.quality > b.quality:
+			return -1
+		case a.quality < b.quality:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	for _, charset := range accepted {
+		if charset.quality <= 0 {
+			break
+		}
+		if charset.name == "*" || charset.name == defaultCharset {
+			return defaultCharset
+		}
+		if enc, err := ianaindex.IANA.Encoding(charset.name); err == nil && enc != nil {
+			if name, err := ianaindex.MIME.Name(enc); err == nil {
+				return strings.ToLower(name)
+			}
+		}
+	}
+
+	// No acceptable charset can be encoded: RFC 9110 allows to ignore the header.
+	return defaultCharset
+}
+
+// ResponseCharset returns the charset negotiated for text responses from the Accept-Charset header.
+func (c netHttpContext[B, P]) ResponseCharset() string {
+	AddVary(c.Res.Header(), "Accept-Charset")
+	return NegotiateCharset(c.Req.Header)
+}
+
+// responseCharset returns the charset negotiated for the request, UTF-8 if there is no request.
+func responseCharset(r *http.Request) string {
+	if r == nil {
+		return defaultCharset
+	}
+	return NegotiateCharset(r.Header)
+}
+
+// charsetWriter returns a writer transcoding UTF-8 to the given charset.
+// Characters that cannot be represented in the charset are replaced.
+// The writer must be closed to flush the transcoded content.
+func charsetWriter(w io.Writer, charset string) io.WriteCloser {
+	if charset != defaultCharset {
+		if enc, err := ianaindex.IANA.Encoding(charset); err == nil && enc != nil {
+			return texttransform.NewWriter(w, encoding.ReplaceUnsupported(enc.NewEncoder()))
+		}
+	}
+	return nopWriteCloser{w}
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
@@ -0,0 +1,97 @@
+package fuego
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiateCharset(t *testing.T) {
+	tests := []struct {
+		name          string
+		acceptCharset string
+		expected      string
+	}{
+		{name: "defaults to UTF-8", acceptCharset: "", expected: "utf-8"},
+		{name: "wildcard", acceptCharset: "*", expected: "utf-8"},
+		{name: "explicit UTF-8", acceptCharset: "UTF-8", expected: "utf-8"},
+		{name: "acceptable legacy charset", acceptCharset: "iso-8859-1", expected: "iso-8859-1"},
+		{name: "alias of a legacy charset", acceptCharset: "latin1", expected: "iso-8859-1"},
+		{name: "highest quality wins", acceptCharset: "utf-8;q=0.5, iso-8859-1;q=0.9", expected: "iso-8859-1"},
+		{name: "first charset wins on equal quality", acceptCharset: "utf-8, iso-8859-1", expected: "utf-8"},
+		{name: "unknown charsets are skipped", acceptCharset: "klingon, windows-1252;q=0.8", expected: "windows-1252"},
+		{name: "refused charsets are skipped", acceptCharset: "iso-8859-1;q=0", expected: "utf-8"},
+		{name: "nothing acceptable falls back to UTF-8", acceptCharset: "klingon", expected: "utf-8"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.acceptCharset != "" {
+				header.Set("Accept-Charset", tt.acceptCharset)
+			}
+			require.Equal(t, tt.expected, NegotiateCharset(header))
+		})
+	}
+}
+
+func TestCharsetResponses(t *testing.T) {
+	s := NewServer()
+	Get(s, "/text", func(c ContextNoBody) (string, error) {
+		return "crème brûlée €", nil
+	})
+	Get(s, "/html", func(c ContextNoBody) (HTML, error) {
+		return "<p>crème brûlée</p>", nil
+	})
+	Get(s, "/charset", func(c ContextNoBody) (string, error) {
+		return c.ResponseCharset(), nil
+	})
+
+	t.Run("text is sent in UTF-8 by default", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest("GET", "/text", nil))
+
+		require.Equal(t, "text/plain; charset=utf-8", w.Header().Get("Content-Type"))
+		require.Equal(t, "crème brûlée €", w.Body.String())
+	})
+
+	t.Run("text is transcoded to an acceptable charset", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/text", nil)
+		r.Header.Set("Accept-Charset", "iso-8859-1")
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, "text/plain; charset=iso-8859-1", w.Header().Get("Content-Type"))
+		// The euro sign does not exist in ISO-8859-1 and is replaced.
+		require.Equal(t, []byte("cr\xe8me br\xfbl\xe9e \x1a"), w.Body.Bytes())
+	})
+
+	t.Run("html is transcoded to an acceptable charset", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/html", nil)
+		r.Header.Set("Accept-Charset", "windows-1252, utf-8;q=0.9")
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, "text/html; charset=windows-1252", w.Header().Get("Content-Type"))
+		require.Equal(t, []byte("<p>cr\xe8me br\xfbl\xe9e</p>"), w.Body.Bytes())
+	})
+
+	t.Run("negotiated charset is available in the context", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/charset", nil)
+		r.Header.Set("Accept-Charset", "latin1")
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, "iso-8859-1", w.Body.String())
+	})
+
+	t.Run("mock context", func(t *testing.T) {
+		c := NewMockContextNoBody()
+		require.Equal(t, "utf-8", c.ResponseCharset())
+
+		c.SetHeader("Accept-Charset", "iso-8859-1")
+		require.Equal(t, "iso-8859-1", c.ResponseCharset())
+	})
+}
@@ -0,0 +1,97 @@
+package fuego
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ContextWithRequest is the subset of [Context] needed to read the request.
+type ContextWithRequest interface {
+	Request() *http.Request
+}
+
+// ClaimsVerifier verifies a token and decodes its claims into v, a pointer to the claims type.
+// It keeps [ClaimsFromRequest] independent of the JWT library: see [JWTVerifier] for golang-jwt.
+type ClaimsVerifier interface {
+	VerifyClaims(token string, v any) error
+}
+
+// JWTVerifier is a [ClaimsVerifier] for JWT, using golang-jwt.
+// Expiration, not before and issued at claims are checked if present.
+type JWTVerifier struct {
+	// Keyfunc returns the key used to verify the signature of the token.
+	Keyfunc jwt.Keyfunc
+	// Options of the parser, e.g. jwt.WithValidMethods([]string{"ES256"}).
+	Options []jwt.ParserOption
+}
+
+var _ ClaimsVerifier = JWTVerifier{}
+
+// VerifyClaims verifies the token signature and validity, and decodes its claims into v.
+func (verifier JWTVerifier) VerifyClaims(token string, v any) error {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, verifier.Keyfunc, verifier.Options...)
+	if err != nil {
+		return err
+	}
+
+	// Decodes the claims into the custom type through JSON, like they were encoded.
+	data, err := json.Marshal(claims)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// Claims extracts the JWT from the Authorization header ("Bearer <token>") or the [JWTCookieName] cookie,
+// verifies it with the given keyfunc and decodes its claims into T.
+// It returns an [UnauthorizedError] if the token is missing, invalid or expired.
+//
+//	type UserClaims struct {
+//		jwt.RegisteredClaims
+//		Roles []string `json:"roles"`
+//	}
+//
+//	fuego.Get(s, "/me", func(c fuego.ContextNoBody) (User, error) {
+//		claims, err := fuego.Claims[UserClaims](c, func(t *jwt.Token) (any, error) { return publicKey, nil })
+//		...
+//	})
+func Claims[T any](c ContextWithRequest, keyfunc jwt.Keyfunc) (T, error) {
+	return ClaimsFromRequest[T](c.Request(), JWTVerifier{Keyfunc: keyfunc}, JWTCookieName)
+}
+
+// ClaimsFromRequest extracts the token from the Authorization header ("Bearer <token>") or the given cookie,
+// verifies it with the given verifier and decodes its claims into T.
+// The cookie is not read if cookieName is empty.
+// It returns an [UnauthorizedError] if the token is missing, invalid or expired.
+func ClaimsFromRequest[T any](r *http.Request, verifier ClaimsVerifier, cookieName string) (T, error) {
+	var claims T
+
+	token := TokenFromHeader(r)
+	if token == "" && cookieName != "" {
+		if cookie, err := r.Cookie(cookieName); err == nil {
+			token = cookie.Value
+		}
+	}
+	if token == "" {
+		return claims, UnauthorizedError{Title: "Token not found", Detail: "no bearer token in the Authorization header or cookie"}
+	}
+
+	err := verifier.VerifyClaims(token, &claims)
+	if err != nil {
+		var unauthorized UnauthorizedError
+		switch {
+		case errors.As(err, &unauthorized):
+			return claims, err
+		case errors.Is(err, jwt.ErrTokenExpired):
+			return claims, UnauthorizedError{Title: "Token expired", Err: err, Detail: err.Error()}
+		default:
+			return claims, UnauthorizedError{Title: "Invalid token", Err: err, Detail: err.Error()}
+		}
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,130 @@
+package fuego
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+)
+
+type userClaims struct {
+	jwt.RegisteredClaims
+	Roles []string `json:"roles"`
+}
+
+func TestClaims(t *testing.T) {
+	secret := []byte("secret")
+	keyfunc := func(t *jwt.Token) (any, error) { return secret, nil }
+
+	sign := func(t *testing.T, expiresAt time.Time) string {
+		t.Helper()
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, userClaims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				Subject:   "user-1",
+				ExpiresAt: jwt.NewNumericDate(expiresAt),
+			},
+			Roles: []string{"admin"},
+		}).SignedString(secret)
+		require.NoError(t, err)
+		return token
+	}
+
+	t.Run("valid token from the Authorization header", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Authorization", "Bearer "+sign(t, time.Now().Add(time.Hour)))
+		c := NewNetHTTPContext[any, any](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+
+		claims, err := Claims[userClaims](c, keyfunc)
+		require.NoError(t, err)
+		require.Equal(t, "user-1", claims.Subject)
+		require.Equal(t, []string{"admin"}, claims.Roles)
+	})
+
+	t.Run("valid token from the cookie", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.AddCookie(&http.Cookie{Name: JWTCookieName, Value: sign(t, time.Now().Add(time.Hour))})
+		c := NewNetHTTPContext[any, any](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+
+		claims, err := Claims[userClaims](c, keyfunc)
+		require.NoError(t, err)
+		require.Equal(t, "user-1", claims.Subject)
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Authorization", "Bearer "+sign(t, time.Now().Add(-time.Hour)))
+		c := NewNetHTTPContext[any, any](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+
+		_, err := Claims[userClaims](c, keyfunc)
+		var unauthorized UnauthorizedError
+		require.ErrorAs(t, err, &unauthorized)
+		require.Equal(t, "Token expired", unauthorized.Title)
+		require.Equal(t, http.StatusUnauthorized, unauthorized.StatusCode())
+		require.ErrorIs(t, err, jwt.ErrTokenExpired)
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Authorization", "Bearer "+sign(t, time.Now().Add(time.Hour)))
+		c := NewNetHTTPContext[any, any](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+
+		_, err := Claims[userClaims](c, func(t *jwt.Token) (any, error) { return []byte("other"), nil })
+		var unauthorized UnauthorizedError
+		require.ErrorAs(t, err, &unauthorized)
+		require.Equal(t, "Invalid token", unauthorized.Title)
+	})
+
+	t.Run("missing token", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		c := NewNetHTTPContext[any, any](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+
+		_, err := Claims[userClaims](c, keyfunc)
+		var unauthorized UnauthorizedError
+		require.ErrorAs(t, err, &unauthorized)
+		require.Equal(t, "Token not found", unauthorized.Title)
+	})
+}
+
+type staticVerifier map[string]string
+
+func (v staticVerifier) VerifyClaims(token string, claims any) error {
+	subject, ok := v[token]
+	if !ok {
+		return errors.New("unknown token")
+	}
+	claims.(*userClaims).Subject = subject
+	return nil
+}
+
+func TestClaimsFromRequest(t *testing.T) {
+	verifier := staticVerifier{"abc": "user-1"}
+
+	t.Run("custom verifier and cookie", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.AddCookie(&http.Cookie{Name: "session", Value: "abc"})
+
+		claims, err := ClaimsFromRequest[userClaims](r, verifier, "session")
+		require.NoError(t, err)
+		require.Equal(t, "user-1", claims.Subject)
+	})
+
+	t.Run("cookie disabled", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.AddCookie(&http.Cookie{Name: "session", Value: "abc"})
+
+		_, err := ClaimsFromRequest[userClaims](r, verifier, "")
+		require.ErrorAs(t, err, &UnauthorizedError{})
+	})
+
+	t.Run("verifier error", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Authorization", "Bearer unknown")
+
+		_, err := ClaimsFromRequest[userClaims](r, verifier, "")
+		require.ErrorAs(t, err, &UnauthorizedError{})
+	})
+}
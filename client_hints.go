@@ -0,0 +1,162 @@
+package fuego
+
+import (
+	"net/http"
+	"strings"
+)
+
+// clientHintHeaders are the User-Agent Client Hints read by [ParseClientHints].
+var clientHintHeaders = []string{
+	"Sec-CH-UA",
+	"Sec-CH-UA-Mobile",
+	"Sec-CH-UA-Platform",
+	"Sec-CH-UA-Platform-Version",
+	"Sec-CH-UA-Model",
+}
+
+// ClientHints are the User-Agent Client Hints sent by the browser, see [ParseClientHints].
+// Fields are empty if the hint is not sent: Chromium-based browsers send Brands, Mobile and Platform by default,
+// and the others only once requested with [RequestClientHints].
+type ClientHints struct {
+	// Brands of the browser, from Sec-CH-UA, e.g. "Chromium" 124, "Google Chrome" 124, and a GREASE brand like "Not-A.Brand".
+	Brands []ClientHintBrand
+	// Mobile is true if the browser prefers a mobile experience, from Sec-CH-UA-Mobile.
+	Mobile bool
+	// Platform is the operating system, from Sec-CH-UA-Platform, e.g. "Windows", "macOS" or "Android".
+	Platform string
+	// PlatformVersion is the version of the operating system, from Sec-CH-UA-Platform-Version, e.g. "14.4.1".
+	PlatformVersion string
+	// Model is the device model, from Sec-CH-UA-Model, e.g. "Pixel 8". Usually empty on desktop.
+	Model string
+}
+
+// ClientHintBrand is a brand of the browser and its significant version, as sent in Sec-CH-UA.
+type ClientHintBrand struct {
+	Brand   string
+	Version string
+}
+
+// HasBrand reports whether the browser sent the given brand, e.g. "Chromium" or "Google Chrome".
+func (h ClientHints) HasBrand(brand string) bool {
+	for _, b := range h.Brands {
+		if b.Brand == brand {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseClientHints parses the User-Agent Client Hints headers, which are structured fields (RFC 8941):
+//
+//	Sec-CH-UA: "Chromium";v="124", "Google Chrome";v="124", "Not-A.Brand";v="99"
+//	Sec-CH-UA-Mobile: ?1
+//	Sec-CH-UA-Platform: "Android"
+//
+// Malformed hints are ignored.
+func ParseClientHints(header http.Header) ClientHints {
+	return ClientHints{
+		Brands:          parseClientHintBrands(header.Get("Sec-CH-UA")),
+		Mobile:          strings.TrimSpace(header.Get("Sec-CH-UA-Mobile")) == "?1",
+		Platform:        parseClientHintString(header.Get("Sec-CH-UA-Platform")),
+		PlatformVersion: parseClientHintString(header.Get("Sec-CH-UA-Platform-Version")),
+		Model:           parseClientHintString(header.Get("Sec-CH-UA-Model")),
+	}
+}
+
+// RequestClientHints asks the browser to send all the hints read by [ParseClientHints] on the next requests,
+// with the Accept-CH response header, and adds them to the Vary header, as the response depends on them.
+func RequestClientHints(header http.Header) {
+	accepted := map[string]bool{}
+	for _, value := range header.Values("Accept-CH") {
+		for _, hint := range strings.Split(value, ",") {
+			accepted[strings.ToLower(strings.TrimSpace(hint))] = true
+		}
+	}
+	var missing []string
+	for _, hint := range clientHintHeaders {
+		if !accepted[strings.ToLower(hint)] {
+			missing = append(missing, hint)
+		}
+	}
+	if len(missing) > 0 {
+		header.Add("Accept-CH", strings.Join(missing, ", "))
+	}
+	AddVary(header, clientHintHeaders...)
+}
+
+// parseClientHintBrands parses a list of strings with a "v" parameter, skipping malformed members.
+func parseClientHintBrands(value string) []ClientHintBrand {
+	var brands []ClientHintBrand
+	for value = strings.TrimSpace(value); value != ""; value = strings.TrimLeft(value, " \t") {
+		brand, rest, ok := parseSFString(value)
+		if !ok {
+			// Skip to the next member of the list.
+			_, value, _ = strings.Cut(value, ",")
+			continue
+		}
+
+		member := ClientHintBrand{Brand: brand}
+		for strings.HasPrefix(rest, ";") {
+			var key string
+			key, rest, _ = strings.Cut(strings.TrimLeft(rest[1:], " "), "=")
+			param, after, ok := parseSFString(rest)
+			if !ok {
+				break
+			}
+			if key == "v" {
+				member.Version = param
+			}
+			rest = after
+		}
+		brands = append(brands, member)
+
+		_, value, ok = strings.Cut(rest, ",")
+		if !ok {
+			break
+		}
+	}
+	return brands
+}
+
+// parseClientHintString parses a hint holding a single string, like Sec-CH-UA-Platform.
+func parseClientHintString(value string) string {
+	s, rest, ok := parseSFString(strings.TrimSpace(value))
+	if !ok || rest != "" {
+		return ""
+	}
+	return s
+}
+
+// parseSFString parses the structured field string (RFC 8941, section 3.3.3) at the start of value,
+// and returns the rest of value after it.
+func parseSFString(value string) (s, rest string, ok bool) {
+	if !strings.HasPrefix(value, `"`) {
+		return "", value, false
+	}
+	var b strings.Builder
+	for i := 1; i < len(value); i++ {
+		switch c := value[i]; c {
+		case '\\':
+			i++
+			if i == len(value) || (value[i] != '"' && value[i] != '\\') {
+				return "", value, false
+			}
+			b.WriteByte(value[i])
+		case '"':
+			return b.String(), value[i+1:], true
+		default:
+			if c < 0x20 || c > 0x7e {
+				return "", value, false
+			}
+			b.WriteByte(c)
+		}
+	}
+	return "", value, false
+}
+
+// ClientHints parses the User-Agent Client Hints of the request, and requests them for the next requests,
+// see [ParseClientHints] and [RequestClientHints].
+func (c netHttpContext[B, P]) ClientHints() ClientHints {
+	RequestClientHints(c.Res.Header())
+	return ParseClientHints(c.Req.Header)
+}
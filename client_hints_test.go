@@ -0,0 +1,106 @@
+package fuego
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseClientHints(t *testing.T) {
+	t.Run("chrome on android", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("Sec-CH-UA", `"Chromium";v="124", "Google Chrome";v="124", "Not-A.Brand";v="99"`)
+		header.Set("Sec-CH-UA-Mobile", "?1")
+		header.Set("Sec-CH-UA-Platform", `"Android"`)
+		header.Set("Sec-CH-UA-Platform-Version", `"14.0.0"`)
+		header.Set("Sec-CH-UA-Model", `"Pixel 8"`)
+
+		hints := ParseClientHints(header)
+		require.Equal(t, ClientHints{
+			Brands: []ClientHintBrand{
+				{Brand: "Chromium", Version: "124"},
+				{Brand: "Google Chrome", Version: "124"},
+				{Brand: "Not-A.Brand", Version: "99"},
+			},
+			Mobile:          true,
+			Platform:        "Android",
+			PlatformVersion: "14.0.0",
+			Model:           "Pixel 8",
+		}, hints)
+		require.True(t, hints.HasBrand("Google Chrome"))
+		require.False(t, hints.HasBrand("Microsoft Edge"))
+	})
+
+	t.Run("edge on windows", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("Sec-CH-UA", `" Not A;Brand";v="99", "Chromium";v="120", "Microsoft Edge";v="120"`)
+		header.Set("Sec-CH-UA-Mobile", "?0")
+		header.Set("Sec-CH-UA-Platform", `"Windows"`)
+
+		hints := ParseClientHints(header)
+		require.Len(t, hints.Brands, 3)
+		require.Equal(t, " Not A;Brand", hints.Brands[0].Brand)
+		require.True(t, hints.HasBrand("Microsoft Edge"))
+		require.False(t, hints.Mobile)
+		require.Equal(t, "Windows", hints.Platform)
+		require.Empty(t, hints.Model)
+	})
+
+	t.Run("escapes and malformed values", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("Sec-CH-UA", `"Quoted \"Brand\"";v="1", Token;v="2", "No Version"`)
+		header.Set("Sec-CH-UA-Mobile", "true")
+		header.Set("Sec-CH-UA-Platform", "Linux")
+		header.Set("Sec-CH-UA-Model", `"Unterminated`)
+
+		hints := ParseClientHints(header)
+		require.Equal(t, []ClientHintBrand{
+			{Brand: `Quoted "Brand"`, Version: "1"},
+			{Brand: "No Version"},
+		}, hints.Brands)
+		require.False(t, hints.Mobile)
+		require.Empty(t, hints.Platform)
+		require.Empty(t, hints.Model)
+	})
+
+	t.Run("no hints", func(t *testing.T) {
+		require.Zero(t, ParseClientHints(http.Header{}))
+	})
+}
+
+func TestContext_ClientHints(t *testing.T) {
+	s := NewServer()
+	Get(s, "/feed", func(c ContextNoBody) (ClientHints, error) {
+		return c.ClientHints(), nil
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/feed", nil)
+	r.Header.Set("Sec-CH-UA-Mobile", "?1")
+	r.Header.Set("Sec-CH-UA-Platform", `"iOS"`)
+	w := httptest.NewRecorder()
+	s.Mux.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"Mobile":true`)
+	require.Contains(t, w.Body.String(), `"Platform":"iOS"`)
+	require.Equal(t, "Sec-CH-UA, Sec-CH-UA-Mobile, Sec-CH-UA-Platform, Sec-CH-UA-Platform-Version, Sec-CH-UA-Model", w.Header().Get("Accept-CH"))
+	require.Contains(t, w.Header().Get("Vary"), "Sec-CH-UA-Platform")
+
+	t.Run("Accept-CH already set is not duplicated", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("Accept-CH", "sec-ch-ua-model, Sec-CH-Prefers-Color-Scheme")
+		RequestClientHints(header)
+		require.Equal(t, []string{
+			"sec-ch-ua-model, Sec-CH-Prefers-Color-Scheme",
+			"Sec-CH-UA, Sec-CH-UA-Mobile, Sec-CH-UA-Platform, Sec-CH-UA-Platform-Version",
+		}, header.Values("Accept-CH"))
+	})
+
+	t.Run("mock context", func(t *testing.T) {
+		c := NewMockContextNoBody()
+		c.SetHeader("Sec-CH-UA-Platform", `"macOS"`)
+		require.Equal(t, "macOS", c.ClientHints().Platform)
+	})
+}
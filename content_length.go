@@ -0,0 +1,132 @@
+package fuego
+
+import (
+	"bufio"
+	"bytes"
+	"net"
+	"net/http"
+	"strconv"
+)
+
+// WithBufferedResponses buffers the responses to send them with a Content-Length header,
+// for legacy clients that do not support chunked responses.
+// Responses larger than maxBytes, or flushed by the controller (streaming), are sent chunked.
+// 0 means no maximum size: beware of the memory cost of large responses.
+// Disabled by default: net/http only sets the Content-Length of small responses.
+func WithBufferedResponses(maxBytes int64) func(*Server) {
+	return func(s *Server) {
+		s.bufferResponses = true
+		s.maxBufferedResponseSize = maxBytes
+	}
+}
+
+// bufferedResponseWriter buffers the status and body of the response,
+// to send them with a Content-Length header once the controller returns, see [WithBufferedResponses].
+type bufferedResponseWriter struct {
+	http.ResponseWriter
+	r         *http.Request
+	maxBytes  int64
+	buf       bytes.Buffer
+	status    int
+	streaming bool
+	// autoETag computes the ETag of the response from the buffered body, see [WithAutoETag].
+	autoETag bool
+}
+
+func newBufferedResponseWriter(w http.ResponseWriter, r *http.Request, maxBytes int64) *bufferedResponseWriter {
+	return &bufferedResponseWriter{ResponseWriter: w, r: r, maxBytes: maxBytes}
+}
+
+func (w *bufferedResponseWriter) WriteHeader(code int) {
+	// Informational responses are sent before the final response.
+	if w.streaming || code < http.StatusOK {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	if w.status == 0 {
+		w.status = code
+	}
+}
+
+func (w *bufferedResponseWriter) Write(b []byte) (int, error) {
+	if !w.streaming && w.maxBytes > 0 && int64(w.buf.Len()+len(b)) > w.maxBytes {
+		if err := w.stream(); err != nil {
+			return 0, err
+		}
+	}
+	if w.streaming {
+		return w.ResponseWriter.Write(b)
+	}
+	return w.buf.Write(b)
+}
+
+// stream sends what has been buffered so far, and sends the rest of the response as is.
+func (w *bufferedResponseWriter) stream() error {
+	w.streaming = true
+	if w.status != 0 {
+		w.ResponseWriter.WriteHeader(w.status)
+	}
+	_, err := w.ResponseWriter.Write(w.buf.Bytes())
+	w.buf = bytes.Buffer{}
+	return err
+}
+
+// finish sends the buffered response with its Content-Length.
+// A Content-Length set by the controller, see [Context.SetContentLength], is kept.
+func (w *bufferedResponseWriter) finish() error {
+	if w.streaming {
+		return nil
+	}
+
+	status := w.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	header := w.Header()
+	if w.autoETag && status == http.StatusOK {
+		if header.Get("ETag") == "" {
+			header.Set("ETag", contentETag(w.buf.Bytes()))
+		}
+		if IfNoneMatch(w.r.Header, header.Get("ETag")) {
+			header.Del("Content-Length")
+			w.status = http.StatusNotModified
+			w.buf = bytes.Buffer{}
+			return w.stream()
+		}
+	}
+
+	if header.Get("Content-Length") == "" && header.Get("Transfer-Encoding") == "" &&
+		w.r.Method != http.MethodHead && status != http.StatusNoContent && status != http.StatusNotModified {
+		header.Set("Content-Length", strconv.Itoa(w.buf.Len()))
+	}
+
+	return w.stream()
+}
+
+func (w *bufferedResponseWriter) Flush() {
+	if !w.streaming {
+		_ = w.stream()
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *bufferedResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+	w.streaming = true
+	return hijacker.Hijack()
+}
+
+// Unwrap returns the underlying [http.ResponseWriter], for [http.ResponseController].
+func (w *bufferedResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// SetContentLength sets the Content-Length header of the response.
+func (c netHttpContext[B, P]) SetContentLength(n int64) {
+	c.Res.Header().Set("Content-Length", strconv.FormatInt(n, 10))
+}
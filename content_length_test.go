@@ -0,0 +1,105 @@
+package fuego
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithBufferedResponses(t *testing.T) {
+	// Larger than the net/http chunking buffer
+	large := strings.Repeat("a", 10_000)
+
+	newServer := func(options ...func(*Server)) *Server {
+		s := NewServer(options...)
+		Get(s, "/large", func(c ContextNoBody) (ans, error) {
+			return ans{Ans: large}, nil
+		}, OptionDefaultStatusCode(http.StatusCreated))
+		return s
+	}
+
+	t.Run("sends the Content-Length of serialized responses", func(t *testing.T) {
+		s := newServer(WithBufferedResponses(0))
+
+		r := httptest.NewRequest(http.MethodGet, "/large", nil)
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusCreated, w.Code)
+		require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		require.Equal(t, strconv.Itoa(w.Body.Len()), w.Header().Get("Content-Length"))
+		require.Equal(t, crlf(`{"ans":"`+large+`"}`), w.Body.String())
+	})
+
+	t.Run("responses are not chunked", func(t *testing.T) {
+		server := httptest.NewServer(newServer(WithBufferedResponses(0)).Mux)
+		defer server.Close()
+
+		resp, err := http.Get(server.URL + "/large")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		require.Empty(t, resp.TransferEncoding)
+		require.Equal(t, int64(len(body)), resp.ContentLength)
+	})
+
+	t.Run("chunked by default", func(t *testing.T) {
+		server := httptest.NewServer(newServer().Mux)
+		defer server.Close()
+
+		resp, err := http.Get(server.URL + "/large")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, []string{"chunked"}, resp.TransferEncoding)
+	})
+
+	t.Run("streams responses larger than the maximum size", func(t *testing.T) {
+		s := newServer(WithBufferedResponses(1000))
+
+		r := httptest.NewRequest(http.MethodGet, "/large", nil)
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusCreated, w.Code)
+		require.Empty(t, w.Header().Get("Content-Length"))
+		require.Equal(t, crlf(`{"ans":"`+large+`"}`), w.Body.String())
+	})
+
+	t.Run("keeps the Content-Length set by the controller", func(t *testing.T) {
+		s := NewServer(WithBufferedResponses(0))
+		Get(s, "/file", func(c ContextNoBody) (any, error) {
+			c.SetContentLength(5)
+			_, err := c.Response().Write([]byte("hello"))
+			return nil, err
+		})
+
+		r := httptest.NewRequest(http.MethodGet, "/file", nil)
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, "5", w.Header().Get("Content-Length"))
+		require.Equal(t, "hello", w.Body.String())
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		s := NewServer(WithBufferedResponses(0))
+		Get(s, "/error", func(c ContextNoBody) (any, error) {
+			return nil, NotFoundError{Title: "Not Found"}
+		})
+
+		r := httptest.NewRequest(http.MethodGet, "/error", nil)
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+		require.Equal(t, strconv.Itoa(w.Body.Len()), w.Header().Get("Content-Length"))
+	})
+}
@@ -0,0 +1,56 @@
+package fuego
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// RequireContentType returns a 415 [UnsupportedMediaTypeError] if the Content-Type header
+// is missing or does not match one of the allowed media types.
+// The media types are compared case-insensitively, without their parameters (charset, boundary...),
+// and "type/*" allows all the subtypes of a type.
+//
+//	Content-Type: application/json; charset=utf-8
+//	-> nil for RequireContentType(header, "application/json")
+func RequireContentType(header http.Header, allowed ...string) error {
+	contentType := header.Get("Content-Type")
+	if contentType == "" {
+		return UnsupportedMediaTypeError{
+			Title:  "Unsupported Media Type",
+			Detail: "the Content-Type header is required, expected one of: " + strings.Join(allowed, ", "),
+		}
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return UnsupportedMediaTypeError{
+			Title:  "Unsupported Media Type",
+			Err:    err,
+			Detail: "invalid Content-Type " + contentType,
+		}
+	}
+
+	for _, allowedType := range allowed {
+		allowedType = strings.ToLower(strings.TrimSpace(allowedType))
+		if parsed, _, err := mime.ParseMediaType(allowedType); err == nil {
+			allowedType = parsed
+		}
+		if mediaType == allowedType {
+			return nil
+		}
+		if prefix, ok := strings.CutSuffix(allowedType, "/*"); ok && strings.HasPrefix(mediaType, prefix+"/") {
+			return nil
+		}
+	}
+
+	return UnsupportedMediaTypeError{
+		Title:  "Unsupported Media Type",
+		Detail: "unsupported Content-Type " + mediaType + ", expected one of: " + strings.Join(allowed, ", "),
+	}
+}
+
+// RequireContentType checks the Content-Type of the request against the allowed media types, see [RequireContentType].
+func (c netHttpContext[B, P]) RequireContentType(allowed ...string) error {
+	return RequireContentType(c.Req.Header, allowed...)
+}
@@ -0,0 +1,77 @@
+package fuego
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequireContentType(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		allowed     []string
+		ok          bool
+	}{
+		{name: "allowed", contentType: "application/json", allowed: []string{"application/json"}, ok: true},
+		{name: "allowed with parameters", contentType: "application/json; charset=utf-8", allowed: []string{"application/xml", "application/json"}, ok: true},
+		{name: "case-insensitive", contentType: "Application/JSON", allowed: []string{"application/json"}, ok: true},
+		{name: "wildcard subtype", contentType: "text/csv", allowed: []string{"text/*"}, ok: true},
+		{name: "disallowed", contentType: "application/xml", allowed: []string{"application/json"}, ok: false},
+		{name: "wildcard of another type", contentType: "application/csv", allowed: []string{"text/*"}, ok: false},
+		{name: "missing", contentType: "", allowed: []string{"application/json"}, ok: false},
+		{name: "invalid", contentType: "application/json; =", allowed: []string{"application/json"}, ok: false},
+		{name: "nothing allowed", contentType: "application/json", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.contentType != "" {
+				header.Set("Content-Type", tt.contentType)
+			}
+
+			err := RequireContentType(header, tt.allowed...)
+			if tt.ok {
+				require.NoError(t, err)
+				return
+			}
+			var unsupported UnsupportedMediaTypeError
+			require.ErrorAs(t, err, &unsupported)
+			require.Equal(t, http.StatusUnsupportedMediaType, unsupported.StatusCode())
+		})
+	}
+}
+
+func TestContext_RequireContentType(t *testing.T) {
+	s := NewServer()
+	Post(s, "/recipes", func(c ContextNoBody) (string, error) {
+		if err := c.RequireContentType("application/json"); err != nil {
+			return "", err
+		}
+		return "created", nil
+	})
+
+	post := func(contentType string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/recipes", strings.NewReader(`{}`))
+		if contentType != "" {
+			r.Header.Set("Content-Type", contentType)
+		}
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+		return w
+	}
+
+	require.Equal(t, http.StatusOK, post("application/json").Code)
+
+	w := post("text/plain")
+	require.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	require.Contains(t, w.Body.String(), "unsupported Content-Type text/plain")
+
+	w = post("")
+	require.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	require.Contains(t, w.Body.String(), "Content-Type header is required")
+}
@@ -0,0 +1,85 @@
+package fuego
+
+import (
+	"context"
+	"sync"
+)
+
+// contextValueKey is the key of values stored by type, see [ContextValue].
+type contextValueKey[T any] struct{}
+
+// contextValuesKey is the key of the request-scoped [contextValues] store.
+type contextValuesKey struct{}
+
+// contextValues is a request-scoped store, so values can be added to
+// a context after it has been created, see [SetContextValue].
+type contextValues struct {
+	values sync.Map
+}
+
+// withContextValues adds an empty request-scoped store to the context.
+func withContextValues(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextValuesKey{}, &contextValues{})
+}
+
+// ContextWithValue returns a copy of ctx carrying the given value, keyed by its type.
+// Use it in net/http middlewares to inject typed values, for example an auth principal,
+// that handlers retrieve with [ContextValue], without string keys or type assertions.
+//
+//	func AuthMiddleware(next http.Handler) http.Handler {
+//		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+//			user := User{ID: "123"}
+//			next.ServeHTTP(w, r.WithContext(fuego.ContextWithValue(r.Context(), user)))
+//		})
+//	}
+func ContextWithValue[T any](ctx context.Context, value T) context.Context {
+	return context.WithValue(ctx, contextValueKey[T]{}, value)
+}
+
+// SetContextValue stores the given value in the Fuego context, keyed by its type.
+// It overrides any value of the same type set by a middleware with [ContextWithValue].
+// Panics if the context was not created by Fuego (net/http server or [MockContext]).
+//
+//	fuego.SetContextValue(c, User{ID: "123"})
+func SetContextValue[T any](ctx context.Context, value T) {
+	store, ok := ctx.Value(contextValuesKey{}).(*contextValues)
+	if !ok {
+		panic("fuego: SetContextValue requires a context created by Fuego")
+	}
+	store.values.Store(contextValueKey[T]{}, value)
+}
+
+// ContextValue returns the value of type T stored with [SetContextValue] or [ContextWithValue],
+// and whether it was found.
+//
+//	fuego.Get(s, "/me", func(c fuego.ContextNoBody) (User, error) {
+//		user, ok := fuego.ContextValue[User](c)
+//		if !ok {
+//			return User{}, fuego.UnauthorizedError{}
+//		}
+//		return user, nil
+//	})
+func ContextValue[T any](ctx context.Context) (T, bool) {
+	if store, ok := ctx.Value(contextValuesKey{}).(*contextValues); ok {
+		if value, ok := store.values.Load(contextValueKey[T]{}); ok {
+			return value.(T), true
+		}
+	}
+
+	value, ok := ctx.Value(contextValueKey[T]{}).(T)
+	return value, ok
+}
+
+// FromContext returns the value stored under the given key by an upstream middleware with [context.WithValue],
+// asserted to T. It returns false if the value is absent or not of type T, instead of panicking.
+// Prefer [ContextValue] for values set by your own middlewares: FromContext is meant for third-party
+// middlewares using their own keys.
+//
+//	fuego.Get(s, "/me", func(c fuego.ContextNoBody) (string, error) {
+//		userID, ok := fuego.FromContext[string](c, auth.UserIDKey)
+//		...
+//	})
+func FromContext[T any](ctx context.Context, key any) (T, bool) {
+	value, ok := ctx.Value(key).(T)
+	return value, ok
+}
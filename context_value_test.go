@@ -0,0 +1,134 @@
+package fuego
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type principal struct {
+	ID    string
+	Roles []string
+}
+
+func TestContextValue(t *testing.T) {
+	t.Run("value injected by a middleware", func(t *testing.T) {
+		s := NewServer()
+		Get(s, "/me", func(c ContextNoBody) (string, error) {
+			user, ok := ContextValue[principal](c)
+			if !ok {
+				return "", UnauthorizedError{}
+			}
+			return user.ID, nil
+		}, OptionMiddleware(func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				ctx := ContextWithValue(r.Context(), principal{ID: "123", Roles: []string{"admin"}})
+				next.ServeHTTP(w, r.WithContext(ctx))
+			})
+		}))
+
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest("GET", "/me", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "123", w.Body.String())
+	})
+
+	t.Run("value set on the Fuego context", func(t *testing.T) {
+		c := NewNetHTTPContext[any, any](BaseRoute{}, httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil), readOptions{})
+
+		_, ok := ContextValue[principal](c)
+		require.False(t, ok)
+
+		SetContextValue(c, principal{ID: "123"})
+		user, ok := ContextValue[principal](c)
+		require.True(t, ok)
+		require.Equal(t, principal{ID: "123"}, user)
+	})
+
+	t.Run("values are keyed by type", func(t *testing.T) {
+		c := NewMockContextNoBody()
+		SetContextValue(c, principal{ID: "123"})
+		SetContextValue(c, &principal{ID: "456"})
+
+		user, ok := ContextValue[principal](c)
+		require.True(t, ok)
+		require.Equal(t, "123", user.ID)
+
+		userPtr, ok := ContextValue[*principal](c)
+		require.True(t, ok)
+		require.Equal(t, "456", userPtr.ID)
+
+		_, ok = ContextValue[string](c)
+		require.False(t, ok)
+	})
+
+	t.Run("set value overrides the middleware one", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/", nil)
+		r = r.WithContext(ContextWithValue(r.Context(), principal{ID: "middleware"}))
+		c := NewNetHTTPContext[any, any](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+
+		user, _ := ContextValue[principal](c)
+		require.Equal(t, "middleware", user.ID)
+
+		SetContextValue(c, principal{ID: "handler"})
+		user, _ = ContextValue[principal](c)
+		require.Equal(t, "handler", user.ID)
+	})
+
+	t.Run("panics on a context not created by Fuego", func(t *testing.T) {
+		require.Panics(t, func() {
+			SetContextValue(context.Background(), principal{})
+		})
+	})
+}
+
+func TestFromContext(t *testing.T) {
+	type userIDKey struct{}
+
+	s := NewServer()
+	var userID string
+	var found bool
+	var roles []string
+	var rolesFound bool
+	Get(s, "/me", func(c ContextNoBody) (any, error) {
+		userID, found = FromContext[string](c, userIDKey{})
+		roles, rolesFound = FromContext[[]string](c, userIDKey{})
+		return nil, nil
+	}, OptionMiddleware(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if id := r.Header.Get("X-User-ID"); id != "" {
+				r = r.WithContext(context.WithValue(r.Context(), userIDKey{}, id))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}))
+
+	t.Run("present", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/me", nil)
+		r.Header.Set("X-User-ID", "123")
+		s.Mux.ServeHTTP(httptest.NewRecorder(), r)
+
+		require.True(t, found)
+		require.Equal(t, "123", userID)
+	})
+
+	t.Run("wrong type", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/me", nil)
+		r.Header.Set("X-User-ID", "123")
+		s.Mux.ServeHTTP(httptest.NewRecorder(), r)
+
+		require.False(t, rolesFound)
+		require.Nil(t, roles)
+	})
+
+	t.Run("absent", func(t *testing.T) {
+		s.Mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/me", nil))
+
+		require.False(t, found)
+		require.Empty(t, userID)
+	})
+}
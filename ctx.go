@@ -1,11 +1,17 @@
 package fuego
 
 import (
+	"archive/zip"
 	"context"
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"html/template"
 	"io"
 	"io/fs"
+	"iter"
+	"log/slog"
+	"mime"
 	"net/http"
 	"net/url"
 	"reflect"
@@ -51,31 +57,133 @@ type Context[B, P any] interface {
 	Body() (B, error)
 
 	// MustBody works like Body, but panics if there is an error.
+	// In a controller, the error is recovered and sent like a returned error, see [Must].
 	MustBody() B
 
-	// Params returns the typed parameters of the request.
-	// It returns an error if the parameters are not valid.
+	// BodyAny reads the request body without a compile-time type, based on the Content-Type, see [ReadBodyAny]:
+	// map[string]any or []any for JSON and YAML, string for text and XML, [url.Values] for forms, []byte otherwise.
+	// Useful for proxies, debug or echo endpoints.
+	// Example:
+	//   body, err := c.BodyAny()
+	//   if m, ok := body.(map[string]any); ok {
+	//   	log.Println(m["name"])
+	//   }
+	BodyAny() (any, error)
+
+	// BodyLines returns an iterator over the lines of a text request body, read as they are iterated
+	// without loading the whole body into memory, for large uploads like logs. See [ReadLines].
+	// Example:
+	//   for line, err := range c.BodyLines() {
+	//   	if err != nil {
+	//   		return nil, err
+	//   	}
+	//   	count++
+	//   }
+	BodyLines() iter.Seq2[string, error]
+
+	// ExpectContinue reports whether the client sent "Expect: 100-continue" and waits for a "100 Continue"
+	// response before sending the body. To reject a request before its body is sent, return an error without reading it.
+	// See [WithExpectContinueCheck] to validate these requests for all routes.
+	ExpectContinue() bool
+	// SendContinue sends a "100 Continue" informational response if the client expects it.
+	// It is sent automatically when the body is first read, but sending it explicitly
+	// avoids waiting the client timeout when the body is read later.
+	// Example:
+	//   if c.ExpectContinue() {
+	//   	if c.Request().ContentLength > maxUpload {
+	//   		return nil, fuego.HTTPError{Status: http.StatusRequestEntityTooLarge}
+	//   	}
+	//   	c.SendContinue()
+	//   }
+	SendContinue()
+
+	// EarlyHints sends a "103 Early Hints" informational response with Link preload headers,
+	// before the final response, so browsers start fetching the assets while the controller runs.
+	// Links are paths or URLs to preload, or complete Link header values. Only sent to HTTP/2 and later clients.
+	// See [SendEarlyHints].
+	// Example:
+	//   c.EarlyHints("/static/app.css", "/static/app.js")
+	//   page, err := renderSlowPage(c)
+	EarlyHints(links ...string)
+
+	// Trailers returns the trailer headers of the request, sent after a chunked body (like the signature of AWS chunked uploads).
+	// Trailers are only received once the body has been consumed:
+	// call [Context.Body] or [Context.BodyAny] first, the rest of the body is discarded.
+	// Example:
+	//   body, err := c.Body()
+	//   ...
+	//   checksum := c.Trailer("X-Checksum")
+	Trailers() http.Header
+	// Trailer returns the value of the given trailer header, see [Context.Trailers].
+	Trailer(key string) string
+
+	// VerifyContentMD5 checks the request body against the Content-MD5 header.
+	// It returns a [BadRequestError] if the header is missing or does not match.
+	// The body is buffered, so [Context.Body] can still be called afterwards.
+	VerifyContentMD5() error
+	// VerifyDigestSHA256 checks the request body against the SHA-256 value of the Digest header.
+	// It returns a [BadRequestError] if the header is missing or does not match.
+	// The body is buffered, so [Context.Body] can still be called afterwards.
+	VerifyDigestSHA256() error
+	// VerifySignature checks the HMAC of the request body, computed with the secret and the algorithm
+	// ("sha256", "sha512" or "sha1"), against the signature of the given header, for webhooks.
+	// It returns a 401 [UnauthorizedError] if the header is missing or does not match, see [VerifySignature].
+	// The body is buffered, so [Context.Body] can still be called afterwards.
+	// Example:
+	//   if err := c.VerifySignature("X-Hub-Signature-256", secret, "sha256"); err != nil {
+	//   	return nil, err
+	//   }
+	//   event, err := c.Body()
+	VerifySignature(header string, secret []byte, algo string) error
+	// MultipartRelated reads a multipart/related request body (RFC 2387), like JSON metadata with binary attachments.
+	// It returns the body of the root part, identified by the start parameter of the Content-Type
+	// or the first part without it, and the other parts. It returns a [BadRequestError] if the body is invalid.
+	// Example:
+	//   metadata, attachments, err := c.MultipartRelated()
+	//   var document Document
+	//   err = json.Unmarshal(metadata, &document)
+	MultipartRelated() ([]byte, []RelatedPart, error)
+
+	// Params returns the typed parameters of the request, bound from the query, header, path and cookie tags
+	// of the fields of P, see [BindParams] for the binding rules.
+	// It returns a [BadRequestError] if a required parameter is missing or if a value cannot be converted.
 	// Please do not use a pointer type as parameters.
+	// Example:
+	//   type RecipeParams struct {
+	//   	ID    int    `path:"id"`
+	//   	Page  int    `query:"page" default:"1"`
+	//   	Token string `header:"X-Token" required:"true"`
+	//   }
 	//
-	// Deprecated: Not defined yet, incoming in future Fuego versions.
+	//   fuego.Get(s, "/recipes/{id}", func(c fuego.ContextWithParams[RecipeParams]) (Recipe, error) {
+	//   	params, err := c.Params()
+	//   	...
+	//   })
 	Params() (P, error)
 
 	// MustParams works like Params, but panics if there is an error.
-	//
-	// Deprecated: Not defined yet, incoming in future Fuego versions.
+	// In a controller, the error is recovered and sent like a returned error, see [Must].
 	MustParams() P
 
-	// PathParam returns the path parameter with the given name.
-	// If it does not exist, it returns an empty string.
+	// PathParam returns the path parameter with the given name, URL-decoded:
+	// "a/b c" for /files/a%2Fb%20c and the pattern /files/{name}.
+	// If it does not exist, it returns an empty string. Use [Context.PathParamRaw] for the value as sent by the client.
 	// Example:
 	//   fuego.Get(s, "/recipes/{recipe_id}", func(c fuego.ContextNoBody) (any, error) {
 	//	 	id := c.PathParam("recipe_id")
 	//   	...
 	//   })
 	PathParam(name string) string
+	// PathParamRaw returns the path parameter with the given name as sent by the client, without URL-decoding:
+	// "a%2Fb%20c" for /files/a%2Fb%20c and the pattern /files/{name}, see [PathParamRaw].
+	PathParamRaw(name string) string
 	// If the path parameter is not provided or is not an int, it returns 0. Use [Ctx.PathParamIntErr] if you want to know if the path parameter is erroneous.
 	PathParamInt(name string) int
 	PathParamIntErr(name string) (int, error)
+	// PathParamIntArr splits the path parameter with the given separator (defaults to ",")
+	// and parses each element as an int, for paths like /items/1,2,3.
+	// Returns a [PathParamInvalidTypeError] if an element is not an int.
+	PathParamIntArr(name, sep string) ([]int, error)
 
 	QueryParam(name string) string
 	QueryParamArr(name string) []string
@@ -83,7 +191,26 @@ type Context[B, P any] interface {
 	QueryParamIntErr(name string) (int, error)
 	QueryParamBool(name string) bool // If the query parameter is not provided or is not a bool, it returns the default given value. Use [Ctx.QueryParamBoolErr] if you want to know if the query parameter is erroneous.
 	QueryParamBoolErr(name string) (bool, error)
+	// QueryParamBoolLenient works like QueryParamBool, but also accepts "yes"/"no", "on"/"off" and "y"/"n", case-insensitively,
+	// as sent by HTML forms. If the query parameter is not provided or is not a bool, it returns def.
+	QueryParamBoolLenient(name string, def bool) bool
 	QueryParams() url.Values
+	// FormValues returns all the values of the given field of the urlencoded or multipart/form-data body,
+	// like the options of a multi-select field. It parallels [Context.QueryParamArr] for form bodies.
+	// The form is parsed once, so it can be called several times, and [Context.Body] afterwards.
+	// Example:
+	//   roles := c.FormValues("role") // role=admin&role=editor -> ["admin", "editor"]
+	FormValues(name string) []string
+	// FormValuesInt works like FormValues, but parses the values as ints.
+	// It returns a [BadRequestError] if a value is not an int.
+	FormValuesInt(name string) ([]int, error)
+	QueryString() string       // QueryString returns the raw query string of the request, without the leading '?'.
+	QueryParamsSorted() string // QueryParamsSorted returns the query string with sorted keys and values. Useful as a stable cache key.
+
+	// ParamsSpec returns the parameters declared for the route (path, query, header and cookie),
+	// sorted by location then name. Useful to build generic API explorers or middlewares.
+	// Modifying the returned slice does not change the route.
+	ParamsSpec() []OpenAPIParam
 
 	MainLang() string   // ex: fr. MainLang returns the main language of the request. It is the first language of the Accept-Language header. To get the main locale (ex: fr-CA), use [Ctx.MainLocale].
 	MainLocale() string // ex: en-US. MainLocale returns the main locale of the request. It is the first locale of the Accept-Language header. To get the main language (ex: en), use [Ctx.MainLang].
@@ -106,21 +233,271 @@ type Context[B, P any] interface {
 	// By default, [templateToExecute] is added to the list of templates to override.
 	Render(templateToExecute string, data any, templateGlobsToOverride ...string) (CtxRenderer, error)
 
+	// RenderMarkdown converts the given Markdown to sanitized HTML,
+	// using the renderer set with [WithMarkdownRenderer].
+	// If no renderer is set, the content is only HTML-escaped.
+	RenderMarkdown(md string) template.HTML
+
+	// CheckRateLimit returns a [RetryableError] (429 Too Many Requests) if the rate limiter set with [WithRateLimiter]
+	// denies the given key, and sets the Retry-After header. Requests are always allowed without rate limiter,
+	// as with the gin and echo adaptors.
+	// Example:
+	//   ip, _, _ := net.SplitHostPort(c.Request().RemoteAddr)
+	//   if err := c.CheckRateLimit(ip); err != nil {
+	//   	return nil, err
+	//   }
+	CheckRateLimit(key string) error
+
+	// APIVersion returns the API version of the request, without the "v" prefix, from the sources set with
+	// [WithAPIVersioning]: by default the path (/v2/...), the X-Api-Version header, then the Accept header
+	// (application/vnd.myapp.v2+json). It returns an [APIVersionError] if the version is required but absent, or not supported.
+	// Example:
+	//   version, err := c.APIVersion()
+	//   if err != nil {
+	//   	return nil, err
+	//   }
+	//   if version == "1" {
+	//   	return legacyRecipes(c)
+	//   }
+	APIVersion() (string, error)
+
 	Cookie(name string) (*http.Cookie, error) // Get request cookie
 	SetCookie(cookie http.Cookie)             // Sets response cookie
 	Header(key string) string                 // Get request header
 	SetHeader(key, value string)              // Sets response header
+	// SetContentLength sets the Content-Length response header, for clients that refuse chunked responses.
+	// It must match the size of the body. To compute it for serialized responses, see [WithBufferedResponses].
+	SetContentLength(n int64)
+	// ResetHeaders clears all the response headers, for example to send a clean error response
+	// after a controller partially set headers.
+	// Returns [ErrHeadersAlreadySent] if the response has already been written.
+	ResetHeaders() error
+
+	// Prefer returns the value of the given preference of the Prefer header (RFC 7240), and whether it was sent.
+	// Handlers can honor it and confirm it with [Context.SetPreferenceApplied].
+	// Example:
+	//   fuego.Post(s, "/recipes", func(c fuego.ContextWithBody[Recipe]) (any, error) {
+	//   	recipe, err := create(c)
+	//   	...
+	//   	if ret, _ := c.Prefer("return"); ret == "minimal" {
+	//   		c.SetPreferenceApplied("return", "minimal")
+	//   		c.SetStatus(http.StatusNoContent)
+	//   		return nil, nil
+	//   	}
+	//   	return recipe, nil
+	//   })
+	Prefer(name string) (string, bool)
+	// SetPreferenceApplied adds the given preference to the Preference-Applied response header.
+	SetPreferenceApplied(name, value string)
+
+	// Vary adds the given request headers to the Vary response header, without duplicates,
+	// when the response depends on them. The built-in negotiations (serialization from the Accept header,
+	// [Context.ResponseCharset], [Context.MainLocale], [Context.APIVersion]...) add their headers automatically.
+	// Example:
+	//   c.Vary("X-Tenant")
+	Vary(headers ...string)
 
-	// Returns the underlying net/http, gin or echo context.
+	// ClientHints parses the User-Agent Client Hints of the request (Sec-CH-UA, Sec-CH-UA-Mobile, Sec-CH-UA-Platform...),
+	// to adapt the response to the device without User-Agent sniffing. It also sets the Accept-CH response header,
+	// so the browser sends all of them on the next requests, and adds them to the Vary header. See [ParseClientHints].
+	// Example:
+	//   if c.ClientHints().Mobile {
+	//   	return compactFeed(feed), nil
+	//   }
+	ClientHints() ClientHints
+
+	// RequireContentType returns a 415 [UnsupportedMediaTypeError] if the Content-Type of the request
+	// is missing or not one of the allowed media types. Parameters like charset are ignored,
+	// and "type/*" allows all the subtypes.
+	// Example:
+	//   if err := c.RequireContentType("application/json", "application/xml"); err != nil {
+	//   	return nil, err
+	//   }
+	RequireContentType(allowed ...string) error
+
+	// IfMatch reports whether the If-Match header of the request (RFC 9110) matches the given ETag
+	// of the current resource, with a strong comparison. "*" matches any existing resource (non-empty ETag).
+	// It returns true when the header is absent, as there is no precondition.
+	IfMatch(etag string) bool
+	// RequireIfMatch returns a 412 [PreconditionFailedError] if the If-Match header is absent
+	// or does not match the current ETag of the resource, for optimistic concurrency on updates.
+	// Example:
+	//   fuego.Put(s, "/recipes/{id}", func(c fuego.ContextWithBody[Recipe]) (Recipe, error) {
+	//   	current := store.Get(c.PathParam("id"))
+	//   	if err := c.RequireIfMatch(current.ETag()); err != nil {
+	//   		return Recipe{}, err
+	//   	}
+	//   	...
+	//   })
+	RequireIfMatch(current string) error
+
+	// StartTiming starts measuring a sub-operation of the controller, like a database query or a rendering,
+	// and returns a function to call when it ends. Its duration is added to the Server-Timing header,
+	// along with the timings measured by Fuego.
+	// Example:
+	//   stop := c.StartTiming("db")
+	//   recipes, err := db.ListRecipes(c)
+	//   stop()
+	//   // Server-Timing: db;dur=12, ..., controller;dur=15
+	StartTiming(name string) func()
+
+	// SetLinkHeader sets the Link response header (RFC 8288), mapping relation types to URLs.
+	// Use it with [PaginationLinks] to advertise pagination to clients.
+	// Example:
+	//   c.SetLinkHeader(fuego.PaginationLinks(c.Request().URL, page, perPage, total))
+	//   // Link: </pets?page=1&per_page=10>; rel="first", </pets?page=3&per_page=10>; rel="next", ...
+	SetLinkHeader(links map[string]string)
+
+	// Deprecate marks the endpoint as deprecated while still returning the response,
+	// with the Deprecation, Sunset, Link and Warning headers, see [SetDeprecationHeaders].
+	// date is the date the endpoint will be removed, link the URL of the deprecation notice.
+	// Example:
+	//   c.Deprecate(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), "https://example.com/changelog#v1")
+	Deprecate(date time.Time, link string)
+
+	// SetExpires sets the Expires header of the response, the date after which the response is stale.
+	// Caches ignore it if the Cache-Control header has a max-age directive.
+	// Example:
+	//   c.SetExpires(time.Now().Add(time.Hour))
+	//   // Expires: Wed, 01 Jan 2025 00:00:00 GMT
+	SetExpires(t time.Time)
+
+	// RemoteIP returns the IP address of the client, from the Forwarded header (RFC 7239),
+	// the X-Forwarded-For header or the remote address of the connection, in this order.
+	// Clients can set these headers: only rely on them behind a proxy that overwrites them.
+	// The first address is chosen by the client, so do not use it for rate limiting or access control:
+	// any client could change its address by sending another header.
+	RemoteIP() string
+	// FullURL returns the URL requested by the client, with the scheme and host
+	// from the Forwarded header, the X-Forwarded-Proto and X-Forwarded-Host headers or the request.
+	// Example:
+	//   c.FullURL() // "https://example.com/recipes?page=2" behind a TLS-terminating proxy
+	FullURL() string
+	// ForwardedFor returns the addresses of the client and of the proxies,
+	// from the Forwarded header or the X-Forwarded-For header. The first address is the client.
+	ForwardedFor() []string
+
+	// AcceptOffers returns the media ranges of the Accept header, with their quality and parameters,
+	// by order of preference, for custom content negotiation. See [ParseAccept].
+	// Example:
+	//   for _, offer := range c.AcceptOffers() {
+	//   	if offer.Quality > 0 && offer.MediaType() == "application/vnd.api+json" {
+	//   		...
+	//   	}
+	//   }
+	AcceptOffers() []MediaOffer
+
+	// Logger returns a logger with the attributes of the request ("request_id", "route", "method" and "remote_ip"),
+	// to correlate the logs of a request. The base logger is set with [WithLogger].
+	// Example:
+	//   c.Logger().Info("recipe created", "id", recipe.ID)
+	//   // level=INFO msg="recipe created" request_id=3f1c... route="POST /recipes" method=POST remote_ip=203.0.113.7 id=42
+	Logger() *slog.Logger
+
+	// TraceID returns the W3C Trace Context trace ID of the request: the one of the incoming traceparent header,
+	// or a new one if absent, to correlate the logs of all the services handling the request. See [NewTraceContext].
+	TraceID() string
+	// SpanID returns the W3C Trace Context span ID of the handling of the request by this server, new for each request.
+	SpanID() string
+	// PropagateTrace sets the traceparent and tracestate headers of an outgoing request,
+	// so the service called continues the trace of the request.
+	// Example:
+	//   req, _ := http.NewRequestWithContext(c, http.MethodGet, "http://inventory/stock/42", nil)
+	//   c.PropagateTrace(req)
+	//   resp, err := http.DefaultClient.Do(req)
+	PropagateTrace(req *http.Request)
+
+	// ClientCertificate returns the client certificate verified during the TLS handshake, for mTLS authentication.
+	// It returns false for non-TLS requests, and if no certificate was verified. See [ClientCertificate].
+	// Example:
+	//   cert, ok := c.ClientCertificate()
+	//   if !ok {
+	//   	return nil, fuego.UnauthorizedError{Title: "Client Certificate Required"}
+	//   }
+	//   return store.ServiceByName(cert.Subject.CommonName)
+	ClientCertificate() (*x509.Certificate, bool)
+	// TLSVersion returns the TLS version of the connection, like tls.VersionTLS13, or 0 for non-TLS requests.
+	TLSVersion() uint16
+
+	// OnFinish registers a callback run after the controller returns and the response is written,
+	// with the error returned by the controller (or by the framework, like a validation error), or nil. Callbacks run in reverse order of registration, like deferred calls.
+	// Useful to release resources or record metrics once the request is over.
+	// Callbacks also run if the controller panics, with an error describing the panic.
+	// Example:
+	//   start := time.Now()
+	//   c.OnFinish(func(err error) {
+	//   	metrics.Observe(c.Method(), time.Since(start), err != nil)
+	//   })
+	OnFinish(fn func(err error))
+
+	// Tx returns the transaction of the request begun by the [TxProvider], and whether there is one.
+	// It is committed before the response if the controller succeeds, and rolled back otherwise. See [WithTxProvider].
+	// Example:
+	//   tx, ok := c.Tx()
+	//   if !ok {
+	//   	return nil, errors.New("no transaction")
+	//   }
+	//   _, err := tx.(*sql.Tx).ExecContext(c, "INSERT INTO recipes (name) VALUES (?)", body.Name)
+	Tx() (any, bool)
+
+	// Cursor decodes the opaque "cursor" query parameter, for cursor-based pagination.
+	// It returns the zero [Cursor] for the first page, and a [BadRequestError] if the token is malformed.
+	// Encode the cursor of the next page with [NextCursor].
+	// Example:
+	//   cursor, err := c.Cursor()
+	//   if err != nil {
+	//   	return nil, err
+	//   }
+	//   pets, last := store.ListPetsAfter(cursor.After, cmp.Or(cursor.Limit, 20))
+	//   return PetsPage{Pets: pets, Next: fuego.NextCursor(fuego.Cursor{After: last, Limit: cursor.Limit})}, nil
+	Cursor() (Cursor, error)
+
+	// TimeRange parses the time range given by two query parameters, with the given layout ([time.RFC3339] if empty).
+	// Without an end the range ends now, and without a start it lasts 24 hours, see [TimeRangeDefault].
+	// It returns a [BadRequestError] if a bound is malformed or if the start is after the end.
+	// Example:
+	//   from, to, err := c.TimeRange("from", "to", time.DateOnly, fuego.TimeRangeMax(31*24*time.Hour))
+	//   if err != nil {
+	//   	return nil, err
+	//   }
+	//   return store.Revenue(c, from, to)
+	TimeRange(fromParam, toParam, layout string, options ...TimeRangeOption) (from, to time.Time, err error)
+
+	// DebugInfo returns the metadata of the request, for debugging endpoints: "method", "path", "route" (the matched pattern),
+	// "query", "headers", "remote_ip", and the negotiated content types "accept" and "charset".
+	// Sensitive headers, like Authorization and Cookie, are redacted, see [WithDebugRedactedHeaders].
+	// Example:
+	//   fuego.Get(s, "/debug/echo", func(c fuego.ContextNoBody) (map[string]any, error) {
+	//   	return c.DebugInfo(), nil
+	//   })
+	DebugInfo() map[string]any
+
+	// ResponseCharset returns the charset negotiated from the Accept-Charset header, "utf-8" by default.
+	// Text and HTML responses are sent with this charset, see [NegotiateCharset].
+	ResponseCharset() string
+
+	// BytesWritten returns the number of bytes written in the response body so far.
+	// See [WithResponseSizeLimit] to limit it.
+	BytesWritten() int64
+
+	// SetBandwidthLimit caps the rate of the rest of the response body, in bytes per second, for fair bandwidth sharing
+	// on downloads and streams. A limit of 0 removes it. See [ThrottledWriter].
+	// Example:
+	//   c.SetBandwidthLimit(512 << 10) // 512 KiB/s
+	//   return nil, fuego.ServeFileCompressed(c.Response(), c.Request(), videos, c.PathParam("name"))
+	SetBandwidthLimit(bytesPerSec int64)
+
+	// Returns the underlying net/http or gin context.
 	//
 	// Usage:
-	//  ctx := c.Context() // net/http: the [context.Context] of the *http.Request
-	//  ctx := c.Context().(*gin.Context) // gin: Safe because the underlying context is always a [gin.Context]
-	//  ctx := c.Context().(echo.Context) // echo: Safe because the underlying context is always a [echo.Context]
+	//  ctx := c.Context() // net/http and echo: the [context.Context] of the *http.Request
+	//  ginCtx, ok := fuegogin.GinContext(c) // gin: the [gin.Context], ok is false for other backends
+	//  echoCtx, ok := fuegoecho.EchoContext(c) // echo: the [echo.Context], ok is false for other backends
 	Context() context.Context
 
 	Request() *http.Request        // Request returns the underlying HTTP request.
 	Response() http.ResponseWriter // Response returns the underlying HTTP response writer.
+	Method() string                // Method returns the HTTP method of the request, like "GET". Shortcut for Request().Method.
 
 	// SetStatus sets the status code of the response.
 	// Alias to http.ResponseWriter.WriteHeader.
@@ -133,20 +510,139 @@ type Context[B, P any] interface {
 	//   	return c.Redirect(301, "/recipes-list")
 	//   })
 	Redirect(code int, url string) (any, error)
+
+	// RedirectPreserveQuery redirects to the given path with the given status code,
+	// adding the query parameters of the request that are not already set in the path.
+	// Example:
+	//   // GET /account?next=/cart -> 303 See Other, Location: /login?next=%2Fcart
+	//   fuego.Get(s, "/account", func(c fuego.ContextNoBody) (any, error) {
+	//   	return c.RedirectPreserveQuery(http.StatusSeeOther, "/login")
+	//   })
+	RedirectPreserveQuery(code int, path string) (any, error)
+
+	// Health runs the health checks concurrently, and returns a [HealthResponse] with the result of each check:
+	// {"status":"pass","checks":[{"name":"database","status":"pass"}]}.
+	// The status code is 503 Service Unavailable if a check fails, 200 otherwise.
+	// Example:
+	//   fuego.Get(s, "/health", func(c fuego.ContextNoBody) (any, error) {
+	//   	return c.Health(fuego.HealthCheck{Name: "database", Check: db.PingContext})
+	//   })
+	Health(checks ...HealthCheck) (any, error)
+
+	// SendRaw writes the given already-serialized payload to the response, bypassing the serializer.
+	// The Content-Type and Content-Length headers are set, along with the default status code of the route.
+	// Useful to send cached responses without re-marshaling them.
+	// Example:
+	//   fuego.Get(s, "/recipes", func(c fuego.ContextNoBody) (any, error) {
+	//   	if cached, ok := cache.Get("recipes"); ok {
+	//   		return c.SendRaw("application/json", cached)
+	//   	}
+	//   	...
+	//   })
+	SendRaw(contentType string, data []byte) (any, error)
+
+	// MultipartResponse starts a multipart/mixed response, for batch endpoints returning several parts,
+	// each with its own headers and body. The parts are flushed to the client as they are written.
+	// Returns [ErrHeadersAlreadySent] if the response has already been written.
+	// Example:
+	//   fuego.Post(s, "/batch", func(c fuego.ContextWithBody[[]Request]) (any, error) {
+	//   	mw, err := c.MultipartResponse()
+	//   	if err != nil {
+	//   		return nil, err
+	//   	}
+	//   	for _, result := range results {
+	//   		mw.WritePart(textproto.MIMEHeader{"Content-Type": {"application/json"}}, result)
+	//   	}
+	//   	return nil, mw.Close()
+	//   })
+	MultipartResponse() (*MultipartWriter, error)
+
+	// SerializeFields sends the data as JSON with only the requested top-level fields (sparse fieldsets),
+	// of the data or of each element for collections, see [FilterFields]. Unknown fields are ignored.
+	// Without fields, the data is serialized as usual.
+	// Example:
+	//   fuego.Get(s, "/recipes", func(c fuego.ContextNoBody) (any, error) {
+	//   	recipes := ...
+	//   	fields := strings.Split(c.QueryParam("fields"), ",") // ?fields=id,name
+	//   	return nil, c.SerializeFields(recipes, fields)
+	//   })
+	SerializeFields(data any, fields []string) error
+
+	// TailStream writes each string received from the channel as a line, flushed to the client,
+	// until the channel is closed or the client disconnects, for `tail -f`-style log endpoints.
+	// The producer should stop sending when the request context is done. See [WriteLines].
+	// Example:
+	//   fuego.Get(s, "/logs", func(c fuego.ContextNoBody) (any, error) {
+	//   	lines := make(chan string)
+	//   	go followLogs(c.Context(), lines) // closes lines when done
+	//   	return nil, c.TailStream(lines)
+	//   })
+	TailStream(ch <-chan string) error
+
+	// StreamNDJSON writes each item of the iterator as a line of JSON ("application/x-ndjson"),
+	// flushed to the client periodically, for large exports consumed incrementally.
+	// The iteration stops if the client disconnects. See [WriteNDJSON].
+	// Example:
+	//   fuego.Get(s, "/recipes/export", func(c fuego.ContextNoBody) (any, error) {
+	//   	return nil, c.StreamNDJSON(func(yield func(any) bool) {
+	//   		for recipe := range store.AllRecipes(c) {
+	//   			if !yield(recipe) {
+	//   				return
+	//   			}
+	//   		}
+	//   	})
+	//   })
+	StreamNDJSON(items iter.Seq[any]) error
+
+	// ZipStream streams a ZIP archive to the client, for "download all" endpoints: fn adds the entries
+	// to the [zip.Writer], which are sent as they are compressed. The archive is sent as an attachment
+	// named "archive.zip", unless the Content-Disposition header is set before. See [WriteZip].
+	// Example:
+	//   fuego.Get(s, "/invoices.zip", func(c fuego.ContextNoBody) (any, error) {
+	//   	c.SetHeader("Content-Disposition", `attachment; filename="invoices.zip"`)
+	//   	return c.ZipStream(func(zw *zip.Writer) error {
+	//   		for _, invoice := range invoices {
+	//   			f, err := zw.Create(invoice.Name + ".pdf")
+	//   			if err != nil {
+	//   				return err
+	//   			}
+	//   			if _, err := f.Write(invoice.PDF); err != nil {
+	//   				return err
+	//   			}
+	//   		}
+	//   		return nil
+	//   	})
+	//   })
+	ZipStream(fn func(zw *zip.Writer) error) (any, error)
 }
 
 // NewNetHTTPContext returns a new context. It is used internally by Fuego. You probably want to use Ctx[B] instead.
 func NewNetHTTPContext[B, P any](route BaseRoute, w http.ResponseWriter, r *http.Request, options readOptions) *netHttpContext[B, P] {
+	// Count the bytes written, see [Context.BytesWritten].
+	if _, ok := w.(*responseSizeWriter); !ok {
+		w = newResponseSizeWriter(w, r, ResponseSizeLimit{})
+	}
+
+	urlValues := r.URL.Query()
+	if options.TrimParamWhitespace {
+		for _, values := range urlValues {
+			for i := range values {
+				values[i] = strings.TrimSpace(values[i])
+			}
+		}
+	}
+
 	c := &netHttpContext[B, P]{
 		CommonContext: internal.CommonContext[B]{
-			CommonCtx:         r.Context(),
-			UrlValues:         r.URL.Query(),
+			CommonCtx:         withContextValues(r.Context()),
+			UrlValues:         urlValues,
 			OpenAPIParams:     route.Params,
 			DefaultStatusCode: route.DefaultStatusCode,
 		},
-		Req:         r,
-		Res:         w,
-		readOptions: options,
+		Req:             r,
+		Res:             w,
+		readOptions:     options,
+		finishCallbacks: &FinishCallbacks{},
 	}
 
 	return c
@@ -165,6 +661,16 @@ type netHttpContext[Body, Params any] struct {
 	Req       *http.Request
 	templates *template.Template
 
+	markdownRenderer MarkdownRenderer
+	rateLimiter      RateLimiter
+	apiVersioning    APIVersionConfig
+
+	debugRedactedHeaders []string
+	jsonpParam           string
+	logger               *slog.Logger
+
+	finishCallbacks *FinishCallbacks
+
 	serializer      Sender
 	errorSerializer ErrorSender
 
@@ -194,9 +700,37 @@ func (c netHttpContext[B, P]) SetStatus(code int) {
 
 // readOptions are options for reading the request body.
 type readOptions struct {
-	MaxBodySize           int64
+	MaxBodySize int64
+	// Maximum duration allowed to read the whole request body. 0 means no timeout.
+	BodyReadTimeout       time.Duration
 	DisallowUnknownFields bool
 	LogBody               bool
+	// Validates the raw JSON body before deserialization. nil means no validation.
+	JSONSchema JSONSchemaValidator
+	// Decoders of the route by media type, consulted before the built-in ones.
+	Decoders map[string]BodyDecoder
+	// Trim leading and trailing whitespace of query and path params.
+	TrimParamWhitespace bool
+	// Rewrites the Content-Type of the request before decoding the body. nil means no rewriting.
+	ContentTypeNormalizer func(string) string
+	// Decrypts the fields tagged `encrypted:"true"` of JSON bodies. nil means no decryption.
+	FieldKeys FieldKeyProvider
+	// Maximum nesting depth of JSON bodies. 0 means no limit.
+	MaxJSONDepth int
+	// Maximum number of parts of multipart/form-data bodies. 0 means no limit.
+	MaxMultipartParts int
+	// Maximum number of field values of form bodies. 0 means no limit.
+	MaxFormFields int
+	// Report the number of bytes read from the request body in the X-Body-Bytes response header.
+	ReportBodySize bool
+	// Reject text/plain and JSON bodies that are not valid UTF-8.
+	ValidateUTF8 bool
+	// Decode JSON bodies from the object under this key of an envelope. Empty means no envelope.
+	BodyUnwrapKey string
+	// Codec of the protobuf bodies. nil means only []byte bodies are supported.
+	ProtoCodec ProtoCodec
+	// Codec of the protobuf messages read as JSON. nil means they are read with encoding/json.
+	ProtoJSONCodec ProtoCodec
 }
 
 func (c netHttpContext[B, P]) Redirect(code int, location string) (any, error) {
@@ -204,6 +738,15 @@ func (c netHttpContext[B, P]) Redirect(code int, location string) (any, error) {
 	return nil, nil
 }
 
+// SendRaw writes the given already-serialized payload to the response, bypassing the serializer.
+func (c netHttpContext[B, P]) SendRaw(contentType string, data []byte) (any, error) {
+	c.Res.Header().Set("Content-Type", contentType)
+	c.Res.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	c.SetDefaultStatusCode()
+	_, err := c.Res.Write(data)
+	return nil, err
+}
+
 // Header returns the value of the given header.
 func (c netHttpContext[B, P]) Header(key string) string {
 	return c.Request().Header.Get(key)
@@ -219,6 +762,18 @@ func (c netHttpContext[B, P]) SetHeader(key, value string) {
 	c.Response().Header().Set(key, value)
 }
 
+// ErrHeadersAlreadySent is returned when modifying headers after they have been sent.
+var ErrHeadersAlreadySent = errors.New("headers already sent")
+
+// ResetHeaders clears all the response headers. Returns [ErrHeadersAlreadySent] if they have already been sent.
+func (c netHttpContext[B, P]) ResetHeaders() error {
+	if w, ok := c.Res.(*responseSizeWriter); ok && w.wroteHeader {
+		return ErrHeadersAlreadySent
+	}
+	clear(c.Res.Header())
+	return nil
+}
+
 // Cookie get request cookie
 func (c netHttpContext[B, P]) Cookie(name string) (*http.Cookie, error) {
 	return c.Request().Cookie(name)
@@ -253,11 +808,61 @@ func (c netHttpContext[B, P]) Render(templateToExecute string, data any, layouts
 	}, nil
 }
 
-// PathParam returns the path parameters of the request.
+// RenderMarkdown converts the given Markdown to sanitized HTML.
+func (c netHttpContext[B, P]) RenderMarkdown(md string) template.HTML {
+	return renderMarkdown(c.markdownRenderer, md)
+}
+
+// PathParam returns the path parameters of the request, URL-decoded.
 func (c netHttpContext[B, P]) PathParam(name string) string {
+	if c.readOptions.TrimParamWhitespace {
+		return strings.TrimSpace(c.Req.PathValue(name))
+	}
 	return c.Req.PathValue(name)
 }
 
+// PathParamRaw returns the path parameter of the request as sent by the client, without URL-decoding.
+func (c netHttpContext[B, P]) PathParamRaw(name string) string {
+	return PathParamRaw(c.Req, name)
+}
+
+// PathParamRaw returns the path parameter with the given name as sent by the client, without URL-decoding:
+// "a%2Fb" for /files/a%2Fb and the pattern /files/{name}, when [http.Request.PathValue] returns "a/b".
+// The segments are read from the escaped path of the request, at the position of the wildcard in the matched pattern.
+// If the request was not routed by an [http.ServeMux], the decoded value is escaped again with [url.PathEscape].
+func PathParamRaw(r *http.Request, name string) string {
+	if raw, ok := rawPathValue(r.Pattern, r.URL.EscapedPath(), name); ok {
+		return raw
+	}
+	return url.PathEscape(r.PathValue(name))
+}
+
+// rawPathValue returns the escaped segments of the path matching the given wildcard of the pattern,
+// like "GET /files/{name}" or "/static/{path...}".
+func rawPathValue(pattern, escapedPath, name string) (string, bool) {
+	// Removes the method and host of the pattern
+	if i := strings.Index(pattern, "/"); i >= 0 {
+		pattern = pattern[i:]
+	} else {
+		return "", false
+	}
+
+	patternSegments := strings.Split(strings.TrimPrefix(pattern, "/"), "/")
+	pathSegments := strings.Split(strings.TrimPrefix(escapedPath, "/"), "/")
+	for i, segment := range patternSegments {
+		if i >= len(pathSegments) {
+			return "", false
+		}
+		switch segment {
+		case "{" + name + "}":
+			return pathSegments[i], true
+		case "{" + name + "...}":
+			return strings.Join(pathSegments[i:], "/"), true
+		}
+	}
+	return "", false
+}
+
 type PathParamNotFoundError struct {
 	ParamName string
 }
@@ -312,10 +917,44 @@ func PathParamIntErr(c ContextWithPathParam, name string) (int, error) {
 	return i, nil
 }
 
+// PathParamIntArr splits the path parameter with the given separator and parses each element as an int.
+// The separator defaults to ",". Elements are trimmed of spaces, an empty element is an error.
+func PathParamIntArr(c ContextWithPathParam, name, sep string) ([]int, error) {
+	param := c.PathParam(name)
+	if param == "" {
+		return nil, PathParamNotFoundError{ParamName: name}
+	}
+
+	if sep == "" {
+		sep = ","
+	}
+
+	elements := strings.Split(param, sep)
+	ints := make([]int, 0, len(elements))
+	for _, element := range elements {
+		i, err := strconv.Atoi(strings.TrimSpace(element))
+		if err != nil {
+			return nil, PathParamInvalidTypeError{
+				ParamName:    name,
+				ParamValue:   param,
+				ExpectedType: "[]int",
+				Err:          err,
+			}
+		}
+		ints = append(ints, i)
+	}
+
+	return ints, nil
+}
+
 func (c netHttpContext[B, P]) PathParamIntErr(name string) (int, error) {
 	return PathParamIntErr(c, name)
 }
 
+func (c netHttpContext[B, P]) PathParamIntArr(name, sep string) ([]int, error) {
+	return PathParamIntArr(c, name, sep)
+}
+
 // PathParamInt returns the path parameter with the given name as an int.
 // If the query parameter does not exist, or if it is not an int, it returns 0.
 func (c netHttpContext[B, P]) PathParamInt(name string) int {
@@ -328,9 +967,20 @@ func (c netHttpContext[B, P]) MainLang() string {
 }
 
 func (c netHttpContext[B, P]) MainLocale() string {
+	AddVary(c.Res.Header(), "Accept-Language")
 	return strings.Split(c.Req.Header.Get("Accept-Language"), ",")[0]
 }
 
+// QueryString returns the raw query string of the request.
+func (c netHttpContext[B, P]) QueryString() string {
+	return c.Req.URL.RawQuery
+}
+
+// Method returns the HTTP method of the request.
+func (c netHttpContext[B, P]) Method() string {
+	return c.Req.Method
+}
+
 // Request returns the HTTP request.
 func (c netHttpContext[B, P]) Request() *http.Request {
 	return c.Req
@@ -365,6 +1015,16 @@ func (c *netHttpContext[B, P]) Body() (B, error) {
 	return body, err
 }
 
+// VerifyContentMD5 checks the request body against the Content-MD5 header.
+func (c netHttpContext[B, P]) VerifyContentMD5() error {
+	return verifyContentMD5(c.Req, c.readOptions)
+}
+
+// VerifyDigestSHA256 checks the request body against the SHA-256 value of the Digest header.
+func (c netHttpContext[B, P]) VerifyDigestSHA256() error {
+	return verifyDigestSHA256(c.Req, c.readOptions)
+}
+
 func bitSize(kind reflect.Kind) int {
 	switch kind {
 	case reflect.Uint8, reflect.Int8:
@@ -414,63 +1074,21 @@ func setParamValue(value reflect.Value, paramValue string, kind reflect.Kind) er
 	return nil
 }
 
-func (c *netHttpContext[B, P]) Params() (P, error) {
-	p := new(P)
-
-	paramsType := reflect.TypeOf(p).Elem()
-	if paramsType.Kind() != reflect.Struct {
-		return *p, fmt.Errorf("params must be a struct, got %T", *p)
-	}
-	paramsValue := reflect.ValueOf(p).Elem()
-
-	for i := range paramsType.NumField() {
-		field := paramsType.Field(i)
-		fieldValue := paramsValue.Field(i)
-
-		// Process query parameters
-		if tag := field.Tag.Get("query"); tag != "" {
-			// Handle slice/array types
-			switch field.Type.Kind() {
-			case reflect.Slice, reflect.Array:
-				paramValues := c.QueryParamArr(tag)
-				if len(paramValues) == 0 {
-					continue
-				}
-
-				sliceType := field.Type.Elem()
-				slice := reflect.MakeSlice(field.Type, len(paramValues), len(paramValues))
-
-				for j, paramValue := range paramValues {
-					if err := setParamValue(slice.Index(j), paramValue, sliceType.Kind()); err != nil {
-						return *p, err
-					}
-				}
-				fieldValue.Set(slice)
-			default:
-				// Handle single value
-				paramValue := c.QueryParam(tag)
-				if paramValue == "" {
-					continue
-				}
-				err := setParamValue(fieldValue, paramValue, field.Type.Kind())
-				if err != nil {
-					return *p, err
-				}
-			}
-		} else if tag := field.Tag.Get("header"); tag != "" {
-			// Process header parameters
-			paramValue := c.Header(tag)
-			if paramValue == "" {
-				continue
-			}
-			err := setParamValue(fieldValue, paramValue, field.Type.Kind())
-			if err != nil {
-				return *p, err
-			}
+// setSliceParamValue sets the given values to a slice, converting each one based on the kind of the slice elements
+func setSliceParamValue(value reflect.Value, paramValues []string) error {
+	slice := reflect.MakeSlice(value.Type(), len(paramValues), len(paramValues))
+	for i, paramValue := range paramValues {
+		if err := setParamValue(slice.Index(i), paramValue, value.Type().Elem().Kind()); err != nil {
+			return err
 		}
 	}
+	value.Set(slice)
+	return nil
+}
 
-	return *p, nil
+// Params binds the parameters of the request, see [BindParams].
+func (c *netHttpContext[B, P]) Params() (P, error) {
+	return BindParams[P](c)
 }
 
 func (c *netHttpContext[B, P]) MustParams() P {
@@ -483,6 +1101,11 @@ func (c *netHttpContext[B, P]) MustParams() P {
 
 // Serialize serializes the given data to the response. It uses the Content-Type header to determine the serialization format.
 func (c netHttpContext[B, P]) Serialize(data any) error {
+	if c.jsonpParam != "" {
+		if callback := c.Req.URL.Query().Get(c.jsonpParam); callback != "" {
+			return SendJSONP(c.Res, c.Req, callback, data)
+		}
+	}
 	if c.serializer == nil {
 		return Send(c.Res, c.Req, data)
 	}
@@ -511,11 +1134,65 @@ func body[B, P any](c netHttpContext[B, P]) (B, error) {
 		c.Req.Body = http.MaxBytesReader(nil, c.Req.Body, c.readOptions.MaxBodySize)
 	}
 
+	// Limit the time spent reading the request body.
+	if c.readOptions.BodyReadTimeout != 0 {
+		var clearDeadline func()
+		c.Req.Body, clearDeadline = limitBodyReadTime(c.Res, c.Req.Body, c.readOptions.BodyReadTimeout)
+		defer clearDeadline()
+	}
+
+	// Maps non-standard media types to the supported decoders.
+	if c.readOptions.ContentTypeNormalizer != nil {
+		contentType := c.Req.Header.Get("Content-Type")
+		if normalized := c.readOptions.ContentTypeNormalizer(contentType); normalized != contentType {
+			c.Req.Header.Set("Content-Type", normalized)
+		}
+	}
+
+	var counter *countingReader
+	if c.readOptions.ReportBodySize {
+		counter = &countingReader{ReadCloser: c.Req.Body}
+		c.Req.Body = counter
+	}
+
 	timeDeserialize := time.Now()
 
 	var body B
 	var err error
-	switch c.Req.Header.Get("Content-Type") {
+	if decoder, ok := requestDecoder(c.readOptions.Decoders, c.Req); ok {
+		body, err = readWithDecoder[B](c.Req, decoder)
+	} else {
+		body, err = readBuiltin[B, P](c)
+	}
+
+	c.Res.Header().Add("Server-Timing", Timing{"deserialize", "controller > deserialize", time.Since(timeDeserialize)}.String())
+	if counter != nil {
+		c.Res.Header().Set("X-Body-Bytes", strconv.FormatInt(counter.n, 10))
+	}
+
+	// Readers wrap read errors in a 400, surface the timeout instead.
+	var timeoutErr RequestTimeoutError
+	if errors.As(err, &timeoutErr) {
+		err = timeoutErr
+	}
+
+	return body, err
+}
+
+// readBuiltin reads the request body with the built-in decoder matching the Content-Type.
+func readBuiltin[B, P any](c netHttpContext[B, P]) (B, error) {
+	contentType := c.Req.Header.Get("Content-Type")
+	if hasTextBody(contentType) {
+		c.Req.Body = newBOMReader(c.Req.Body)
+	}
+	// The multipart/form-data Content-Type always has a boundary parameter.
+	if mediaType, _, _ := mime.ParseMediaType(contentType); mediaType == "multipart/form-data" {
+		contentType = mediaType
+	}
+
+	var body B
+	var err error
+	switch contentType {
 	case "text/plain":
 		s, errReadingString := readString[string](c.Req.Context(), c.Req.Body, c.readOptions)
 		body = any(s).(B)
@@ -526,6 +1203,10 @@ func body[B, P any](c netHttpContext[B, P]) (B, error) {
 		body, err = readXML[B](c.Req.Context(), c.Req.Body, c.readOptions)
 	case "application/x-yaml", "text/yaml; charset=utf-8", "application/yaml": // https://www.rfc-editor.org/rfc/rfc9512.html
 		body, err = readYAML[B](c.Req.Context(), c.Req.Body, c.readOptions)
+	case "application/x-protobuf", "application/protobuf":
+		body, err = readProto[B](c.Req.Context(), c.Req.Body, c.readOptions)
+	case "application/grpc-web", "application/grpc-web+proto":
+		body, err = readGRPCWeb[B](c.Req.Context(), c.Req.Body, c.readOptions)
 	case "application/octet-stream":
 		// Read c.Req Body to bytes
 		bytes, err := io.ReadAll(c.Req.Body)
@@ -538,10 +1219,12 @@ func body[B, P any](c netHttpContext[B, P]) (B, error) {
 		}
 		body = respBytes
 	default:
-		body, err = readJSON[B](c.Req.Context(), c.Req.Body, c.readOptions)
+		if c.readOptions.JSONSchema != nil {
+			body, err = readJSONWithSchema[B](c.Req, c.readOptions)
+		} else {
+			body, err = readJSON[B](c.Req.Context(), c.Req.Body, c.readOptions)
+		}
 	}
 
-	c.Res.Header().Add("Server-Timing", Timing{"deserialize", "controller > deserialize", time.Since(timeDeserialize)}.String())
-
 	return body, err
 }
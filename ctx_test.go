@@ -8,8 +8,10 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -105,6 +107,114 @@ func TestContext_PathParam(t *testing.T) {
 	})
 }
 
+func TestContext_PathParamRaw(t *testing.T) {
+	s := NewServer()
+	Get(s, "/files/{name}", func(c ContextNoBody) ([]string, error) {
+		return []string{c.PathParam("name"), c.PathParamRaw("name")}, nil
+	})
+	Get(s, "/users/{user}/files/{name}", func(c ContextNoBody) ([]string, error) {
+		return []string{c.PathParam("user"), c.PathParamRaw("user"), c.PathParam("name"), c.PathParamRaw("name")}, nil
+	})
+	Get(s, "/static/{path...}", func(c ContextNoBody) ([]string, error) {
+		return []string{c.PathParam("path"), c.PathParamRaw("path"), c.PathParamRaw("missing")}, nil
+	})
+
+	tests := []struct {
+		name     string
+		target   string
+		expected string
+	}{
+		{"encoded slash and space", "/files/a%2Fb%20c", `["a/b c","a%2Fb%20c"]`},
+		{"encoded percent", "/files/100%25", `["100%","100%25"]`},
+		{"no encoded characters", "/files/report.pdf", `["report.pdf","report.pdf"]`},
+		{"several params", "/users/jean%20luc/files/a%2Fb", `["jean luc","jean%20luc","a/b","a%2Fb"]`},
+		{"rest wildcard", "/static/my%20dir/a%2Fb.css", `["my dir/a/b.css","my%20dir/a%2Fb.css",""]`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			s.Mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.target, nil))
+
+			require.Equal(t, http.StatusOK, w.Code)
+			require.JSONEq(t, tc.expected, w.Body.String())
+		})
+	}
+
+	t.Run("without pattern, escapes the decoded value", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/files/a%2Fb", nil)
+		r.SetPathValue("name", "a/b c")
+
+		require.Equal(t, "a%2Fb%20c", PathParamRaw(r, "name"))
+	})
+
+	t.Run("mock context", func(t *testing.T) {
+		c := NewMockContextNoBody()
+		c.PathParams["name"] = "a/b c"
+
+		require.Equal(t, "a/b c", c.PathParam("name"))
+		require.Equal(t, "a%2Fb%20c", c.PathParamRaw("name"))
+	})
+}
+
+func TestContext_PathParamIntArr(t *testing.T) {
+	s := NewServer()
+	Get(s, "/items/{ids}", func(c ContextNoBody) ([]int, error) {
+		return c.PathParamIntArr("ids", ",")
+	})
+	Get(s, "/pairs/{ids}", func(c ContextNoBody) ([]int, error) {
+		return c.PathParamIntArr("ids", "-")
+	})
+
+	t.Run("parses a comma-separated list", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest("GET", "/items/1,2,3", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, crlf(`[1,2,3]`), w.Body.String())
+	})
+
+	t.Run("single element", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest("GET", "/items/42", nil))
+
+		require.Equal(t, crlf(`[42]`), w.Body.String())
+	})
+
+	t.Run("custom separator", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest("GET", "/pairs/4-5", nil))
+
+		require.Equal(t, crlf(`[4,5]`), w.Body.String())
+	})
+
+	t.Run("malformed list sends an error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest("GET", "/items/1,two,3", nil))
+
+		require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		require.JSONEq(t, `{"title":"Unprocessable Entity","status":422,"detail":"path param ids=1,two,3 is not of type []int"}`, w.Body.String())
+	})
+
+	t.Run("empty element sends an error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest("GET", "/items/1,,3", nil))
+
+		require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+
+	t.Run("with the mock context", func(t *testing.T) {
+		c := NewMockContextNoBody()
+		c.PathParams["ids"] = "7,8"
+
+		ids, err := c.PathParamIntArr("ids", "")
+		require.NoError(t, err)
+		require.Equal(t, []int{7, 8}, ids)
+
+		_, err = c.PathParamIntArr("missing", ",")
+		require.ErrorAs(t, err, &PathParamNotFoundError{})
+	})
+}
+
 func TestContext_QueryParam(t *testing.T) {
 	r := httptest.NewRequest("GET", "http://example.com/foo/123?id=456&other=hello&boo=true&name=jhon&name=doe", nil)
 	w := httptest.NewRecorder()
@@ -191,6 +301,22 @@ func TestContext_QueryParam(t *testing.T) {
 		assert.Equal(t, "query param other=hello is not of type bool", invalidErr.DetailMsg())
 	})
 
+	t.Run("lenient bool", func(t *testing.T) {
+		for value, expected := range map[string]bool{
+			"1": true, "true": true, "TRUE": true, "t": true, "yes": true, "Yes": true, "y": true, "on": true, "ON": true,
+			"0": false, "false": false, "False": false, "f": false, "no": false, "NO": false, "n": false, "off": false, "Off": false,
+		} {
+			r := httptest.NewRequest("GET", "/?active="+value, nil)
+			c := NewNetHTTPContext[any, any](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+			require.Equal(t, expected, c.QueryParamBoolLenient("active", !expected), value)
+		}
+
+		require.True(t, c.QueryParamBoolLenient("notfound", true))
+		require.False(t, c.QueryParamBoolLenient("notfound", false))
+		require.True(t, c.QueryParamBoolLenient("other", true), "invalid value falls back to the default")
+		require.False(t, c.QueryParamBoolLenient("other", false))
+	})
+
 	t.Run("slice", func(t *testing.T) {
 		name := c.QueryParamArr("name")
 		require.NotEmpty(t, name)
@@ -214,6 +340,99 @@ func TestContext_QueryParams(t *testing.T) {
 	require.Empty(t, params["notfound"])
 }
 
+func TestContext_QueryString(t *testing.T) {
+	t.Run("returns the raw query string", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "http://example.com/foo?b=2&a=1&a=0", nil)
+		c := NewNetHTTPContext[any, any](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+
+		require.Equal(t, "b=2&a=1&a=0", c.QueryString())
+	})
+
+	t.Run("returns an empty string without query", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "http://example.com/foo", nil)
+		c := NewNetHTTPContext[any, any](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+
+		require.Empty(t, c.QueryString())
+		require.Empty(t, c.QueryParamsSorted())
+	})
+}
+
+func TestContext_QueryParamsSorted(t *testing.T) {
+	t.Run("sorts keys and values", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "http://example.com/foo?b=2&a=z&a=y", nil)
+		c := NewNetHTTPContext[any, any](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+
+		require.Equal(t, "a=y&a=z&b=2", c.QueryParamsSorted())
+	})
+
+	t.Run("is stable regardless of input order", func(t *testing.T) {
+		queries := []string{
+			"name=John%20Doe&tag=b&tag=a&page=1",
+			"page=1&tag=a&name=John+Doe&tag=b",
+			"tag=b&page=1&tag=a&name=John%20Doe",
+		}
+
+		var keys []string
+		for _, query := range queries {
+			r := httptest.NewRequest("GET", "http://example.com/foo?"+query, nil)
+			c := NewNetHTTPContext[any, any](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+			keys = append(keys, c.QueryParamsSorted())
+		}
+
+		require.Equal(t, "name=John+Doe&page=1&tag=a&tag=b", keys[0])
+		require.Equal(t, keys[0], keys[1])
+		require.Equal(t, keys[0], keys[2])
+	})
+
+	t.Run("does not modify the query params", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "http://example.com/foo?a=z&a=y", nil)
+		c := NewNetHTTPContext[any, any](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+
+		_ = c.QueryParamsSorted()
+		require.Equal(t, []string{"z", "y"}, c.QueryParams()["a"])
+	})
+}
+
+func TestContext_ParamsSpec(t *testing.T) {
+	s := NewServer()
+
+	var spec []OpenAPIParam
+	Get(s, "/recipes/{id}", func(c ContextNoBody) (any, error) {
+		spec = c.ParamsSpec()
+		return nil, nil
+	},
+		OptionPath("id", "Recipe ID"),
+		OptionQueryInt("page", "Page", ParamDefault(1)),
+		OptionQuery("name", "Name", ParamRequired()),
+		OptionHeader("X-Request-ID", "Request ID"),
+	)
+
+	r := httptest.NewRequest(http.MethodGet, "/recipes/1?name=pizza", nil)
+	s.Mux.ServeHTTP(httptest.NewRecorder(), r)
+
+	names := make([]string, 0, len(spec))
+	for _, param := range spec {
+		names = append(names, param.Name)
+	}
+	// The Accept header is declared by default
+	require.Equal(t, []string{"Accept", "X-Request-ID", "id", "name", "page"}, names)
+
+	require.Equal(t, HeaderParamType, spec[1].Type)
+	require.Equal(t, PathParamType, spec[2].Type)
+	require.True(t, spec[2].Required)
+	require.Equal(t, QueryParamType, spec[3].Type)
+	require.True(t, spec[3].Required)
+	require.Equal(t, "integer", spec[4].GoType)
+	require.Equal(t, 1, spec[4].Default)
+
+	t.Run("is empty without declared params", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		c := NewNetHTTPContext[any, any](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+
+		require.Empty(t, c.ParamsSpec())
+	})
+}
+
 type testStruct struct {
 	XMLName xml.Name `xml:"TestStruct"`
 	Name    string   `json:"name" xml:"Name" yaml:"name"`
@@ -670,6 +889,67 @@ func TestNetHttpContext_Params(t *testing.T) {
 		assert.InEpsilon(t, 20.30, params.Temperature, 0.01)
 	})
 
+	t.Run("reads the first alias present", func(t *testing.T) {
+		type MyParams struct {
+			PageSize int      `query:"page_size,perPage,limit"`
+			Tags     []string `query:"tags,tag, labels"`
+		}
+
+		r := httptest.NewRequest("GET", "http://example.com/foo?limit=30&perPage=20&labels=a&labels=b", nil)
+		c := NewNetHTTPContext[any, MyParams](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+
+		params, err := c.Params()
+		require.NoError(t, err)
+		require.Equal(t, 20, params.PageSize)
+		require.Equal(t, []string{"a", "b"}, params.Tags)
+
+		r = httptest.NewRequest("GET", "http://example.com/foo?limit=30&tag=c", nil)
+		c = NewNetHTTPContext[any, MyParams](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+
+		params, err = c.Params()
+		require.NoError(t, err)
+		require.Equal(t, 30, params.PageSize)
+		require.Equal(t, []string{"c"}, params.Tags)
+
+		r = httptest.NewRequest("GET", "http://example.com/foo?page_size=10&limit=30", nil)
+		c = NewNetHTTPContext[any, MyParams](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+
+		params, err = c.Params()
+		require.NoError(t, err)
+		require.Equal(t, 10, params.PageSize)
+	})
+
+	t.Run("collects bracketed and unbound params into maps", func(t *testing.T) {
+		type MyParams struct {
+			Page    int               `query:"page"`
+			Filters map[string]string `query:"filter,f"`
+			Sort    map[string]string `query:"sort"`
+			Others  map[string]string `query:"*"`
+		}
+
+		r := httptest.NewRequest("GET", "http://example.com/foo?page=2&filter[status]=open&f[author]=ewen&filter[]=empty&filter=noBracket&q=pizza&debug=", nil)
+		c := NewNetHTTPContext[any, MyParams](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+
+		params, err := c.Params()
+		require.NoError(t, err)
+		require.Equal(t, 2, params.Page)
+		require.Equal(t, map[string]string{"status": "open", "author": "ewen", "": "empty"}, params.Filters)
+		require.Nil(t, params.Sort)
+		require.Equal(t, map[string]string{"filter": "noBracket", "q": "pizza", "debug": ""}, params.Others)
+	})
+
+	t.Run("does not support maps of other types", func(t *testing.T) {
+		type MyParams struct {
+			Filters map[string]int `query:"filter"`
+		}
+
+		r := httptest.NewRequest("GET", "http://example.com/foo?filter[page]=1", nil)
+		c := NewNetHTTPContext[any, MyParams](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+
+		_, err := c.Params()
+		require.Error(t, err)
+	})
+
 	t.Run("does not support other receivers than struct", func(t *testing.T) {
 		t.Run("pointer to struct", func(t *testing.T) {
 			type MyParams struct{}
@@ -954,3 +1234,199 @@ func TestNetHttpContext_Params(t *testing.T) {
 		})
 	})
 }
+
+func TestContext_SendRaw(t *testing.T) {
+	payload := []byte(`{"ans":"cached"}`)
+
+	t.Run("writes the exact bytes", func(t *testing.T) {
+		s := NewServer()
+		Get(s, "/cached", func(c ContextNoBody) (any, error) {
+			return c.SendRaw("application/json", payload)
+		})
+
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest("GET", "/cached", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		require.Equal(t, "16", w.Header().Get("Content-Length"))
+		require.Equal(t, payload, w.Body.Bytes())
+	})
+
+	t.Run("uses the default status code of the route", func(t *testing.T) {
+		s := NewServer()
+		Post(s, "/cached", func(c ContextNoBody) (any, error) {
+			return c.SendRaw("application/x-protobuf", []byte{0x0a, 0x02})
+		}, OptionDefaultStatusCode(http.StatusCreated))
+
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest("POST", "/cached", nil))
+
+		require.Equal(t, http.StatusCreated, w.Code)
+		require.Equal(t, "application/x-protobuf", w.Header().Get("Content-Type"))
+		require.Equal(t, []byte{0x0a, 0x02}, w.Body.Bytes())
+	})
+
+	t.Run("keeps the status set by the controller", func(t *testing.T) {
+		s := NewServer()
+		Get(s, "/cached", func(c ContextNoBody) (any, error) {
+			c.SetStatus(http.StatusAccepted)
+			return c.SendRaw("text/plain", []byte("ok"))
+		})
+
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest("GET", "/cached", nil))
+
+		require.Equal(t, http.StatusAccepted, w.Code)
+		require.Equal(t, "ok", w.Body.String())
+	})
+}
+
+func TestContext_ResetHeaders(t *testing.T) {
+	t.Run("clears the headers before the first write", func(t *testing.T) {
+		s := NewServer()
+		Get(s, "/", func(c ContextNoBody) (string, error) {
+			c.SetHeader("X-Partial", "true")
+			c.SetHeader("Cache-Control", "max-age=3600")
+			if err := c.ResetHeaders(); err != nil {
+				return "", err
+			}
+			return "", BadRequestError{}
+		})
+
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Empty(t, w.Header().Get("X-Partial"))
+		require.Empty(t, w.Header().Get("Cache-Control"))
+	})
+
+	t.Run("errors after the first write", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c := NewNetHTTPContext[any, any](BaseRoute{}, w, httptest.NewRequest("GET", "/", nil), readOptions{})
+		c.SetHeader("X-Partial", "true")
+		_, err := c.Response().Write([]byte("partial"))
+		require.NoError(t, err)
+
+		require.ErrorIs(t, c.ResetHeaders(), ErrHeadersAlreadySent)
+		require.Equal(t, "true", w.Header().Get("X-Partial"))
+	})
+
+	t.Run("errors after the status is sent", func(t *testing.T) {
+		c := NewNetHTTPContext[any, any](BaseRoute{}, httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil), readOptions{})
+		c.SetStatus(http.StatusAccepted)
+
+		require.ErrorIs(t, c.ResetHeaders(), ErrHeadersAlreadySent)
+	})
+}
+
+type conformanceContextKey struct{}
+
+func TestContext_ContextConformance(t *testing.T) {
+	t.Run("Done closes on request cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		r := httptest.NewRequest("GET", "/", nil).WithContext(ctx)
+		c := NewNetHTTPContext[any, any](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+
+		require.NoError(t, c.Err())
+		select {
+		case <-c.Done():
+			t.Fatal("Done() must not be closed before cancellation")
+		default:
+		}
+
+		cancel()
+		select {
+		case <-c.Done():
+		case <-time.After(time.Second):
+			t.Fatal("Done() must close when the request is canceled")
+		}
+		require.ErrorIs(t, c.Err(), context.Canceled)
+	})
+
+	t.Run("cancellation propagates to the controller", func(t *testing.T) {
+		s := NewServer()
+		var err error
+		Get(s, "/slow", func(c ContextNoBody) (any, error) {
+			select {
+			case <-c.Done():
+				err = c.Err()
+			case <-time.After(time.Second):
+			}
+			return nil, nil
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		s.Mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/slow", nil).WithContext(ctx))
+
+		require.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("Deadline comes from the request context", func(t *testing.T) {
+		deadline := time.Now().Add(time.Minute)
+		ctx, cancel := context.WithDeadline(context.Background(), deadline)
+		defer cancel()
+		c := NewNetHTTPContext[any, any](BaseRoute{}, httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil).WithContext(ctx), readOptions{})
+
+		got, ok := c.Deadline()
+		require.True(t, ok)
+		require.Equal(t, deadline, got)
+	})
+
+	t.Run("Value resolves request context values", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), conformanceContextKey{}, "value")
+		c := NewNetHTTPContext[any, any](BaseRoute{}, httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil).WithContext(ctx), readOptions{})
+
+		require.Equal(t, "value", c.Value(conformanceContextKey{}))
+		require.Equal(t, "value", c.Context().Value(conformanceContextKey{}))
+		require.Nil(t, c.Value("missing"))
+	})
+
+	t.Run("can be given to functions expecting a context.Context", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(NewMockContextNoBody(), time.Millisecond)
+		defer cancel()
+
+		<-ctx.Done()
+		require.ErrorIs(t, ctx.Err(), context.DeadlineExceeded)
+	})
+}
+
+func TestTrimParamWhitespace(t *testing.T) {
+	type params struct {
+		Tags []string `query:"tags"`
+		Name string   `query:"name"`
+		Page int      `query:"page"`
+	}
+
+	handler := func(c ContextWithParams[params]) ([]string, error) {
+		p, err := c.Params()
+		if err != nil {
+			return nil, err
+		}
+		return append([]string{c.PathParam("id"), c.QueryParam("name"), p.Name, strconv.Itoa(p.Page)}, p.Tags...), nil
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		s := NewServer()
+		Get(s, "/recipes/{id}", handler)
+
+		r := httptest.NewRequest(http.MethodGet, "/recipes/%2042%20?name=%20foo%20&tags=%20a&tags=b%20", nil)
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.JSONEq(t, `[" 42 ", " foo ", " foo ", "0", " a", "b "]`, w.Body.String())
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		s := NewServer(WithTrimParamWhitespace())
+		Get(s, "/recipes/{id}", handler)
+
+		r := httptest.NewRequest(http.MethodGet, "/recipes/%2042%20?name=%20foo%20&page=%092&tags=%20a&tags=b%20", nil)
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.JSONEq(t, `["42", "foo", "foo", "2", "a", "b"]`, w.Body.String())
+	})
+}
@@ -0,0 +1,67 @@
+package fuego
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// Cursor is the position of a page in a cursor-based pagination, see [Context.Cursor] and [NextCursor].
+// Unlike offset pagination, pages stay consistent when items are inserted or deleted between requests.
+type Cursor struct {
+	// After is the key of the last item of the previous page: the page starts after it.
+	After string `json:"after,omitempty"`
+	// Before is the key of the first item of the next page, to paginate backwards.
+	Before string `json:"before,omitempty"`
+	// Limit is the number of items of the page. 0 means the default of the route.
+	Limit int `json:"limit,omitempty"`
+}
+
+// NextCursor encodes the cursor as an opaque token (URL-safe base64 JSON),
+// to be sent to the client and given back with the "cursor" query parameter, see [ParseCursor].
+//
+//	next := fuego.NextCursor(fuego.Cursor{After: lastPet.ID, Limit: 20})
+//	// "eyJhZnRlciI6InBldC00MiIsImxpbWl0IjoyMH0"
+func NextCursor(c Cursor) string {
+	data, _ := json.Marshal(c) // Cannot fail: Cursor only has strings and ints.
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// ParseCursor decodes a token encoded with [NextCursor]. An empty token gives the zero [Cursor], for the first page.
+// It returns a [BadRequestError] if the token is malformed.
+func ParseCursor(token string) (Cursor, error) {
+	var cursor Cursor
+	if token == "" {
+		return cursor, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(token, "="))
+	if err != nil {
+		return Cursor{}, invalidCursorError(err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&cursor); err != nil {
+		return Cursor{}, invalidCursorError(err)
+	}
+	if cursor.Limit < 0 {
+		return Cursor{}, invalidCursorError(errors.New("negative limit"))
+	}
+	return cursor, nil
+}
+
+func invalidCursorError(err error) error {
+	return BadRequestError{
+		Title:  "Invalid Cursor",
+		Err:    err,
+		Detail: "query param cursor is not a valid pagination token",
+	}
+}
+
+// Cursor decodes the "cursor" query parameter of the request, see [ParseCursor].
+func (c netHttpContext[B, P]) Cursor() (Cursor, error) {
+	return ParseCursor(c.QueryParam("cursor"))
+}
@@ -0,0 +1,80 @@
+package fuego
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		for _, cursor := range []Cursor{
+			{},
+			{After: "pet-42", Limit: 20},
+			{Before: "pet-7"},
+			{After: "+/= é", Before: "?&", Limit: 1},
+		} {
+			token := NextCursor(cursor)
+			require.NotContains(t, token, "=")
+
+			parsed, err := ParseCursor(token)
+			require.NoError(t, err)
+			require.Equal(t, cursor, parsed)
+		}
+	})
+
+	t.Run("empty token is the first page", func(t *testing.T) {
+		cursor, err := ParseCursor("")
+		require.NoError(t, err)
+		require.Zero(t, cursor)
+	})
+
+	t.Run("padded token is accepted", func(t *testing.T) {
+		token := base64.URLEncoding.EncodeToString([]byte(`{"after":"a"}`))
+		cursor, err := ParseCursor(token)
+		require.NoError(t, err)
+		require.Equal(t, "a", cursor.After)
+	})
+
+	t.Run("malformed tokens", func(t *testing.T) {
+		for _, token := range []string{
+			"not base64!",
+			base64.RawURLEncoding.EncodeToString([]byte("not json")),
+			base64.RawURLEncoding.EncodeToString([]byte(`{"offset":10}`)),
+			base64.RawURLEncoding.EncodeToString([]byte(`{"limit":-1}`)),
+			base64.RawURLEncoding.EncodeToString([]byte(`{"limit":"ten"}`)),
+		} {
+			_, err := ParseCursor(token)
+			var badRequest BadRequestError
+			require.ErrorAs(t, err, &badRequest, token)
+			require.Equal(t, "Invalid Cursor", badRequest.Title)
+		}
+	})
+
+	t.Run("from the query", func(t *testing.T) {
+		s := NewServer()
+		Get(s, "/pets", func(c ContextNoBody) (Cursor, error) {
+			return c.Cursor()
+		})
+
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/pets?cursor="+NextCursor(Cursor{After: "pet-42", Limit: 5}), nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		require.JSONEq(t, `{"after":"pet-42","limit":5}`, w.Body.String())
+
+		w = httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/pets?cursor=garbage!", nil))
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("mock context", func(t *testing.T) {
+		c := NewMockContextNoBody()
+		c.UrlValues.Set("cursor", NextCursor(Cursor{Before: "b"}))
+		cursor, err := c.Cursor()
+		require.NoError(t, err)
+		require.Equal(t, "b", cursor.Before)
+	})
+}
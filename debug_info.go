@@ -0,0 +1,70 @@
+package fuego
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// DefaultDebugRedactedHeaders are the request headers hidden by [RequestDebugInfo] unless configured otherwise,
+// see [WithDebugRedactedHeaders].
+var DefaultDebugRedactedHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"X-Api-Key",
+	"X-Auth-Token",
+	"X-Csrf-Token",
+}
+
+const redactedValue = "[REDACTED]"
+
+// WithDebugRedactedHeaders replaces the list of request headers hidden by [Context.DebugInfo],
+// [DefaultDebugRedactedHeaders] by default. Header names are case-insensitive.
+//
+//	s := fuego.NewServer(
+//		fuego.WithDebugRedactedHeaders(append(fuego.DefaultDebugRedactedHeaders, "X-Tenant-Secret")...),
+//	)
+func WithDebugRedactedHeaders(headers ...string) func(*Server) {
+	return func(s *Server) { s.debugRedactedHeaders = headers }
+}
+
+// RequestDebugInfo returns the metadata of the request, for debugging endpoints:
+// method, path, route pattern, query parameters, headers, remote IP and negotiated content types.
+// The values of the redacted headers are replaced by "[REDACTED]".
+func RequestDebugInfo(r *http.Request, route string, redactedHeaders []string) map[string]any {
+	headers := make(map[string][]string, len(r.Header))
+	for name, values := range r.Header {
+		if slices.ContainsFunc(redactedHeaders, func(redacted string) bool { return strings.EqualFold(redacted, name) }) {
+			values = []string{redactedValue}
+		}
+		headers[name] = values
+	}
+
+	accept := []string{}
+	for _, offer := range ParseAccept(r.Header) {
+		if offer.Quality > 0 {
+			accept = append(accept, offer.MediaType())
+		}
+	}
+
+	return map[string]any{
+		"method":    r.Method,
+		"path":      r.URL.Path,
+		"route":     route,
+		"query":     map[string][]string(r.URL.Query()),
+		"headers":   headers,
+		"remote_ip": RemoteIP(r),
+		"accept":    accept,
+		"charset":   NegotiateCharset(r.Header),
+	}
+}
+
+// DebugInfo returns the metadata of the request, see [RequestDebugInfo].
+func (c netHttpContext[B, P]) DebugInfo() map[string]any {
+	redacted := c.debugRedactedHeaders
+	if redacted == nil {
+		redacted = DefaultDebugRedactedHeaders
+	}
+	return RequestDebugInfo(c.Req, c.Req.Pattern, redacted)
+}
@@ -0,0 +1,64 @@
+package fuego
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebugInfo(t *testing.T) {
+	debugEcho := func(c ContextNoBody) (map[string]any, error) {
+		return c.DebugInfo(), nil
+	}
+
+	t.Run("request metadata with redacted headers", func(t *testing.T) {
+		s := NewServer()
+		Get(s, "/debug/echo/{id}", debugEcho)
+
+		r := httptest.NewRequest(http.MethodGet, "/debug/echo/42?page=2&tag=a&tag=b", nil)
+		r.RemoteAddr = "203.0.113.7:1234"
+		r.Header.Set("Authorization", "Bearer secret")
+		r.Header.Set("Cookie", "session=secret")
+		r.Header.Set("X-Tenant", "acme")
+		r.Header.Set("Accept", "application/json, application/xml;q=0.5, text/csv;q=0")
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var info map[string]any
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &info))
+		require.Equal(t, "GET", info["method"])
+		require.Equal(t, "/debug/echo/42", info["path"])
+		require.Equal(t, "GET /debug/echo/{id}", info["route"])
+		require.Equal(t, map[string]any{"page": []any{"2"}, "tag": []any{"a", "b"}}, info["query"])
+		require.Equal(t, "203.0.113.7", info["remote_ip"])
+		require.Equal(t, []any{"application/json", "application/xml"}, info["accept"])
+		require.Equal(t, "utf-8", info["charset"])
+
+		headers := info["headers"].(map[string]any)
+		require.Equal(t, []any{"[REDACTED]"}, headers["Authorization"])
+		require.Equal(t, []any{"[REDACTED]"}, headers["Cookie"])
+		require.Equal(t, []any{"acme"}, headers["X-Tenant"])
+		require.NotContains(t, w.Body.String(), "secret")
+	})
+
+	t.Run("custom redaction list", func(t *testing.T) {
+		s := NewServer(WithDebugRedactedHeaders("x-tenant"))
+		Get(s, "/debug/echo", debugEcho)
+
+		r := httptest.NewRequest(http.MethodGet, "/debug/echo", nil)
+		r.Header.Set("Authorization", "Bearer token")
+		r.Header.Set("X-Tenant", "acme")
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		var info map[string]any
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &info))
+		headers := info["headers"].(map[string]any)
+		require.Equal(t, []any{"[REDACTED]"}, headers["X-Tenant"])
+		require.Equal(t, []any{"Bearer token"}, headers["Authorization"])
+	})
+}
@@ -0,0 +1,33 @@
+package fuego
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// SetDeprecationHeaders marks the response as coming from a deprecated endpoint, removed at the given date:
+//   - Deprecation: the date as a structured field date (RFC 9745), e.g. "@1735689600"
+//   - Sunset: the date as an HTTP-date (RFC 8594), e.g. "Wed, 01 Jan 2025 00:00:00 GMT"
+//   - Link: the link to the deprecation notice, with the "deprecation" relation type, if not empty
+//   - Warning: a 299 "Miscellaneous Persistent Warning" (RFC 7234), for clients that only log warnings
+//
+// If the date is zero, the Deprecation and Sunset headers are not set.
+func SetDeprecationHeaders(header http.Header, date time.Time, link string) {
+	text := "Deprecated API"
+	if !date.IsZero() {
+		header.Set("Deprecation", "@"+strconv.FormatInt(date.Unix(), 10))
+		header.Set("Sunset", date.UTC().Format(http.TimeFormat))
+		text += ", sunset on " + date.UTC().Format(http.TimeFormat)
+	}
+	if link != "" {
+		header.Add("Link", "<"+link+`>; rel="deprecation"`)
+		text += ", see " + link
+	}
+	header.Add("Warning", "299 - "+strconv.Quote(text))
+}
+
+// Deprecate marks the endpoint as deprecated, see [SetDeprecationHeaders].
+func (c netHttpContext[B, P]) Deprecate(date time.Time, link string) {
+	SetDeprecationHeaders(c.Res.Header(), date, link)
+}
@@ -0,0 +1,59 @@
+package fuego
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetDeprecationHeaders(t *testing.T) {
+	sunset := time.Date(2025, 1, 1, 0, 0, 0, 0, time.FixedZone("CET", 3600))
+
+	t.Run("date and link", func(t *testing.T) {
+		header := http.Header{}
+		SetDeprecationHeaders(header, sunset, "https://example.com/changelog#v1")
+
+		require.Equal(t, "@1735686000", header.Get("Deprecation"))
+		require.Equal(t, "Tue, 31 Dec 2024 23:00:00 GMT", header.Get("Sunset"))
+		require.Equal(t, `<https://example.com/changelog#v1>; rel="deprecation"`, header.Get("Link"))
+		require.Equal(t, `299 - "Deprecated API, sunset on Tue, 31 Dec 2024 23:00:00 GMT, see https://example.com/changelog#v1"`, header.Get("Warning"))
+	})
+
+	t.Run("without date nor link", func(t *testing.T) {
+		header := http.Header{}
+		SetDeprecationHeaders(header, time.Time{}, "")
+
+		require.Empty(t, header.Get("Deprecation"))
+		require.Empty(t, header.Get("Sunset"))
+		require.Empty(t, header.Get("Link"))
+		require.Equal(t, `299 - "Deprecated API"`, header.Get("Warning"))
+	})
+
+	t.Run("keeps existing links", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("Link", `</pets?page=2>; rel="next"`)
+		SetDeprecationHeaders(header, sunset, "https://example.com/changelog")
+
+		require.Equal(t, []string{`</pets?page=2>; rel="next"`, `<https://example.com/changelog>; rel="deprecation"`}, header.Values("Link"))
+	})
+}
+
+func TestContextDeprecate(t *testing.T) {
+	s := NewServer()
+	Get(s, "/v1/recipes", func(c ContextNoBody) (string, error) {
+		c.Deprecate(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), "https://example.com/v2")
+		return "recipes", nil
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/v1/recipes", nil)
+	w := httptest.NewRecorder()
+	s.Mux.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "recipes", w.Body.String())
+	require.Equal(t, "@1748736000", w.Header().Get("Deprecation"))
+	require.Equal(t, "Sun, 01 Jun 2025 00:00:00 GMT", w.Header().Get("Sunset"))
+}
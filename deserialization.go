@@ -1,6 +1,7 @@
 package fuego
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"encoding/json"
@@ -10,7 +11,11 @@ import (
 	"io"
 	"log/slog"
 	"net/http"
+	"net/url"
+	"os"
 	"reflect"
+	"strings"
+	"time"
 
 	"github.com/gorilla/schema"
 	"gopkg.in/yaml.v3"
@@ -23,6 +28,17 @@ type InTransformer interface {
 	InTransform(context.Context) error // InTransforms the entity.
 }
 
+// InTransformerChain is an interface for entities transformed by several ordered steps,
+// like normalizing then enriching. The steps are run after [InTransformer], if implemented,
+// each one receiving the entity modified by the previous one. The first error stops the chain.
+//
+//	func (r *CreateRecipe) InTransformers() []func(context.Context) error {
+//		return []func(context.Context) error{r.normalize, r.enrich}
+//	}
+type InTransformerChain interface {
+	InTransformers() []func(context.Context) error
+}
+
 var ReadOptions = readOptions{
 	DisallowUnknownFields: true,
 	MaxBodySize:           maxBodySize,
@@ -40,13 +56,67 @@ func ReadJSON[B any](ctx context.Context, input io.Reader) (B, error) {
 // or as a method of Context.
 // It will also read strings.
 func readJSON[B any](ctx context.Context, input io.Reader, options readOptions) (B, error) {
+	// Rejects deeply nested documents before decoding them.
+	if options.MaxJSONDepth > 0 {
+		input = newJSONDepthReader(input, options.MaxJSONDepth)
+	}
+	if options.ValidateUTF8 {
+		input = newUTF8Reader(input)
+	}
+	if options.BodyUnwrapKey != "" {
+		inner, err := unwrapJSONBody(input, options.BodyUnwrapKey)
+		if err != nil {
+			return *new(B), err
+		}
+		input = bytes.NewReader(inner)
+	}
+
+	// Protobuf messages follow the proto3 JSON mapping.
+	if options.ProtoJSONCodec != nil && isProtoMessageType[B]() {
+		body, err := readProtoJSON[B](ctx, input, options)
+		if errors.Is(err, errInvalidUTF8) {
+			return body, invalidUTF8Error(err)
+		}
+		if errors.Is(err, errJSONTooDeep) {
+			return body, BadRequestError{
+				Title:  "JSON Too Deep",
+				Err:    err,
+				Detail: "cannot decode request body: " + err.Error(),
+			}
+		}
+		return body, err
+	}
+
 	// Deserialize the request body.
 	dec := json.NewDecoder(input)
 	if options.DisallowUnknownFields {
 		dec.DisallowUnknownFields()
 	}
 
-	return read[B](ctx, dec)
+	body, err := decode[B](ctx, dec)
+	if errors.Is(err, errInvalidUTF8) {
+		return body, invalidUTF8Error(err)
+	}
+	if errors.Is(err, errJSONTooDeep) {
+		return body, BadRequestError{
+			Title:  "JSON Too Deep",
+			Err:    err,
+			Detail: "cannot decode request body: " + err.Error(),
+		}
+	}
+	if err != nil {
+		return body, err
+	}
+
+	// Encrypted fields are decrypted before being transformed and validated.
+	if options.FieldKeys != nil {
+		body, err = decryptBody(ctx, body, options.FieldKeys)
+		if err != nil {
+			return body, err
+		}
+	}
+
+	return TransformAndValidate(ctx, body)
 }
 
 // ReadXML reads the request body as XML.
@@ -92,6 +162,15 @@ type decoder interface {
 }
 
 func read[B any](ctx context.Context, dec decoder) (B, error) {
+	body, err := decode[B](ctx, dec)
+	if err != nil {
+		return body, err
+	}
+
+	return TransformAndValidate(ctx, body)
+}
+
+func decode[B any](ctx context.Context, dec decoder) (B, error) {
 	var body B
 
 	err := dec.Decode(&body)
@@ -104,7 +183,7 @@ func read[B any](ctx context.Context, dec decoder) (B, error) {
 	}
 	slog.DebugContext(ctx, "Decoded body", "body", body)
 
-	return TransformAndValidate(ctx, body)
+	return body, nil
 }
 
 // ReadString reads the request body as string.
@@ -114,9 +193,16 @@ func ReadString[B ~string](ctx context.Context, input io.Reader) (B, error) {
 	return readString[B](ctx, input, ReadOptions)
 }
 
-func readString[B ~string](ctx context.Context, input io.Reader, _ readOptions) (B, error) {
+func readString[B ~string](ctx context.Context, input io.Reader, options readOptions) (B, error) {
+	if options.ValidateUTF8 {
+		input = newUTF8Reader(input)
+	}
+
 	// Read the request body.
 	readBody, err := io.ReadAll(input)
+	if errors.Is(err, errInvalidUTF8) {
+		return "", invalidUTF8Error(err)
+	}
 	if err != nil {
 		return "", BadRequestError{
 			Err:    err,
@@ -165,15 +251,20 @@ func ReadURLEncoded[B any](r *http.Request) (B, error) {
 func readURLEncoded[B any](r *http.Request, options readOptions) (B, error) {
 	var body B
 
-	err := r.ParseForm()
+	form, err := parseForm(r, options)
 	if err != nil {
-		return body, fmt.Errorf("cannot parse form: %w", err)
+		return body, err
 	}
 
 	decoder := newDecoder()
 	decoder.IgnoreUnknownKeys(!options.DisallowUnknownFields)
 
-	err = decoder.Decode(&body, r.PostForm)
+	values, mapValues := splitBracketNotation(form, formMapFields(reflect.TypeOf(body)))
+
+	err = decoder.Decode(&body, values)
+	if err == nil {
+		err = bindFormMaps(&body, mapValues)
+	}
 	if err != nil {
 		return body, BadRequestError{
 			Detail: "cannot decode x-www-form-urlencoded request body: " + err.Error(),
@@ -188,10 +279,110 @@ func readURLEncoded[B any](r *http.Request, options readOptions) (B, error) {
 	return TransformAndValidate(r.Context(), body)
 }
 
+// formMapFields returns the map fields of the given struct type, by their lowercased form name.
+func formMapFields(t reflect.Type) map[string]int {
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	fields := make(map[string]int)
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() || field.Type.Kind() != reflect.Map {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("schema"), ",")
+		if name == "" {
+			name = field.Name
+		}
+		fields[strings.ToLower(name)] = i
+	}
+	return fields
+}
+
+// splitBracketNotation converts the bracket notation of jQuery/PHP-style form encoders,
+// that gorilla/schema does not understand.
+// "tags[]=a&tags[]=b" becomes "tags=a&tags=b", and "meta[key]=v" entries are returned
+// aside, by field index, when "meta" is a map field.
+func splitBracketNotation(form url.Values, mapFields map[string]int) (url.Values, map[int]url.Values) {
+	values := make(url.Values, len(form))
+	mapValues := make(map[int]url.Values)
+	for key, formValues := range form {
+		name, sub, found := strings.Cut(key, "[")
+		if !found || name == "" || !strings.HasSuffix(sub, "]") {
+			values[key] = append(values[key], formValues...)
+			continue
+		}
+		sub = strings.TrimSuffix(sub, "]")
+
+		switch fieldIndex, isMap := mapFields[strings.ToLower(name)]; {
+		case sub == "":
+			values[name] = append(values[name], formValues...)
+		case isMap && !strings.ContainsAny(sub, "[]"):
+			if mapValues[fieldIndex] == nil {
+				mapValues[fieldIndex] = make(url.Values)
+			}
+			mapValues[fieldIndex][sub] = append(mapValues[fieldIndex][sub], formValues...)
+		default:
+			values[key] = append(values[key], formValues...)
+		}
+	}
+	return values, mapValues
+}
+
+// bindFormMaps sets the map fields of the struct pointed by body from mapValues,
+// converting keys and values to the types of the maps.
+func bindFormMaps(body any, mapValues map[int]url.Values) error {
+	if len(mapValues) == 0 {
+		return nil
+	}
+
+	structValue := reflect.ValueOf(body).Elem()
+	for fieldIndex, entries := range mapValues {
+		field := structValue.Field(fieldIndex)
+		mapType := field.Type()
+		if field.IsNil() {
+			field.Set(reflect.MakeMapWithSize(mapType, len(entries)))
+		}
+
+		for key, entryValues := range entries {
+			mapKey := reflect.New(mapType.Key()).Elem()
+			if err := setParamValue(mapKey, key, mapType.Key().Kind()); err != nil {
+				return fmt.Errorf("%s[%s]: %w", structValue.Type().Field(fieldIndex).Name, key, err)
+			}
+
+			mapValue := reflect.New(mapType.Elem()).Elem()
+			var err error
+			if mapType.Elem().Kind() == reflect.Slice {
+				err = setSliceParamValue(mapValue, entryValues)
+			} else {
+				err = setParamValue(mapValue, entryValues[len(entryValues)-1], mapType.Elem().Kind())
+			}
+			if err != nil {
+				return fmt.Errorf("%s[%s]: %w", structValue.Type().Field(fieldIndex).Name, key, err)
+			}
+
+			field.SetMapIndex(mapKey, mapValue)
+		}
+	}
+	return nil
+}
+
 // transforms the input if possible.
 func transform[B any](ctx context.Context, body B) (B, error) {
+	var steps []func(context.Context) error
 	if inTransformerBody, ok := any(&body).(InTransformer); ok {
-		err := inTransformerBody.InTransform(ctx)
+		steps = append(steps, inTransformerBody.InTransform)
+	}
+	if chain, ok := any(&body).(InTransformerChain); ok {
+		steps = append(steps, chain.InTransformers()...)
+	}
+	if len(steps) == 0 {
+		return body, nil
+	}
+
+	for _, step := range steps {
+		err := step(ctx)
 		if err != nil {
 			return body, BadRequestError{
 				Title:  "Transformation Failed",
@@ -202,11 +393,10 @@ func transform[B any](ctx context.Context, body B) (B, error) {
 				},
 			}
 		}
-		body = *any(inTransformerBody).(*B)
-
-		slog.DebugContext(ctx, "InTransformd body", "body", body)
 	}
 
+	slog.DebugContext(ctx, "InTransformd body", "body", body)
+
 	return body, nil
 }
 
@@ -223,3 +413,111 @@ func TransformAndValidate[B any](ctx context.Context, body B) (B, error) {
 
 	return body, nil
 }
+
+// limitBodyReadTime limits the time spent reading the request body.
+// Contrary to context cancellation, it also interrupts reads blocked on a stalling client.
+// The read deadline of the connection is used, see [http.ResponseController.SetReadDeadline].
+// If the response writer does not support it, the body is wrapped in a [timeoutReader].
+// The returned function clears the deadline, and must be called once the body is read.
+// It is kept once exceeded, so the server does not wait for the rest of the body of a stalled client.
+func limitBodyReadTime(w http.ResponseWriter, body io.ReadCloser, timeout time.Duration) (io.ReadCloser, func()) {
+	controller := http.NewResponseController(w)
+	if err := controller.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+		return newTimeoutReader(body, timeout), func() {}
+	}
+	reader := &deadlineReader{r: body, timeout: timeout}
+	return reader, func() {
+		if !reader.exceeded {
+			_ = controller.SetReadDeadline(time.Time{})
+		}
+	}
+}
+
+// deadlineReader reports the read deadline of the connection being exceeded as a [RequestTimeoutError].
+type deadlineReader struct {
+	r        io.ReadCloser
+	timeout  time.Duration
+	exceeded bool
+}
+
+func (d *deadlineReader) Read(p []byte) (int, error) {
+	n, err := d.r.Read(p)
+	if errors.Is(err, os.ErrDeadlineExceeded) {
+		d.exceeded = true
+		return n, bodyReadTimeoutError(d.timeout)
+	}
+	return n, err
+}
+
+func (d *deadlineReader) Close() error {
+	return d.r.Close()
+}
+
+// timeoutReader enforces a hard deadline on the time spent reading a request body,
+// for the response writers that do not support read deadlines, like [httptest.ResponseRecorder].
+// Each read is run in a goroutine, interrupted by the deadline.
+type timeoutReader struct {
+	r        io.ReadCloser
+	deadline time.Time
+	timeout  time.Duration
+	// result of the in-flight read, if the previous one timed out
+	pending chan readResult
+}
+
+type readResult struct {
+	data []byte
+	err  error
+}
+
+func newTimeoutReader(r io.ReadCloser, timeout time.Duration) *timeoutReader {
+	return &timeoutReader{
+		r:        r,
+		deadline: time.Now().Add(timeout),
+		timeout:  timeout,
+	}
+}
+
+func (t *timeoutReader) Read(p []byte) (int, error) {
+	if t.pending != nil {
+		return 0, t.timeoutError()
+	}
+
+	remaining := time.Until(t.deadline)
+	if remaining <= 0 {
+		return 0, t.timeoutError()
+	}
+
+	// Read in a separate buffer: the goroutine may outlive this call.
+	result := make(chan readResult, 1)
+	go func(buf []byte) {
+		n, err := t.r.Read(buf)
+		result <- readResult{data: buf[:n], err: err}
+	}(make([]byte, len(p)))
+
+	timer := time.NewTimer(remaining)
+	defer timer.Stop()
+
+	select {
+	case res := <-result:
+		return copy(p, res.data), res.err
+	case <-timer.C:
+		t.pending = result
+		return 0, t.timeoutError()
+	}
+}
+
+func (t *timeoutReader) Close() error {
+	return t.r.Close()
+}
+
+func (t *timeoutReader) timeoutError() error {
+	return bodyReadTimeoutError(t.timeout)
+}
+
+func bodyReadTimeoutError(timeout time.Duration) error {
+	return RequestTimeoutError{
+		Title:  "Request Timeout",
+		Err:    fmt.Errorf("request body not read after %s", timeout),
+		Detail: "the request body took too long to be sent",
+	}
+}
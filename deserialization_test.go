@@ -2,15 +2,22 @@ package fuego
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"database/sql"
+	"encoding/json"
 	"encoding/xml"
 	"errors"
+	"fmt"
 	"io"
+	"math"
+	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -60,6 +67,95 @@ func TestReadJSON(t *testing.T) {
 	})
 }
 
+// amount is a number of cents, sent as a number or as a string: 12.5 or "12.50".
+type amount int64
+
+func (a *amount) UnmarshalJSON(data []byte) error {
+	value := strings.Trim(string(data), `"`)
+	float, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fmt.Errorf("invalid amount %s: %w", data, err)
+	}
+	*a = amount(math.Round(float * 100))
+	return nil
+}
+
+type payment struct {
+	Amount   amount `json:"amount"`
+	Currency string `json:"currency" validate:"required"`
+}
+
+// legacyPayment accepts the legacy "value" field with its own unmarshaler.
+type legacyPayment struct {
+	Amount amount
+}
+
+func (p *legacyPayment) UnmarshalJSON(data []byte) error {
+	var fields map[string]amount
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	p.Amount = cmp.Or(fields["amount"], fields["value"])
+	return nil
+}
+
+func TestReadJSON_Unmarshaler(t *testing.T) {
+	t.Run("named type over a primitive", func(t *testing.T) {
+		body, err := ReadJSON[amount](context.Background(), strings.NewReader(`"12.50"`))
+		require.NoError(t, err)
+		require.Equal(t, amount(1250), body)
+
+		body, err = ReadJSON[amount](context.Background(), strings.NewReader(`12.5`))
+		require.NoError(t, err)
+		require.Equal(t, amount(1250), body)
+	})
+
+	t.Run("pointer to a named type", func(t *testing.T) {
+		body, err := ReadJSON[*amount](context.Background(), strings.NewReader(`"0.99"`))
+		require.NoError(t, err)
+		require.Equal(t, amount(99), *body)
+	})
+
+	t.Run("field of a struct", func(t *testing.T) {
+		body, err := ReadJSON[payment](context.Background(), strings.NewReader(`{"amount":"3.10","currency":"EUR"}`))
+		require.NoError(t, err)
+		require.Equal(t, payment{Amount: 310, Currency: "EUR"}, body)
+	})
+
+	t.Run("struct is still validated", func(t *testing.T) {
+		_, err := ReadJSON[payment](context.Background(), strings.NewReader(`{"amount":3}`))
+		require.ErrorAs(t, err, &HTTPError{})
+	})
+
+	t.Run("struct unmarshaler is not restricted by DisallowUnknownFields", func(t *testing.T) {
+		body, err := ReadJSON[legacyPayment](context.Background(), strings.NewReader(`{"value":"1.5"}`))
+		require.NoError(t, err)
+		require.Equal(t, amount(150), body.Amount)
+	})
+
+	t.Run("unmarshaler errors are bad requests", func(t *testing.T) {
+		_, err := ReadJSON[amount](context.Background(), strings.NewReader(`"twelve"`))
+		require.ErrorAs(t, err, &BadRequestError{})
+		require.ErrorContains(t, err, "invalid amount")
+	})
+
+	t.Run("in a route", func(t *testing.T) {
+		s := NewServer()
+		Post(s, "/payments", func(c ContextWithBody[payment]) (int64, error) {
+			body, err := c.Body()
+			return int64(body.Amount), err
+		})
+
+		r := httptest.NewRequest(http.MethodPost, "/payments", strings.NewReader(`{"amount":"12.34","currency":"EUR"}`))
+		r.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, crlf("1234"), w.Body.String())
+	})
+}
+
 func TestReadYAML(t *testing.T) {
 	inputStr := `
 A: a
@@ -231,6 +327,53 @@ func TestInTransformStringWithError(t *testing.T) {
 	})
 }
 
+type bodyWithTransformerChain struct {
+	Name  string
+	Steps []string
+}
+
+func (b *bodyWithTransformerChain) InTransform(context.Context) error {
+	b.Steps = append(b.Steps, "in-transform")
+	return nil
+}
+
+func (b *bodyWithTransformerChain) InTransformers() []func(context.Context) error {
+	return []func(context.Context) error{b.normalize, b.enrich}
+}
+
+func (b *bodyWithTransformerChain) normalize(context.Context) error {
+	b.Name = strings.ToLower(strings.TrimSpace(b.Name))
+	b.Steps = append(b.Steps, "normalize")
+	return nil
+}
+
+func (b *bodyWithTransformerChain) enrich(context.Context) error {
+	if b.Name != "pizza" {
+		return errors.New("unknown recipe " + b.Name)
+	}
+	b.Name += " margherita"
+	b.Steps = append(b.Steps, "enrich")
+	return nil
+}
+
+var _ InTransformerChain = &bodyWithTransformerChain{}
+
+func TestInTransformerChain(t *testing.T) {
+	t.Run("steps run in order on the previous result", func(t *testing.T) {
+		body, err := ReadJSON[bodyWithTransformerChain](context.Background(), strings.NewReader(`{"Name":"  PIZZA "}`))
+		require.NoError(t, err)
+		require.Equal(t, "pizza margherita", body.Name)
+		require.Equal(t, []string{"in-transform", "normalize", "enrich"}, body.Steps)
+	})
+
+	t.Run("an error stops the chain", func(t *testing.T) {
+		body, err := ReadJSON[bodyWithTransformerChain](context.Background(), strings.NewReader(`{"Name":"pasta"}`))
+		require.ErrorAs(t, err, &BadRequestError{})
+		require.ErrorContains(t, err, "unknown recipe pasta")
+		require.Equal(t, []string{"in-transform", "normalize"}, body.Steps)
+	})
+}
+
 func TestReadURLEncoded(t *testing.T) {
 	t.Run("read urlencoded", func(t *testing.T) {
 		input := strings.NewReader(`A=a&B=1&C=true`)
@@ -260,6 +403,49 @@ func TestReadURLEncoded(t *testing.T) {
 		require.Equal(t, BodyTestWithInTransformerError{"a", 9}, res)
 	})
 
+	t.Run("read urlencoded with bracket notation", func(t *testing.T) {
+		type bracketBody struct {
+			A    []int          `schema:"a"`
+			Tags []string       `schema:"tags"`
+			M    map[string]int `schema:"m"`
+			Meta map[string]string
+			IDs  map[int][]string `schema:"ids"`
+		}
+
+		input := strings.NewReader(`a[]=1&a[]=2&tags=x&m[x]=1&m[y]=2&Meta[key]=v&ids[1]=a&ids[1]=b`)
+		r := httptest.NewRequest("POST", "/", input)
+		r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+		res, err := ReadURLEncoded[bracketBody](r)
+		require.NoError(t, err)
+		require.Equal(t, bracketBody{
+			A:    []int{1, 2},
+			Tags: []string{"x"},
+			M:    map[string]int{"x": 1, "y": 2},
+			Meta: map[string]string{"key": "v"},
+			IDs:  map[int][]string{1: {"a", "b"}},
+		}, res)
+	})
+
+	t.Run("read urlencoded with bracket notation and type error", func(t *testing.T) {
+		type bracketBody struct {
+			M map[string]int `schema:"m"`
+		}
+
+		input := strings.NewReader(`m[x]=notanint`)
+		r := httptest.NewRequest("POST", "/", input)
+		r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+		_, err := ReadURLEncoded[bracketBody](r)
+		require.ErrorAs(t, err, &BadRequestError{})
+	})
+
+	t.Run("read urlencoded with bracket notation on unknown field", func(t *testing.T) {
+		input := strings.NewReader(`A=a&unknown[x]=1`)
+		r := httptest.NewRequest("POST", "/", input)
+		r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+		_, err := ReadURLEncoded[BodyTest](r)
+		require.Error(t, err, "unknown fields are rejected by ReadOptions")
+	})
+
 	t.Run("read invalid semicolon separator in query", func(t *testing.T) {
 		r := httptest.NewRequest("POST", "/", nil)
 		r.URL.RawQuery = ";invalid;"
@@ -287,3 +473,98 @@ func TestConvertSQLNullBool(t *testing.T) {
 		require.Equal(t, reflect.Value{}, v)
 	})
 }
+
+// slowReader sends its content one byte at a time, waiting between each byte.
+type slowReader struct {
+	content []byte
+	delay   time.Duration
+}
+
+func (r *slowReader) Read(p []byte) (int, error) {
+	if len(r.content) == 0 {
+		return 0, io.EOF
+	}
+	time.Sleep(r.delay)
+	n := copy(p[:1], r.content)
+	r.content = r.content[n:]
+	return n, nil
+}
+
+func TestBodyReadTimeout(t *testing.T) {
+	t.Run("reads a fast body", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/", io.NopCloser(strings.NewReader(`{"name":"John","age":30}`)))
+		c := NewNetHTTPContext[testStruct, any](BaseRoute{}, httptest.NewRecorder(), r, readOptions{
+			BodyReadTimeout: time.Second,
+		})
+
+		body, err := c.Body()
+		require.NoError(t, err)
+		require.Equal(t, "John", body.Name)
+	})
+
+	t.Run("rejects a slow body", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/", &slowReader{content: []byte(`{"name":"John","age":30}`), delay: 10 * time.Millisecond})
+		c := NewNetHTTPContext[testStruct, any](BaseRoute{}, httptest.NewRecorder(), r, readOptions{
+			BodyReadTimeout: 50 * time.Millisecond,
+		})
+
+		start := time.Now()
+		_, err := c.Body()
+		require.Less(t, time.Since(start), time.Second)
+
+		var timeoutErr RequestTimeoutError
+		require.ErrorAs(t, err, &timeoutErr)
+		require.Equal(t, http.StatusRequestTimeout, timeoutErr.StatusCode())
+	})
+
+	t.Run("interrupts a stalled read", func(t *testing.T) {
+		stalled, writer := io.Pipe()
+		defer writer.Close()
+		r := httptest.NewRequest("POST", "/", stalled)
+		r.Header.Set("Content-Type", "text/plain")
+		c := NewNetHTTPContext[string, any](BaseRoute{}, httptest.NewRecorder(), r, readOptions{
+			BodyReadTimeout: 20 * time.Millisecond,
+		})
+
+		_, err := c.Body()
+		require.ErrorAs(t, err, &RequestTimeoutError{})
+	})
+
+	t.Run("uses the read deadline of the connection", func(t *testing.T) {
+		s := NewServer(
+			WithBodyReadTimeout(50 * time.Millisecond),
+		)
+		Post(s, "/", func(c ContextWithBody[string]) (string, error) {
+			_, err := c.Body()
+			_, isFallback := c.Request().Body.(*timeoutReader)
+			require.False(t, isFallback)
+			return "", err
+		})
+		server := httptest.NewServer(s.Mux)
+		defer server.Close()
+
+		stalled, writer := io.Pipe()
+		defer writer.Close()
+		go func() { _, _ = writer.Write([]byte("Hello")) }()
+
+		response, err := http.Post(server.URL, "text/plain", stalled)
+		require.NoError(t, err)
+		defer response.Body.Close()
+		require.Equal(t, http.StatusRequestTimeout, response.StatusCode)
+	})
+
+	t.Run("returns 408 through the server", func(t *testing.T) {
+		s := NewServer(
+			WithBodyReadTimeout(50 * time.Millisecond),
+		)
+		Post(s, "/", func(c ContextWithBody[testStruct]) (testStruct, error) {
+			return c.Body()
+		})
+
+		r := httptest.NewRequest("POST", "/", &slowReader{content: []byte(`{"name":"John","age":30}`), delay: 10 * time.Millisecond})
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusRequestTimeout, w.Code)
+	})
+}
@@ -0,0 +1,114 @@
+package fuego
+
+import (
+	"bytes"
+	"crypto/md5" // #nosec G501 (Content-MD5 is an integrity check, not a security feature)
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// VerifyContentMD5 checks that the request body matches the base64-encoded MD5 digest
+// sent in the Content-MD5 header, as used by S3-compatible upload APIs.
+// The body is buffered, so it can still be read afterwards.
+// Customizable by modifying ReadOptions.
+func VerifyContentMD5(r *http.Request) error {
+	return verifyContentMD5(r, ReadOptions)
+}
+
+// VerifyDigestSHA256 checks that the request body matches the SHA-256 digest
+// sent in the Digest header (RFC 3230), for example "Digest: SHA-256=X48E9qOokqqrvdts8nOJRJN3OWDUoyWxBf7kbu9DBPE=".
+// The body is buffered, so it can still be read afterwards.
+// Customizable by modifying ReadOptions.
+func VerifyDigestSHA256(r *http.Request) error {
+	return verifyDigestSHA256(r, ReadOptions)
+}
+
+func verifyContentMD5(r *http.Request, options readOptions) error {
+	expected := r.Header.Get("Content-MD5")
+	if expected == "" {
+		return missingDigestError("Content-MD5")
+	}
+
+	return verifyDigest(r, options, "Content-MD5", md5.New(), expected) // #nosec G401
+}
+
+func verifyDigestSHA256(r *http.Request, options readOptions) error {
+	digest := r.Header.Get("Digest")
+
+	// The Digest header can contain several digests: "SHA-256=xxx,MD5=yyy"
+	var expected string
+	for part := range strings.SplitSeq(digest, ",") {
+		algorithm, value, found := strings.Cut(strings.TrimSpace(part), "=")
+		if found && strings.EqualFold(algorithm, "SHA-256") {
+			expected = value
+			break
+		}
+	}
+	if expected == "" {
+		return missingDigestError("Digest")
+	}
+
+	return verifyDigest(r, options, "Digest", sha256.New(), expected)
+}
+
+func verifyDigest(r *http.Request, options readOptions, header string, h hash.Hash, expected string) error {
+	expectedSum, err := base64.StdEncoding.DecodeString(expected)
+	if err != nil {
+		return BadRequestError{
+			Title:  "Invalid Digest",
+			Err:    err,
+			Detail: fmt.Sprintf("the %s header is not valid base64", header),
+		}
+	}
+
+	body, err := bufferBody(r, options)
+	if err != nil {
+		return err
+	}
+
+	h.Write(body)
+	if subtle.ConstantTimeCompare(h.Sum(nil), expectedSum) != 1 {
+		return BadRequestError{
+			Title:  "Digest Mismatch",
+			Err:    fmt.Errorf("request body does not match the %s header", header),
+			Detail: fmt.Sprintf("the request body does not match the %s header", header),
+		}
+	}
+
+	return nil
+}
+
+func missingDigestError(header string) error {
+	return BadRequestError{
+		Title:  "Missing Digest",
+		Err:    errors.New("missing " + header + " header"),
+		Detail: fmt.Sprintf("the %s header is required to verify the request body", header),
+	}
+}
+
+// bufferBody reads the whole request body and replaces it with an in-memory copy,
+// so it can be read again later, for example by [Context.Body].
+func bufferBody(r *http.Request, options readOptions) ([]byte, error) {
+	reader := r.Body
+	if options.MaxBodySize != 0 {
+		reader = http.MaxBytesReader(nil, reader, options.MaxBodySize)
+	}
+
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, BadRequestError{
+			Err:    err,
+			Detail: "cannot read request body: " + err.Error(),
+		}
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
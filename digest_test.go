@@ -0,0 +1,134 @@
+package fuego
+
+import (
+	"crypto/md5" // #nosec G501
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const digestTestBody = `{"name":"John","age":30}`
+
+func md5Base64(s string) string {
+	sum := md5.Sum([]byte(s)) // #nosec G401
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func sha256Base64(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func TestContext_VerifyContentMD5(t *testing.T) {
+	t.Run("matching digest keeps the body readable", func(t *testing.T) {
+		r := httptest.NewRequest("PUT", "/", strings.NewReader(digestTestBody))
+		r.Header.Set("Content-MD5", md5Base64(digestTestBody))
+		c := NewNetHTTPContext[testStruct, any](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+
+		require.NoError(t, c.VerifyContentMD5())
+
+		body, err := c.Body()
+		require.NoError(t, err)
+		require.Equal(t, "John", body.Name)
+		require.Equal(t, 30, body.Age)
+	})
+
+	t.Run("mismatching digest", func(t *testing.T) {
+		r := httptest.NewRequest("PUT", "/", strings.NewReader(digestTestBody))
+		r.Header.Set("Content-MD5", md5Base64("something else"))
+		c := NewNetHTTPContext[testStruct, any](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+
+		err := c.VerifyContentMD5()
+		require.ErrorAs(t, err, &BadRequestError{})
+		require.ErrorContains(t, err, "Digest Mismatch")
+	})
+
+	t.Run("missing header", func(t *testing.T) {
+		r := httptest.NewRequest("PUT", "/", strings.NewReader(digestTestBody))
+		c := NewNetHTTPContext[testStruct, any](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+
+		err := c.VerifyContentMD5()
+		require.ErrorAs(t, err, &BadRequestError{})
+		require.ErrorContains(t, err, "Missing Digest")
+	})
+
+	t.Run("invalid base64", func(t *testing.T) {
+		r := httptest.NewRequest("PUT", "/", strings.NewReader(digestTestBody))
+		r.Header.Set("Content-MD5", "not base64!")
+		c := NewNetHTTPContext[testStruct, any](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+
+		err := c.VerifyContentMD5()
+		require.ErrorAs(t, err, &BadRequestError{})
+		require.ErrorContains(t, err, "Invalid Digest")
+	})
+
+	t.Run("body too large", func(t *testing.T) {
+		r := httptest.NewRequest("PUT", "/", strings.NewReader(digestTestBody))
+		r.Header.Set("Content-MD5", md5Base64(digestTestBody))
+		c := NewNetHTTPContext[testStruct, any](BaseRoute{}, httptest.NewRecorder(), r, readOptions{MaxBodySize: 5})
+
+		require.ErrorAs(t, c.VerifyContentMD5(), &BadRequestError{})
+	})
+}
+
+func TestContext_VerifyDigestSHA256(t *testing.T) {
+	t.Run("matching digest keeps the body readable", func(t *testing.T) {
+		r := httptest.NewRequest("PUT", "/", strings.NewReader(digestTestBody))
+		r.Header.Set("Digest", "SHA-256="+sha256Base64(digestTestBody))
+		c := NewNetHTTPContext[testStruct, any](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+
+		require.NoError(t, c.VerifyDigestSHA256())
+
+		body, err := c.Body()
+		require.NoError(t, err)
+		require.Equal(t, "John", body.Name)
+	})
+
+	t.Run("finds the SHA-256 digest among others", func(t *testing.T) {
+		r := httptest.NewRequest("PUT", "/", strings.NewReader(digestTestBody))
+		r.Header.Set("Digest", "MD5="+md5Base64(digestTestBody)+", sha-256="+sha256Base64(digestTestBody))
+		c := NewNetHTTPContext[testStruct, any](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+
+		require.NoError(t, c.VerifyDigestSHA256())
+	})
+
+	t.Run("mismatching digest", func(t *testing.T) {
+		r := httptest.NewRequest("PUT", "/", strings.NewReader(digestTestBody))
+		r.Header.Set("Digest", "SHA-256="+sha256Base64("something else"))
+		c := NewNetHTTPContext[testStruct, any](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+
+		require.ErrorAs(t, c.VerifyDigestSHA256(), &BadRequestError{})
+	})
+
+	t.Run("missing SHA-256 digest", func(t *testing.T) {
+		r := httptest.NewRequest("PUT", "/", strings.NewReader(digestTestBody))
+		r.Header.Set("Digest", "MD5="+md5Base64(digestTestBody))
+		c := NewNetHTTPContext[testStruct, any](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+
+		err := c.VerifyDigestSHA256()
+		require.ErrorAs(t, err, &BadRequestError{})
+		require.ErrorContains(t, err, "Missing Digest")
+	})
+
+	t.Run("returns 400 through the server", func(t *testing.T) {
+		s := NewServer()
+		Put(s, "/upload", func(c ContextWithBody[testStruct]) (testStruct, error) {
+			if err := c.VerifyDigestSHA256(); err != nil {
+				return testStruct{}, err
+			}
+			return c.Body()
+		})
+
+		r := httptest.NewRequest("PUT", "/upload", strings.NewReader(digestTestBody))
+		r.Header.Set("Digest", "SHA-256="+sha256Base64("tampered"))
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
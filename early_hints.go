@@ -0,0 +1,50 @@
+package fuego
+
+import (
+	"net/http"
+	"path"
+	"strings"
+)
+
+// SendEarlyHints sends a "103 Early Hints" informational response (RFC 8297) with the given links,
+// so browsers start fetching the assets while the final response is prepared.
+// A link is either a path or URL, preloaded as `</app.css>; rel=preload; as=style` with its destination
+// guessed from its extension, or a complete Link header value like `<https://cdn.example.com>; rel=preconnect`.
+// The Link headers are kept for the final response.
+// Early hints are only sent to HTTP/2 and later clients: some HTTP/1.1 clients do not support informational responses.
+func SendEarlyHints(w http.ResponseWriter, r *http.Request, links ...string) {
+	if r.ProtoMajor < 2 || len(links) == 0 {
+		return
+	}
+
+	header := w.Header()
+	for _, link := range links {
+		header.Add("Link", preloadLink(link))
+	}
+	w.WriteHeader(http.StatusEarlyHints)
+}
+
+// preloadLink returns the Link header value preloading the given path, see [SendEarlyHints].
+func preloadLink(link string) string {
+	if strings.HasPrefix(link, "<") {
+		return link
+	}
+
+	value := "<" + link + ">; rel=preload"
+	switch strings.ToLower(path.Ext(link)) {
+	case ".css":
+		value += "; as=style"
+	case ".js", ".mjs":
+		value += "; as=script"
+	case ".woff", ".woff2", ".ttf", ".otf":
+		value += "; as=font; crossorigin"
+	case ".png", ".jpg", ".jpeg", ".gif", ".webp", ".avif", ".svg", ".ico":
+		value += "; as=image"
+	}
+	return value
+}
+
+// EarlyHints sends a "103 Early Hints" response with the given links, see [SendEarlyHints].
+func (c netHttpContext[B, P]) EarlyHints(links ...string) {
+	SendEarlyHints(c.Res, c.Req, links...)
+}
@@ -0,0 +1,77 @@
+package fuego
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"net/textproto"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEarlyHints(t *testing.T) {
+	s := NewServer()
+	Get(s, "/page", func(c ContextNoBody) (string, error) {
+		c.EarlyHints("/static/app.css", "/static/app.js", "<https://cdn.example.com>; rel=preconnect")
+		return "page", nil
+	})
+
+	t.Run("103 followed by the final response over HTTP/2", func(t *testing.T) {
+		ts := httptest.NewUnstartedServer(s.Mux)
+		ts.EnableHTTP2 = true
+		ts.StartTLS()
+		defer ts.Close()
+
+		var informational []int
+		var hintedLinks []string
+		trace := &httptrace.ClientTrace{
+			Got1xxResponse: func(code int, header textproto.MIMEHeader) error {
+				informational = append(informational, code)
+				hintedLinks = header.Values("Link")
+				return nil
+			},
+		}
+		r, err := http.NewRequestWithContext(httptrace.WithClientTrace(t.Context(), trace), http.MethodGet, ts.URL+"/page", nil)
+		require.NoError(t, err)
+
+		resp, err := ts.Client().Do(r)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		require.Equal(t, 2, resp.ProtoMajor)
+		require.Equal(t, []int{http.StatusEarlyHints}, informational)
+		require.Equal(t, []string{
+			"</static/app.css>; rel=preload; as=style",
+			"</static/app.js>; rel=preload; as=script",
+			"<https://cdn.example.com>; rel=preconnect",
+		}, hintedLinks)
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, "page", string(body))
+		require.Len(t, resp.Header.Values("Link"), 3)
+	})
+
+	t.Run("not sent over HTTP/1.1", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/page", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Empty(t, w.Header().Values("Link"))
+	})
+}
+
+func TestPreloadLink(t *testing.T) {
+	for link, expected := range map[string]string{
+		"/app.css":                     "</app.css>; rel=preload; as=style",
+		"/fonts/Inter.woff2":           "</fonts/Inter.woff2>; rel=preload; as=font; crossorigin",
+		"/hero.WEBP":                   "</hero.WEBP>; rel=preload; as=image",
+		"/data":                        "</data>; rel=preload",
+		"</app.js>; rel=modulepreload": "</app.js>; rel=modulepreload",
+	} {
+		require.Equal(t, expected, preloadLink(link), link)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"reflect"
 
 	"github.com/getkin/kin-openapi/openapi3"
 )
@@ -40,7 +41,15 @@ type Engine struct {
 	OpenAPI      *OpenAPI
 	ErrorHandler func(context.Context, error) error
 
-	requestContentTypes []string
+	requestContentTypes  []string
+	responseTransformers map[reflect.Type]responseTransformer
+	// Redacts the errors not meant for clients. See [WithErrorRedactor].
+	errorRedactor func(error) string
+	// Wraps the successful responses and the errors. See [WithResponseEnvelope] and [WithErrorEnvelope].
+	responseEnvelope func(data any, c ResponseTransformerContext) any
+	errorEnvelope    func(err error, c ResponseTransformerContext) any
+	// Adds the memory allocated by the controllers to the response headers. See [WithAllocationReport].
+	allocationReport bool
 }
 
 type OpenAPIConfig struct {
@@ -0,0 +1,64 @@
+package fuego
+
+import (
+	"errors"
+	"net/http"
+)
+
+// WithResponseEnvelope wraps all the successful responses in the value returned by wrap, before serialization,
+// for a consistent API shape like {"data": ..., "request_id": "..."}. Contrary to [RegisterResponseTransformer],
+// it applies to all the routes whatever the returned type, after the transformers.
+// Responses written by the controller (streams, files, redirects) and HTML renderers are not wrapped.
+// See [WithErrorEnvelope] for the errors.
+//
+//	s := fuego.NewServer(
+//		fuego.WithEngineOptions(
+//			fuego.WithResponseEnvelope(func(data any, c fuego.ResponseTransformerContext) any {
+//				return map[string]any{"data": data, "request_id": c.Response().Header().Get("X-Request-ID")}
+//			}),
+//		),
+//	)
+func WithResponseEnvelope(wrap func(data any, c ResponseTransformerContext) any) func(*Engine) {
+	return func(e *Engine) { e.responseEnvelope = wrap }
+}
+
+// WithErrorEnvelope serializes the errors as the value returned by wrap, with the status code of the error,
+// instead of the default problem details, see [WithResponseEnvelope]. The error is the one returned
+// by the error handler, like an [HTTPError].
+//
+//	fuego.WithErrorEnvelope(func(err error, c fuego.ResponseTransformerContext) any {
+//		return map[string]any{"error": err, "request_id": c.Response().Header().Get("X-Request-ID")}
+//	})
+func WithErrorEnvelope(wrap func(err error, c ResponseTransformerContext) any) func(*Engine) {
+	return func(e *Engine) { e.errorEnvelope = wrap }
+}
+
+// envelopeResponse wraps the response with the envelope of the engine, if any, see [WithResponseEnvelope].
+func envelopeResponse[B, P any](e *Engine, ctx ContextFlowable[B, P], response any) any {
+	if e.responseEnvelope == nil || ctx.BytesWritten() > 0 {
+		return response
+	}
+	switch response.(type) {
+	case CtxRenderer, Renderer:
+		return response
+	}
+	return e.responseEnvelope(response, ctx)
+}
+
+// serializeError serializes the error, wrapped in the error envelope of the engine if any, see [WithErrorEnvelope].
+func serializeError[B, P any](e *Engine, ctx ContextFlowable[B, P], err error) {
+	if e.errorEnvelope == nil || ctx.BytesWritten() > 0 {
+		ctx.SerializeError(err)
+		return
+	}
+
+	status := http.StatusInternalServerError
+	var errorWithStatus ErrorWithStatus
+	if errors.As(err, &errorWithStatus) && errorWithStatus.StatusCode() != 0 {
+		status = errorWithStatus.StatusCode()
+	}
+	ctx.SetStatus(status)
+	if serializeErr := ctx.Serialize(e.errorEnvelope(err, ctx)); serializeErr != nil {
+		ctx.SerializeError(err)
+	}
+}
@@ -0,0 +1,77 @@
+package fuego
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvelope(t *testing.T) {
+	s := NewServer(
+		WithEngineOptions(
+			WithResponseEnvelope(func(data any, c ResponseTransformerContext) any {
+				return map[string]any{"data": data, "request_id": c.Header("X-Request-ID")}
+			}),
+			WithErrorEnvelope(func(err error, c ResponseTransformerContext) any {
+				var httpErr HTTPError
+				errors.As(err, &httpErr)
+				return map[string]any{"error": map[string]any{"title": httpErr.Title, "detail": httpErr.Detail}, "request_id": c.Header("X-Request-ID")}
+			}),
+		),
+	)
+	Get(s, "/recipes", func(c ContextNoBody) ([]string, error) {
+		return []string{"pizza", "pasta"}, nil
+	})
+	Get(s, "/missing", func(c ContextNoBody) (any, error) {
+		return nil, NotFoundError{Title: "Recipe Not Found", Detail: "no such recipe"}
+	})
+	Get(s, "/stream", func(c ContextNoBody) (any, error) {
+		return nil, c.TailStream(func() chan string {
+			ch := make(chan string, 1)
+			ch <- "line"
+			close(ch)
+			return ch
+		}())
+	})
+	Get(s, "/written", func(c ContextNoBody) (string, error) {
+		_, err := c.Response().Write([]byte("raw"))
+		return "ignored", err
+	})
+
+	get := func(t *testing.T, path string) *httptest.ResponseRecorder {
+		t.Helper()
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		r.Header.Set("X-Request-ID", "req-1")
+		r.Header.Set("Accept", "application/json")
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("success envelope", func(t *testing.T) {
+		w := get(t, "/recipes")
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.JSONEq(t, `{"data":["pizza","pasta"],"request_id":"req-1"}`, w.Body.String())
+	})
+
+	t.Run("error envelope", func(t *testing.T) {
+		w := get(t, "/missing")
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+		require.JSONEq(t, `{"error":{"title":"Recipe Not Found","detail":"no such recipe"},"request_id":"req-1"}`, w.Body.String())
+	})
+
+	t.Run("streams are not wrapped", func(t *testing.T) {
+		require.Equal(t, "line\n", get(t, "/stream").Body.String())
+	})
+
+	t.Run("written responses are not wrapped", func(t *testing.T) {
+		body := get(t, "/written").Body.String()
+		require.Contains(t, body, "raw")
+		require.NotContains(t, body, "request_id")
+	})
+}
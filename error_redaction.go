@@ -0,0 +1,61 @@
+package fuego
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+)
+
+// WithErrorRedactor hides the messages of the errors returned by the controllers that are not meant for clients.
+// Errors implementing [ErrorWithDetail], like [HTTPError] and the errors derived from it, are sent as usual.
+// Other errors are replaced by a 500 [HTTPError] with the message returned by redact as detail,
+// and a correlation ID as instance. The original error is logged with the same correlation ID ("correlation_id"),
+// to find it from the response. The correlation ID is the request ID (X-Request-ID) when available.
+// If redact is nil, a generic message is used.
+//
+//	s := fuego.NewServer(
+//		fuego.WithEngineOptions(
+//			fuego.WithErrorRedactor(nil),
+//		),
+//	)
+//	// {"title":"Internal Server Error","status":500,"detail":"An internal error occurred","instance":"3f1c..."}
+func WithErrorRedactor(redact func(err error) string) func(*Engine) {
+	if redact == nil {
+		redact = func(error) string { return "An internal error occurred" }
+	}
+	return func(e *Engine) { e.errorRedactor = redact }
+}
+
+// errorContext is the part of the [Context] needed to handle errors.
+type errorContext interface {
+	context.Context
+	Response() http.ResponseWriter
+}
+
+// handleError runs the error handler of the engine, then redacts the error if needed, see [WithErrorRedactor].
+func (e *Engine) handleError(ctx errorContext, err error) error {
+	err = e.ErrorHandler(ctx, err)
+	if e.errorRedactor == nil {
+		return err
+	}
+
+	var errorDetail ErrorWithDetail
+	if errors.As(err, &errorDetail) {
+		return err
+	}
+
+	correlationID := ctx.Response().Header().Get("X-Request-ID")
+	if correlationID == "" {
+		correlationID = defaultRequestIDFunc()
+	}
+	slog.ErrorContext(ctx, "Redacted internal error", "correlation_id", correlationID, "error", err)
+
+	return HTTPError{
+		Err:      err,
+		Title:    http.StatusText(http.StatusInternalServerError),
+		Status:   http.StatusInternalServerError,
+		Detail:   e.errorRedactor(err),
+		Instance: correlationID,
+	}
+}
@@ -0,0 +1,107 @@
+package fuego
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithErrorRedactor(t *testing.T) {
+	defaultLogger := slog.Default()
+	t.Cleanup(func() { slog.SetDefault(defaultLogger) })
+
+	var logs bytes.Buffer
+	s := NewServer(
+		WithLogHandler(slog.NewJSONHandler(&logs, nil)),
+		WithEngineOptions(WithErrorRedactor(nil)),
+	)
+	Get(s, "/internal", func(c ContextNoBody) (any, error) {
+		return nil, errors.New("pq: password authentication failed for user admin")
+	})
+	Get(s, "/public", func(c ContextNoBody) (any, error) {
+		return nil, NotFoundError{Detail: "recipe 42 not found"}
+	})
+
+	// correlationIDs returns the correlation IDs of the redacted errors logged so far.
+	correlationIDs := func(t *testing.T) []string {
+		t.Helper()
+		var ids []string
+		for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+			var entry map[string]any
+			require.NoError(t, json.Unmarshal([]byte(line), &entry))
+			if id, ok := entry["correlation_id"].(string); ok {
+				require.Contains(t, entry["error"], "password authentication failed")
+				ids = append(ids, id)
+			}
+		}
+		return ids
+	}
+
+	t.Run("internal errors are redacted with a correlation ID", func(t *testing.T) {
+		logs.Reset()
+		r := httptest.NewRequest(http.MethodGet, "/internal", nil)
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+		require.NotContains(t, w.Body.String(), "password")
+
+		var response HTTPError
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Equal(t, "An internal error occurred", response.Detail)
+		require.NotEmpty(t, response.Instance)
+		require.Equal(t, w.Header().Get("X-Request-ID"), response.Instance)
+		require.Equal(t, []string{response.Instance}, correlationIDs(t))
+	})
+
+	t.Run("the request ID of the client is the correlation ID", func(t *testing.T) {
+		logs.Reset()
+		r := httptest.NewRequest(http.MethodGet, "/internal", nil)
+		r.Header.Set("X-Request-ID", "req-123")
+		r.Header.Set("Accept", "text/plain")
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+		require.NotContains(t, w.Body.String(), "password")
+		require.Contains(t, w.Body.String(), "An internal error occurred")
+		require.Equal(t, []string{"req-123"}, correlationIDs(t))
+	})
+
+	t.Run("errors with detail are not redacted", func(t *testing.T) {
+		logs.Reset()
+		r := httptest.NewRequest(http.MethodGet, "/public", nil)
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+		require.Contains(t, w.Body.String(), "recipe 42 not found")
+		require.Empty(t, correlationIDs(t))
+	})
+
+	t.Run("custom redactor", func(t *testing.T) {
+		e := NewEngine(WithErrorRedactor(func(err error) string { return "try again later" }))
+		c := NewNetHTTPContext[any, any](BaseRoute{}, httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil), readOptions{})
+
+		err := e.handleError(c, errors.New("disk full"))
+		var httpErr HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		require.Equal(t, "try again later", httpErr.Detail)
+		require.NotEmpty(t, httpErr.Instance)
+	})
+
+	t.Run("without redactor, errors are kept", func(t *testing.T) {
+		e := NewEngine()
+		c := NewNetHTTPContext[any, any](BaseRoute{}, httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil), readOptions{})
+
+		internalErr := errors.New("disk full")
+		require.Equal(t, internalErr, e.handleError(c, internalErr))
+	})
+}
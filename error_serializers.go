@@ -0,0 +1,111 @@
+package fuego
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// DefaultErrorSerializers serve browsers and API clients from the same routes, see [WithErrorSerializers]:
+// an HTML error page for "text/html", RFC 9457 problem details for "application/problem+json", and JSON for "application/json".
+var DefaultErrorSerializers = map[string]ErrorSender{
+	"text/html":                SendHTMLErrorPage,
+	"application/problem+json": SendProblemJSONError,
+	"application/json":         SendJSONError,
+}
+
+// WithErrorSerializers sets the serializers of the errors returned by the controllers, by media type.
+// The serializer is chosen from the Accept header of the request, by order of preference, see [ParseAccept]:
+// "text/*" matches "text/html". Without matching serializer, for example for "*/*" or without Accept header,
+// the error serializer of the server is used, see [WithErrorSerializer].
+//
+//	s := fuego.NewServer(
+//		fuego.WithErrorSerializers(fuego.DefaultErrorSerializers),
+//	)
+//	// Accept: text/html        -> <!DOCTYPE html>... 404 Not Found
+//	// Accept: application/json -> {"title":"Not Found","status":404}
+func WithErrorSerializers(serializers map[string]ErrorSender) func(*Server) {
+	return func(s *Server) { s.errorSerializers = serializers }
+}
+
+// negotiateErrorSerializer returns an error serializer choosing among the serializers from the Accept header,
+// and using the fallback if none matches, see [WithErrorSerializers].
+func negotiateErrorSerializer(serializers map[string]ErrorSender, fallback ErrorSender) ErrorSender {
+	mediaTypes := make([]string, 0, len(serializers))
+	for mediaType := range serializers {
+		mediaTypes = append(mediaTypes, mediaType)
+	}
+	slices.Sort(mediaTypes)
+
+	return func(w http.ResponseWriter, r *http.Request, err error) {
+		AddVary(w.Header(), "Accept")
+		for _, offer := range ParseAccept(r.Header) {
+			if offer.Quality == 0 || offer.Type == "*" {
+				continue
+			}
+			if serializer, ok := serializers[offer.MediaType()]; ok {
+				serializer(w, r, err)
+				return
+			}
+			if offer.Subtype == "*" {
+				for _, mediaType := range mediaTypes {
+					if strings.HasPrefix(mediaType, offer.Type+"/") {
+						serializers[mediaType](w, r, err)
+						return
+					}
+				}
+			}
+		}
+		fallback(w, r, err)
+	}
+}
+
+// publicHTTPError returns the [HTTPError] sent to clients: other errors only expose their status.
+func publicHTTPError(err error) HTTPError {
+	status := http.StatusInternalServerError
+	var errorStatus ErrorWithStatus
+	if errors.As(err, &errorStatus) && errorStatus.StatusCode() != 0 {
+		status = errorStatus.StatusCode()
+	}
+
+	var httpError HTTPError
+	if !errors.As(err, &httpError) {
+		httpError = HTTPError{}
+	}
+	httpError.Status = status
+	if httpError.Title == "" {
+		httpError.Title = http.StatusText(status)
+	}
+	return httpError
+}
+
+// SendProblemJSONError sends the error as RFC 9457 problem details ("application/problem+json"),
+// with at least its status and title, even if it is not an [HTTPError].
+func SendProblemJSONError(w http.ResponseWriter, r *http.Request, err error) {
+	problem := publicHTTPError(err)
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(problem.Status)
+	_ = writeJSON(w, problem, serializeOptionsFrom(r))
+}
+
+var errorPageTemplate = template.Must(template.New("error").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>{{.Status}} {{.Title}}</title></head>
+<body>
+<h1>{{.Status}} {{.Title}}</h1>
+{{with .Detail}}<p>{{.}}</p>{{end}}
+{{with .Errors}}<ul>{{range .}}<li>{{.Name}}: {{.Reason}}</li>{{end}}</ul>{{end}}
+</body>
+</html>
+`))
+
+// SendHTMLErrorPage sends the error as a minimal HTML page, with its status, title and detail escaped,
+// for browsers. Use [WithErrorSerializers] to send it to clients accepting "text/html".
+func SendHTMLErrorPage(w http.ResponseWriter, _ *http.Request, err error) {
+	page := publicHTTPError(err)
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(page.Status)
+	_ = errorPageTemplate.Execute(w, page)
+}
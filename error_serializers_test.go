@@ -0,0 +1,81 @@
+package fuego
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithErrorSerializers(t *testing.T) {
+	s := NewServer(WithErrorSerializers(DefaultErrorSerializers))
+	Get(s, "/recipes/{id}", func(c ContextNoBody) (any, error) {
+		return nil, NotFoundError{Title: "Recipe Not Found", Detail: "no recipe <script>" + c.PathParam("id") + "</script>"}
+	})
+	Get(s, "/internal", func(c ContextNoBody) (any, error) {
+		return nil, errors.New("database password leaked")
+	})
+
+	get := func(t *testing.T, path, accept string) *httptest.ResponseRecorder {
+		t.Helper()
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		if accept != "" {
+			r.Header.Set("Accept", accept)
+		}
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("HTML-accepting client gets an error page", func(t *testing.T) {
+		w := get(t, "/recipes/42", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+		require.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+		require.Contains(t, w.Header().Values("Vary"), "Accept")
+		require.Contains(t, w.Body.String(), "<!DOCTYPE html>")
+		require.Contains(t, w.Body.String(), "<h1>404 Recipe Not Found</h1>")
+		require.Contains(t, w.Body.String(), "no recipe &lt;script&gt;42&lt;/script&gt;")
+	})
+
+	t.Run("JSON-accepting client gets JSON", func(t *testing.T) {
+		w := get(t, "/recipes/42", "application/json")
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+		var body HTTPError
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		require.Equal(t, "Recipe Not Found", body.Title)
+	})
+
+	t.Run("problem+json-accepting client gets RFC 9457 problem details", func(t *testing.T) {
+		w := get(t, "/internal", "application/problem+json")
+
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+		require.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+		require.NotContains(t, w.Body.String(), "password")
+		var body HTTPError
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		require.Equal(t, http.StatusInternalServerError, body.Status)
+		require.NotEmpty(t, body.Title)
+	})
+
+	t.Run("preference order and wildcards", func(t *testing.T) {
+		w := get(t, "/recipes/42", "text/html;q=0.5, application/json")
+		require.Contains(t, w.Header().Get("Content-Type"), "json")
+
+		w = get(t, "/recipes/42", "text/*")
+		require.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	})
+
+	t.Run("falls back to the server error serializer", func(t *testing.T) {
+		for _, accept := range []string{"", "*/*", "image/png"} {
+			w := get(t, "/recipes/42", accept)
+
+			require.Equal(t, http.StatusNotFound, w.Code, accept)
+			require.Contains(t, w.Header().Get("Content-Type"), "json", accept)
+		}
+	})
+}
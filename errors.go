@@ -165,6 +165,20 @@ func (e ConflictError) StatusCode() int { return http.StatusConflict }
 
 func (e ConflictError) Unwrap() error { return HTTPError(e) }
 
+// RequestTimeoutError is an error used to return a 408 status code.
+type RequestTimeoutError HTTPError
+
+var _ ErrorWithStatus = RequestTimeoutError{}
+
+func (e RequestTimeoutError) Error() string {
+	e.Status = http.StatusRequestTimeout
+	return HTTPError(e).Error()
+}
+
+func (e RequestTimeoutError) StatusCode() int { return http.StatusRequestTimeout }
+
+func (e RequestTimeoutError) Unwrap() error { return HTTPError(e) }
+
 // InternalServerError is an error used to return a 500 status code.
 type InternalServerError = HTTPError
 
@@ -182,6 +196,66 @@ func (e NotAcceptableError) StatusCode() int { return http.StatusNotAcceptable }
 
 func (e NotAcceptableError) Unwrap() error { return HTTPError(e) }
 
+// RetryableError is an error used to return a 429 status code, when the client can retry later.
+// The delay is sent in the Retry-After header, see [Context.CheckRateLimit].
+type RetryableError HTTPError
+
+var _ ErrorWithStatus = RetryableError{}
+
+func (e RetryableError) Error() string {
+	e.Status = http.StatusTooManyRequests
+	return HTTPError(e).Error()
+}
+
+func (e RetryableError) StatusCode() int { return http.StatusTooManyRequests }
+
+func (e RetryableError) Unwrap() error { return HTTPError(e) }
+
+// APIVersionError is an error used to return a 400 status code,
+// when the API version of the request is missing or not supported, see [Context.APIVersion].
+type APIVersionError HTTPError
+
+var _ ErrorWithStatus = APIVersionError{}
+
+func (e APIVersionError) Error() string {
+	e.Status = http.StatusBadRequest
+	return HTTPError(e).Error()
+}
+
+func (e APIVersionError) StatusCode() int { return http.StatusBadRequest }
+
+func (e APIVersionError) Unwrap() error { return HTTPError(e) }
+
+// PreconditionFailedError is an error used to return a 412 status code,
+// when the If-Match header does not match the current state of the resource, see [Context.RequireIfMatch].
+type PreconditionFailedError HTTPError
+
+var _ ErrorWithStatus = PreconditionFailedError{}
+
+func (e PreconditionFailedError) Error() string {
+	e.Status = http.StatusPreconditionFailed
+	return HTTPError(e).Error()
+}
+
+func (e PreconditionFailedError) StatusCode() int { return http.StatusPreconditionFailed }
+
+func (e PreconditionFailedError) Unwrap() error { return HTTPError(e) }
+
+// UnsupportedMediaTypeError is an error used to return a 415 status code,
+// when the Content-Type of the request is not supported, see [Context.RequireContentType].
+type UnsupportedMediaTypeError HTTPError
+
+var _ ErrorWithStatus = UnsupportedMediaTypeError{}
+
+func (e UnsupportedMediaTypeError) Error() string {
+	e.Status = http.StatusUnsupportedMediaType
+	return HTTPError(e).Error()
+}
+
+func (e UnsupportedMediaTypeError) StatusCode() int { return http.StatusUnsupportedMediaType }
+
+func (e UnsupportedMediaTypeError) Unwrap() error { return HTTPError(e) }
+
 // ErrorHandler is the default error handler used by the framework.
 // If the error is an [HTTPError] that error is returned.
 // If the error adheres to the [ErrorWithStatus] interface
@@ -100,6 +100,18 @@ func TestErrorHandler(t *testing.T) {
 		require.Equal(t, http.StatusConflict, errResponse.(HTTPError).StatusCode())
 	})
 
+	t.Run("request timeout error", func(t *testing.T) {
+		err := RequestTimeoutError{
+			Err: errors.New("too slow"),
+		}
+		errResponse := ErrorHandler(context.Background(), err)
+		require.ErrorAs(t, errResponse, &HTTPError{})
+		require.ErrorContains(t, err, "too slow")
+		require.ErrorContains(t, errResponse, "Request Timeout")
+		require.ErrorContains(t, errResponse, "408")
+		require.Equal(t, http.StatusRequestTimeout, errResponse.(HTTPError).StatusCode())
+	})
+
 	t.Run("unauthorized error", func(t *testing.T) {
 		err := UnauthorizedError{
 			Err: errors.New("coucou"),
@@ -0,0 +1,52 @@
+package fuego
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+// WithAutoETag computes the ETag of the successful responses to GET and HEAD requests from their serialized body,
+// and sends a 304 Not Modified without body when it matches the If-None-Match header of the request, see [IfNoneMatch].
+// The responses are buffered to be hashed before anything is written, like with [WithBufferedResponses]:
+// responses larger than its maximum size, or flushed by the controller (streaming), are sent as is.
+// An ETag set by the controller is kept and compared instead.
+// Useful for polling clients, which then only download the response when it changes.
+//
+//	s := fuego.NewServer(fuego.WithAutoETag())
+//	// GET /recipes                             -> 200, ETag: "5d41402abc4b2a76b9719d911017c592"
+//	// GET /recipes, If-None-Match: "5d41..."   -> 304, no body
+func WithAutoETag() func(*Server) {
+	return func(s *Server) { s.autoETag = true }
+}
+
+// IfNoneMatch reports whether the If-None-Match header (RFC 9110) matches the given ETag, with a weak comparison:
+// W/"v1" matches "v1". "*" matches any existing resource, that is a non-empty ETag.
+// The ETag can be given with or without its surrounding quotes.
+// It returns false when the header is absent.
+//
+//	If-None-Match: W/"v1", "v2"
+//	-> true for "v1" or `"v2"`, false for "v3"
+func IfNoneMatch(header http.Header, etag string) bool {
+	etag = strings.TrimPrefix(quoteETag(etag), "W/")
+	if etag == "" {
+		return false
+	}
+
+	for _, line := range header.Values("If-None-Match") {
+		for candidate := range strings.SplitSeq(line, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// contentETag returns a strong ETag derived from the content of the response body.
+func contentETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
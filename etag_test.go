@@ -0,0 +1,106 @@
+package fuego
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIfNoneMatch(t *testing.T) {
+	for header, expected := range map[string]bool{
+		``:                 false,
+		`"v1"`:             true,
+		`W/"v1"`:           true,
+		`"v0", "v1"`:       true,
+		`"v2"`:             false,
+		`*`:                true,
+		`"v1-with-suffix"`: false,
+	} {
+		h := http.Header{}
+		if header != "" {
+			h.Set("If-None-Match", header)
+		}
+		require.Equal(t, expected, IfNoneMatch(h, `"v1"`), header)
+		require.Equal(t, expected, IfNoneMatch(h, "v1"), header)
+	}
+
+	require.False(t, IfNoneMatch(http.Header{"If-None-Match": {"*"}}, ""))
+	require.True(t, IfNoneMatch(http.Header{"If-None-Match": {`"v1"`}}, `W/"v1"`))
+}
+
+func TestWithAutoETag(t *testing.T) {
+	recipes := []string{"pasta"}
+	s := NewServer(WithAutoETag())
+	Get(s, "/recipes", func(c ContextNoBody) ([]string, error) {
+		return recipes, nil
+	})
+	Get(s, "/versioned", func(c ContextNoBody) (string, error) {
+		c.SetHeader("ETag", `"v1"`)
+		return "versioned", nil
+	})
+	Get(s, "/error", func(c ContextNoBody) (string, error) {
+		return "", BadRequestError{}
+	})
+	Post(s, "/recipes", func(c ContextNoBody) ([]string, error) {
+		return recipes, nil
+	})
+
+	request := func(t *testing.T, method, path, ifNoneMatch string) *httptest.ResponseRecorder {
+		t.Helper()
+		r := httptest.NewRequest(method, path, nil)
+		if ifNoneMatch != "" {
+			r.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("repeated request with the prior ETag gets 304", func(t *testing.T) {
+		first := request(t, http.MethodGet, "/recipes", "")
+		require.Equal(t, http.StatusOK, first.Code)
+		etag := first.Header().Get("ETag")
+		require.Regexp(t, `^"[0-9a-f]{32}"$`, etag)
+		require.JSONEq(t, `["pasta"]`, first.Body.String())
+
+		second := request(t, http.MethodGet, "/recipes", etag)
+		require.Equal(t, http.StatusNotModified, second.Code)
+		require.Equal(t, etag, second.Header().Get("ETag"))
+		require.Empty(t, second.Body.String())
+
+		weak := request(t, http.MethodGet, "/recipes", "W/"+etag)
+		require.Equal(t, http.StatusNotModified, weak.Code)
+	})
+
+	t.Run("changed response gets 200 with a new ETag", func(t *testing.T) {
+		etag := request(t, http.MethodGet, "/recipes", "").Header().Get("ETag")
+
+		recipes = append(recipes, "pizza")
+		defer func() { recipes = recipes[:1] }()
+
+		w := request(t, http.MethodGet, "/recipes", etag)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.NotEqual(t, etag, w.Header().Get("ETag"))
+		require.JSONEq(t, `["pasta","pizza"]`, w.Body.String())
+	})
+
+	t.Run("ETag set by the controller is kept", func(t *testing.T) {
+		w := request(t, http.MethodGet, "/versioned", "")
+		require.Equal(t, `"v1"`, w.Header().Get("ETag"))
+
+		w = request(t, http.MethodGet, "/versioned", `"v1"`)
+		require.Equal(t, http.StatusNotModified, w.Code)
+	})
+
+	t.Run("errors and other methods have no ETag", func(t *testing.T) {
+		w := request(t, http.MethodGet, "/error", "*")
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Empty(t, w.Header().Get("ETag"))
+
+		w = request(t, http.MethodPost, "/recipes", "*")
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Empty(t, w.Header().Get("ETag"))
+	})
+}
@@ -0,0 +1,62 @@
+package fuego
+
+import (
+	"net/http"
+	"strings"
+)
+
+// WithExpectContinueCheck validates the requests sent with "Expect: 100-continue" before their body is received,
+// for example to reject large or unauthenticated uploads without transferring them.
+// The check runs before the controller, after the middlewares: if it returns an error,
+// the error is sent as the final response (like a 413 [HTTPError] or a 401 [UnauthorizedError])
+// and the body is never read. Otherwise, "100 Continue" is sent right away and the controller is called.
+// Requests without the Expect header are not checked.
+//
+//	s := fuego.NewServer(
+//		fuego.WithExpectContinueCheck(func(r *http.Request) error {
+//			if r.ContentLength > 100<<20 {
+//				return fuego.HTTPError{Status: http.StatusRequestEntityTooLarge, Detail: "uploads are limited to 100MB"}
+//			}
+//			return nil
+//		}),
+//	)
+func WithExpectContinueCheck(check func(r *http.Request) error) func(*Server) {
+	return func(s *Server) { s.expectContinueCheck = check }
+}
+
+// ExpectContinue reports whether the client waits for a "100 Continue" response before sending the body of the request.
+func ExpectContinue(r *http.Request) bool {
+	return strings.EqualFold(strings.TrimSpace(r.Header.Get("Expect")), "100-continue")
+}
+
+// SendContinue sends a "100 Continue" informational response if the client expects it, see [ExpectContinue].
+// The Go HTTP server also sends it automatically when the body is first read.
+func SendContinue(w http.ResponseWriter, r *http.Request) {
+	if ExpectContinue(r) {
+		w.WriteHeader(http.StatusContinue)
+	}
+}
+
+// withExpectContinueCheck wraps the controller to run the check of [WithExpectContinueCheck] before it.
+func withExpectContinueCheck[T, B, P any](check func(r *http.Request) error, controller func(c Context[B, P]) (T, error)) func(c Context[B, P]) (T, error) {
+	return func(c Context[B, P]) (T, error) {
+		if ExpectContinue(c.Request()) {
+			if err := check(c.Request()); err != nil {
+				var zero T
+				return zero, err
+			}
+			c.SendContinue()
+		}
+		return controller(c)
+	}
+}
+
+// ExpectContinue reports whether the client waits for a "100 Continue" response, see [ExpectContinue].
+func (c netHttpContext[B, P]) ExpectContinue() bool {
+	return ExpectContinue(c.Req)
+}
+
+// SendContinue sends a "100 Continue" response if the client expects it, see [SendContinue].
+func (c netHttpContext[B, P]) SendContinue() {
+	SendContinue(c.Res, c.Req)
+}
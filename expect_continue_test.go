@@ -0,0 +1,91 @@
+package fuego
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpectContinue(t *testing.T) {
+	s := NewServer(
+		WithExpectContinueCheck(func(r *http.Request) error {
+			if r.ContentLength > 10 {
+				return HTTPError{Status: http.StatusRequestEntityTooLarge, Detail: "too large"}
+			}
+			return nil
+		}),
+	)
+	Post(s, "/upload", func(c ContextWithBody[string]) (string, error) {
+		body, err := c.Body()
+		return "received " + body, err
+	})
+
+	server := httptest.NewServer(s.Mux)
+	defer server.Close()
+
+	// send writes the headers of the request, and waits for the interim or final response before sending the body.
+	send := func(t *testing.T, body string) (string, string) {
+		t.Helper()
+		conn, err := net.Dial("tcp", server.Listener.Addr().String())
+		require.NoError(t, err)
+		defer conn.Close()
+		require.NoError(t, conn.SetDeadline(time.Now().Add(5*time.Second)))
+
+		_, err = fmt.Fprintf(conn, "POST /upload HTTP/1.1\r\nHost: example.com\r\nContent-Type: text/plain\r\nContent-Length: %d\r\nExpect: 100-continue\r\n\r\n", len(body))
+		require.NoError(t, err)
+
+		reader := bufio.NewReader(conn)
+		statusLine, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		if !strings.Contains(statusLine, "100 Continue") {
+			return statusLine, ""
+		}
+		// Skips the headers of the interim response, until the empty line.
+		for line := ""; line != "\r\n"; {
+			line, err = reader.ReadString('\n')
+			require.NoError(t, err)
+		}
+
+		_, err = io.WriteString(conn, body)
+		require.NoError(t, err)
+		res, err := http.ReadResponse(reader, nil)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		response, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		return statusLine, res.Status + " " + string(response)
+	}
+
+	t.Run("accepted: 100 Continue then the final response", func(t *testing.T) {
+		interim, final := send(t, "small")
+
+		require.Contains(t, interim, "100 Continue")
+		require.Equal(t, "200 OK received small", final)
+	})
+
+	t.Run("rejected before the body is sent", func(t *testing.T) {
+		status, _ := send(t, "a body that is much too large")
+
+		require.Contains(t, status, "413")
+	})
+}
+
+func TestContextExpectContinue(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	w := httptest.NewRecorder()
+	c := NewNetHTTPContext[any, any](BaseRoute{}, w, r, readOptions{})
+	require.False(t, c.ExpectContinue())
+
+	r.Header.Set("Expect", "100-Continue")
+	require.True(t, c.ExpectContinue())
+	c.SendContinue()
+	require.Equal(t, http.StatusContinue, w.Code)
+}
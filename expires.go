@@ -0,0 +1,17 @@
+package fuego
+
+import (
+	"net/http"
+	"time"
+)
+
+// SetExpiresHeader sets the Expires header to the given time, as an HTTP-date (RFC 9111),
+// e.g. "Wed, 01 Jan 2025 00:00:00 GMT".
+func SetExpiresHeader(header http.Header, t time.Time) {
+	header.Set("Expires", t.UTC().Format(http.TimeFormat))
+}
+
+// SetExpires sets the Expires header of the response, see [SetExpiresHeader].
+func (c netHttpContext[B, P]) SetExpires(t time.Time) {
+	SetExpiresHeader(c.Res.Header(), t)
+}
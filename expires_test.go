@@ -0,0 +1,48 @@
+package fuego
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetExpiresHeader(t *testing.T) {
+	t.Run("formats the date as an HTTP-date in GMT", func(t *testing.T) {
+		header := http.Header{}
+		paris := time.FixedZone("CEST", 2*60*60)
+
+		SetExpiresHeader(header, time.Date(2025, 6, 1, 2, 30, 15, 999, paris))
+
+		require.Equal(t, "Sun, 01 Jun 2025 00:30:15 GMT", header.Get("Expires"))
+	})
+
+	t.Run("replaces the previous value", func(t *testing.T) {
+		header := http.Header{"Expires": {"0"}}
+
+		SetExpiresHeader(header, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+
+		require.Equal(t, []string{"Wed, 01 Jan 2025 00:00:00 GMT"}, header.Values("Expires"))
+	})
+}
+
+func TestContextSetExpires(t *testing.T) {
+	s := NewServer()
+	Get(s, "/recipes", func(c ContextNoBody) (string, error) {
+		c.SetExpires(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC))
+		return "recipes", nil
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/recipes", nil)
+	w := httptest.NewRecorder()
+	s.Mux.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "Sun, 01 Jun 2025 00:00:00 GMT", w.Header().Get("Expires"))
+
+	parsed, err := http.ParseTime(w.Header().Get("Expires"))
+	require.NoError(t, err)
+	require.True(t, parsed.Equal(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)))
+}
@@ -2,6 +2,7 @@ package fuegoecho
 
 import (
 	"net/http"
+	"regexp"
 
 	"github.com/labstack/echo/v4"
 
@@ -9,6 +10,8 @@ import (
 	"github.com/go-fuego/fuego/internal"
 )
 
+var pathRegex = regexp.MustCompile(`:([a-zA-Z0-9_]+)`)
+
 type OpenAPIHandler struct {
 	Echo *echo.Echo
 }
@@ -34,112 +37,125 @@ type echoIRouter interface {
 func AddEcho(engine *fuego.Engine, echoRouter echoIRouter,
 	method, path string, handler echo.HandlerFunc,
 	options ...func(*fuego.BaseRoute),
-) *fuego.Route[any, any] {
+) *fuego.Route[any, any, any] {
 	return handleEcho(engine, echoRouter, method, path, handler, options...)
 }
 
 func GetEcho(engine *fuego.Engine, echoRouter echoIRouter,
 	path string, handler echo.HandlerFunc,
 	options ...func(*fuego.BaseRoute),
-) *fuego.Route[any, any] {
+) *fuego.Route[any, any, any] {
 	return handleEcho(engine, echoRouter, http.MethodGet, path, handler, options...)
 }
 
 func PostEcho(engine *fuego.Engine, echoRouter echoIRouter,
 	path string, handler echo.HandlerFunc,
 	options ...func(*fuego.BaseRoute),
-) *fuego.Route[any, any] {
+) *fuego.Route[any, any, any] {
 	return handleEcho(engine, echoRouter, http.MethodPost, path, handler, options...)
 }
 
 func PutEcho(engine *fuego.Engine, echoRouter echoIRouter,
 	path string, handler echo.HandlerFunc,
 	options ...func(*fuego.BaseRoute),
-) *fuego.Route[any, any] {
+) *fuego.Route[any, any, any] {
 	return handleEcho(engine, echoRouter, http.MethodPut, path, handler, options...)
 }
 
 func PatchEcho(engine *fuego.Engine, echoRouter echoIRouter,
 	path string, handler echo.HandlerFunc,
 	options ...func(*fuego.BaseRoute),
-) *fuego.Route[any, any] {
+) *fuego.Route[any, any, any] {
 	return handleEcho(engine, echoRouter, http.MethodPatch, path, handler, options...)
 }
 
 func DeleteEcho(engine *fuego.Engine, echoRouter echoIRouter,
 	path string, handler echo.HandlerFunc,
 	options ...func(*fuego.BaseRoute),
-) *fuego.Route[any, any] {
+) *fuego.Route[any, any, any] {
 	return handleEcho(engine, echoRouter, http.MethodDelete, path, handler, options...)
 }
 
-func Add[T, B any](engine *fuego.Engine, echoRouter echoIRouter, method, path string, handler func(c fuego.ContextWithBody[B]) (T, error), options ...func(*fuego.BaseRoute)) *fuego.Route[T, B] {
+func Add[T, B, P any](engine *fuego.Engine, echoRouter echoIRouter, method, path string, handler func(c fuego.Context[B, P]) (T, error), options ...func(*fuego.BaseRoute)) *fuego.Route[T, B, P] {
 	return handleFuego(engine, echoRouter, method, path, handler, options...)
 }
 
-func Get[T, B any](engine *fuego.Engine, echoRouter echoIRouter, path string, handler func(c fuego.ContextWithBody[B]) (T, error), options ...func(*fuego.BaseRoute)) *fuego.Route[T, B] {
+func Get[T, B, P any](engine *fuego.Engine, echoRouter echoIRouter, path string, handler func(c fuego.Context[B, P]) (T, error), options ...func(*fuego.BaseRoute)) *fuego.Route[T, B, P] {
 	return handleFuego(engine, echoRouter, http.MethodGet, path, handler, options...)
 }
 
-func Post[T, B any](engine *fuego.Engine, echoRouter echoIRouter, path string, handler func(c fuego.ContextWithBody[B]) (T, error), options ...func(*fuego.BaseRoute)) *fuego.Route[T, B] {
+func Post[T, B, P any](engine *fuego.Engine, echoRouter echoIRouter, path string, handler func(c fuego.Context[B, P]) (T, error), options ...func(*fuego.BaseRoute)) *fuego.Route[T, B, P] {
 	return handleFuego(engine, echoRouter, http.MethodPost, path, handler, options...)
 }
 
-func Put[T, B any](engine *fuego.Engine, echoRouter echoIRouter, path string, handler func(c fuego.ContextWithBody[B]) (T, error), options ...func(*fuego.BaseRoute)) *fuego.Route[T, B] {
+func Put[T, B, P any](engine *fuego.Engine, echoRouter echoIRouter, path string, handler func(c fuego.Context[B, P]) (T, error), options ...func(*fuego.BaseRoute)) *fuego.Route[T, B, P] {
 	return handleFuego(engine, echoRouter, http.MethodPut, path, handler, options...)
 }
 
-func Patch[T, B any](engine *fuego.Engine, echoRouter echoIRouter, path string, handler func(c fuego.ContextWithBody[B]) (T, error), options ...func(*fuego.BaseRoute)) *fuego.Route[T, B] {
+func Patch[T, B, P any](engine *fuego.Engine, echoRouter echoIRouter, path string, handler func(c fuego.Context[B, P]) (T, error), options ...func(*fuego.BaseRoute)) *fuego.Route[T, B, P] {
 	return handleFuego(engine, echoRouter, http.MethodPatch, path, handler, options...)
 }
 
-func Delete[T, B any](engine *fuego.Engine, echoRouter echoIRouter, path string, handler func(c fuego.ContextWithBody[B]) (T, error), options ...func(*fuego.BaseRoute)) *fuego.Route[T, B] {
+func Delete[T, B, P any](engine *fuego.Engine, echoRouter echoIRouter, path string, handler func(c fuego.Context[B, P]) (T, error), options ...func(*fuego.BaseRoute)) *fuego.Route[T, B, P] {
 	return handleFuego(engine, echoRouter, http.MethodDelete, path, handler, options...)
 }
 
-func handleFuego[T, B any](engine *fuego.Engine, echoRouter echoIRouter, method, path string, fuegoHandler func(c fuego.ContextWithBody[B]) (T, error), options ...func(*fuego.BaseRoute)) *fuego.Route[T, B] {
-	baseRoute := fuego.NewBaseRoute(method, path, fuegoHandler, engine, options...)
-	return fuego.Registers(engine, echoRouteRegisterer[T, B]{
-		echoRouter:  echoRouter,
-		route:       fuego.Route[T, B]{BaseRoute: baseRoute},
-		echoHandler: EchoHandler(engine, fuegoHandler, baseRoute),
+func handleFuego[T, B, P any](engine *fuego.Engine, echoRouter echoIRouter, method, path string, fuegoHandler func(c fuego.Context[B, P]) (T, error), options ...func(*fuego.BaseRoute)) *fuego.Route[T, B, P] {
+	baseRoute := fuego.NewBaseRoute(method, echoToFuegoRoute(path), fuegoHandler, engine, options...)
+	return fuego.Registers(engine, echoRouteRegisterer[T, B, P]{
+		echoRouter:   echoRouter,
+		route:        fuego.Route[T, B, P]{BaseRoute: baseRoute},
+		echoHandler:  EchoHandler(engine, fuegoHandler, baseRoute),
+		originalPath: path,
 	})
 }
 
-func handleEcho(engine *fuego.Engine, echoRouter echoIRouter, method, path string, echoHandler echo.HandlerFunc, options ...func(*fuego.BaseRoute)) *fuego.Route[any, any] {
-	baseRoute := fuego.NewBaseRoute(method, path, echoHandler, engine, options...)
-	return fuego.Registers(engine, echoRouteRegisterer[any, any]{
-		echoRouter:  echoRouter,
-		route:       fuego.Route[any, any]{BaseRoute: baseRoute},
-		echoHandler: echoHandler,
+func handleEcho(engine *fuego.Engine, echoRouter echoIRouter, method, path string, echoHandler echo.HandlerFunc, options ...func(*fuego.BaseRoute)) *fuego.Route[any, any, any] {
+	baseRoute := fuego.NewBaseRoute(method, echoToFuegoRoute(path), echoHandler, engine, options...)
+	return fuego.Registers(engine, echoRouteRegisterer[any, any, any]{
+		echoRouter:   echoRouter,
+		route:        fuego.Route[any, any, any]{BaseRoute: baseRoute},
+		echoHandler:  echoHandler,
+		originalPath: path,
 	})
 }
 
-type echoRouteRegisterer[T, B any] struct {
+type echoRouteRegisterer[T, B, P any] struct {
 	echoRouter  echoIRouter
 	echoHandler echo.HandlerFunc
-	route       fuego.Route[T, B]
+	route       fuego.Route[T, B, P]
+	// Path in the Echo syntax, like "/pets/:id".
+	originalPath string
+}
+
+func echoToFuegoRoute(path string) string {
+	return pathRegex.ReplaceAllString(path, `{$1}`)
 }
 
-func (a echoRouteRegisterer[T, B]) Register() fuego.Route[T, B] {
-	route := a.echoRouter.Add(a.route.Method, a.route.Path, a.echoHandler)
-	a.route.Path = route.Path
+func (a echoRouteRegisterer[T, B, P]) Register() fuego.Route[T, B, P] {
+	// Echo groups prepend their prefix to the path of the route.
+	route := a.echoRouter.Add(a.route.Method, a.originalPath, a.echoHandler)
+	a.route.Path = echoToFuegoRoute(route.Path)
 	return a.route
 }
 
-// Convert a Fuego handler to a Gin handler.
-func EchoHandler[B, T any](engine *fuego.Engine, handler func(c fuego.ContextWithBody[B]) (T, error), route fuego.BaseRoute) echo.HandlerFunc {
+// Convert a Fuego handler to an Echo handler.
+func EchoHandler[B, T, P any](engine *fuego.Engine, handler func(c fuego.Context[B, P]) (T, error), route fuego.BaseRoute) echo.HandlerFunc {
 	return func(c echo.Context) error {
-		context := &echoContext[B, any]{
+		context := &echoContext[B, P]{
 			CommonContext: internal.CommonContext[B]{
 				CommonCtx:         c.Request().Context(),
 				UrlValues:         c.Request().URL.Query(),
 				OpenAPIParams:     route.Params,
 				DefaultStatusCode: route.DefaultStatusCode,
 			},
-			echoCtx: c,
+			echoCtx:         c,
+			finishCallbacks: &fuego.FinishCallbacks{},
 		}
-		fuego.Flow(engine, context, handler)
+		var err error
+		defer context.finishCallbacks.Finish(&err)
+
+		err = fuego.FlowWithError(engine, context, handler)
 		return nil
 	}
 }
@@ -0,0 +1,62 @@
+package fuegoecho
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-fuego/fuego"
+)
+
+func TestFuegoPathWithEchoPathParam(t *testing.T) {
+	e := fuego.NewEngine()
+	echoRouter := echo.New()
+	group := echoRouter.Group("/api")
+
+	Get(e, group, "/pets/:id", func(c fuego.ContextNoBody) (string, error) {
+		return "pet " + c.PathParam("id"), nil
+	})
+
+	require.NotNil(t, e.OutputOpenAPISpec().Paths.Find("/api/pets/{id}"))
+
+	w := httptest.NewRecorder()
+	echoRouter.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/pets/42", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), "pet 42")
+}
+
+func TestEchoHandler(t *testing.T) {
+	type Pet struct {
+		Name string `json:"name"`
+	}
+
+	e := fuego.NewEngine()
+	echoRouter := echo.New()
+	Post(e, echoRouter, "/pets", func(c fuego.ContextWithBody[Pet]) (Pet, error) {
+		pet, err := c.Body()
+		if err != nil {
+			return Pet{}, err
+		}
+		c.SetStatus(http.StatusCreated)
+		return pet, nil
+	})
+	GetEcho(e, echoRouter, "/ping", func(c echo.Context) error {
+		return c.String(http.StatusOK, "pong")
+	})
+
+	r := httptest.NewRequest(http.MethodPost, "/pets", strings.NewReader(`{"name":"rex"}`))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	echoRouter.ServeHTTP(w, r)
+	require.Equal(t, http.StatusCreated, w.Code)
+	require.JSONEq(t, `{"name":"rex"}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	echoRouter.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ping", nil))
+	require.Equal(t, "pong", w.Body.String())
+	require.NotNil(t, e.OutputOpenAPISpec().Paths.Find("/ping"))
+}
@@ -1,10 +1,19 @@
 package fuegoecho
 
 import (
+	"archive/zip"
+	"cmp"
 	"context"
+	"crypto/x509"
 	"errors"
+	"html/template"
+	"iter"
+	"log/slog"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 
@@ -15,6 +24,8 @@ import (
 type echoContext[B, P any] struct {
 	internal.CommonContext[B]
 	echoCtx echo.Context
+
+	finishCallbacks *fuego.FinishCallbacks
 }
 
 var (
@@ -36,6 +47,22 @@ func (c echoContext[B, P]) Context() context.Context {
 	return c.echoCtx.Request().Context()
 }
 
+func (c echoContext[B, P]) underlyingEchoContext() echo.Context {
+	return c.echoCtx
+}
+
+// EchoContext returns the [echo.Context] of a request handled by Echo.
+// It returns false for other backends, like net/http, instead of panicking as a raw type assertion would.
+//
+//	echoCtx, ok := fuegoecho.EchoContext(c)
+func EchoContext[B, P any](c fuego.Context[B, P]) (echo.Context, bool) {
+	echoCtx, ok := c.(interface{ underlyingEchoContext() echo.Context })
+	if !ok {
+		return nil, false
+	}
+	return echoCtx.underlyingEchoContext(), true
+}
+
 func (c echoContext[B, P]) Cookie(name string) (*http.Cookie, error) {
 	return c.echoCtx.Request().Cookie(name)
 }
@@ -52,13 +79,32 @@ func (c echoContext[B, P]) MustBody() B {
 	return body
 }
 
+func (c echoContext[B, P]) BodyAny() (any, error) {
+	return fuego.ReadBodyAny(c.echoCtx.Request())
+}
+
+func (c echoContext[B, P]) BodyLines() iter.Seq2[string, error] {
+	return fuego.ReadLines(c.echoCtx.Request())
+}
+
+func (c echoContext[B, P]) VerifyContentMD5() error {
+	return fuego.VerifyContentMD5(c.echoCtx.Request())
+}
+
+func (c echoContext[B, P]) MultipartRelated() ([]byte, []fuego.RelatedPart, error) {
+	return fuego.ReadMultipartRelated(c.echoCtx.Request())
+}
+
+func (c echoContext[B, P]) VerifyDigestSHA256() error {
+	return fuego.VerifyDigestSHA256(c.echoCtx.Request())
+}
+
+func (c echoContext[B, P]) VerifySignature(header string, secret []byte, algo string) error {
+	return fuego.VerifySignature(c.echoCtx.Request(), header, secret, algo)
+}
+
 func (c echoContext[B, P]) Params() (P, error) {
-	var params P
-	err := c.echoCtx.Bind(&params)
-	if err != nil {
-		return params, err
-	}
-	return params, nil
+	return fuego.BindParams[P](c)
 }
 
 func (c echoContext[B, P]) MustParams() P {
@@ -69,7 +115,23 @@ func (c echoContext[B, P]) MustParams() P {
 	return params
 }
 
+// PathParam returns the path parameter, URL-decoded.
+// Echo routes on the escaped path if it has encoded characters, without decoding the params.
 func (c echoContext[B, P]) PathParam(name string) string {
+	param := c.echoCtx.Param(name)
+	if c.echoCtx.Request().URL.RawPath == "" {
+		return param
+	}
+	if decoded, err := url.PathUnescape(param); err == nil {
+		return decoded
+	}
+	return param
+}
+
+func (c echoContext[B, P]) PathParamRaw(name string) string {
+	if c.echoCtx.Request().URL.RawPath == "" {
+		return url.PathEscape(c.echoCtx.Param(name))
+	}
 	return c.echoCtx.Param(name)
 }
 
@@ -77,6 +139,10 @@ func (c echoContext[B, P]) PathParamIntErr(name string) (int, error) {
 	return fuego.PathParamIntErr(c, name)
 }
 
+func (c echoContext[B, P]) PathParamIntArr(name, sep string) ([]int, error) {
+	return fuego.PathParamIntArr(c, name, sep)
+}
+
 func (c echoContext[B, P]) PathParamInt(name string) int {
 	param, _ := fuego.PathParamIntErr(c, name)
 	return param
@@ -90,15 +156,90 @@ func (c echoContext[B, P]) MainLocale() string {
 	return strings.Split(c.Request().Header.Get("Accept-Language"), ",")[0]
 }
 
+func (c echoContext[B, P]) SendRaw(contentType string, data []byte) (any, error) {
+	status := c.DefaultStatusCode
+	if status == 0 {
+		status = c.echoCtx.Response().Status
+	}
+	c.echoCtx.Response().Header().Set("Content-Length", strconv.Itoa(len(data)))
+	return nil, c.echoCtx.Blob(status, contentType, data)
+}
+
+func (c echoContext[B, P]) StreamNDJSON(items iter.Seq[any]) error {
+	return fuego.WriteNDJSON(c.echoCtx.Request().Context(), c.echoCtx.Response(), c.DefaultStatusCode, items)
+}
+
+func (c echoContext[B, P]) ZipStream(fn func(zw *zip.Writer) error) (any, error) {
+	return nil, fuego.WriteZip(c.echoCtx.Request().Context(), c.echoCtx.Response(), c.DefaultStatusCode, fn)
+}
+
+func (c echoContext[B, P]) MultipartResponse() (*fuego.MultipartWriter, error) {
+	if c.echoCtx.Response().Committed {
+		return nil, fuego.ErrHeadersAlreadySent
+	}
+	writer := fuego.NewMultipartResponse(c.echoCtx.Response())
+	if c.DefaultStatusCode != 0 {
+		c.echoCtx.Response().WriteHeader(c.DefaultStatusCode)
+	}
+	return writer, nil
+}
+
+func (c echoContext[B, P]) ExpectContinue() bool {
+	return fuego.ExpectContinue(c.echoCtx.Request())
+}
+
+func (c echoContext[B, P]) SendContinue() {
+	// The echo response would be committed with 100 as the final status.
+	fuego.SendContinue(c.echoCtx.Response().Writer, c.echoCtx.Request())
+}
+
+func (c echoContext[B, P]) EarlyHints(links ...string) {
+	fuego.SendEarlyHints(c.echoCtx.Response().Writer, c.echoCtx.Request(), links...)
+}
+
+func (c echoContext[B, P]) Trailers() http.Header {
+	return fuego.RequestTrailers(c.echoCtx.Request())
+}
+
+func (c echoContext[B, P]) Trailer(key string) string {
+	return fuego.RequestTrailers(c.echoCtx.Request()).Get(key)
+}
+
+func (c echoContext[B, P]) RedirectPreserveQuery(code int, path string) (any, error) {
+	location, err := fuego.MergeQuery(path, c.echoCtx.Request().URL.Query())
+	if err != nil {
+		return nil, fuego.BadRequestError{Title: "Invalid Redirect", Err: err, Detail: "cannot redirect to " + path}
+	}
+	return c.Redirect(code, location)
+}
+
 func (c echoContext[B, P]) Redirect(code int, url string) (any, error) {
 	c.echoCtx.Redirect(code, url)
 	return nil, nil
 }
 
+func (c echoContext[B, P]) Health(checks ...fuego.HealthCheck) (any, error) {
+	response := fuego.CheckHealth(c.echoCtx.Request().Context(), checks...)
+	c.SetStatus(response.StatusCode())
+	return response, nil
+}
+
 func (c echoContext[B, P]) Render(templateToExecute string, data any, templateGlobsToOverride ...string) (fuego.CtxRenderer, error) {
 	panic("unimplemented")
 }
 
+func (c echoContext[B, P]) QueryString() string {
+	return c.echoCtx.QueryString()
+}
+
+func (c echoContext[B, P]) RenderMarkdown(md string) template.HTML {
+	return template.HTML(template.HTMLEscapeString(md)) // #nosec G203 (escaped)
+}
+
+func (c echoContext[B, P]) Method() string {
+	return c.echoCtx.Request().Method
+}
+
 func (c echoContext[B, P]) Request() *http.Request {
 	return c.echoCtx.Request()
 }
@@ -125,6 +266,179 @@ func (c echoContext[B, P]) SetHeader(key, value string) {
 	c.echoCtx.Response().Header().Add(key, value)
 }
 
+func (c echoContext[B, P]) SetContentLength(n int64) {
+	c.echoCtx.Response().Header().Set("Content-Length", strconv.FormatInt(n, 10))
+}
+
+func (c echoContext[B, P]) ResetHeaders() error {
+	if c.echoCtx.Response().Committed {
+		return fuego.ErrHeadersAlreadySent
+	}
+	clear(c.echoCtx.Response().Header())
+	return nil
+}
+
+func (c echoContext[B, P]) Prefer(name string) (string, bool) {
+	value, ok := fuego.ParsePreferHeader(c.echoCtx.Request().Header)[strings.ToLower(name)]
+	return value, ok
+}
+
+func (c echoContext[B, P]) StartTiming(name string) func() {
+	return fuego.StartTiming(c.echoCtx.Response().Header(), name)
+}
+
+func (c echoContext[B, P]) Vary(headers ...string) {
+	fuego.AddVary(c.echoCtx.Response().Header(), headers...)
+}
+
+func (c echoContext[B, P]) ClientHints() fuego.ClientHints {
+	fuego.RequestClientHints(c.echoCtx.Response().Header())
+	return fuego.ParseClientHints(c.echoCtx.Request().Header)
+}
+
+func (c echoContext[B, P]) RequireContentType(allowed ...string) error {
+	return fuego.RequireContentType(c.echoCtx.Request().Header, allowed...)
+}
+
+func (c echoContext[B, P]) IfMatch(etag string) bool {
+	return fuego.IfMatch(c.echoCtx.Request().Header, etag)
+}
+
+func (c echoContext[B, P]) RequireIfMatch(current string) error {
+	return fuego.RequireIfMatch(c.echoCtx.Request().Header, current)
+}
+
+func (c echoContext[B, P]) SetPreferenceApplied(name, value string) {
+	if value != "" {
+		name += "=" + value
+	}
+	c.echoCtx.Response().Header().Add("Preference-Applied", name)
+}
+
+func (c echoContext[B, P]) SetLinkHeader(links map[string]string) {
+	if len(links) == 0 {
+		return
+	}
+	c.echoCtx.Response().Header().Set("Link", fuego.FormatLinkHeader(links))
+}
+
+func (c echoContext[B, P]) Deprecate(date time.Time, link string) {
+	fuego.SetDeprecationHeaders(c.echoCtx.Response().Header(), date, link)
+}
+
+func (c echoContext[B, P]) SetExpires(t time.Time) {
+	fuego.SetExpiresHeader(c.echoCtx.Response().Header(), t)
+}
+
+func (c echoContext[B, P]) RemoteIP() string {
+	return fuego.RemoteIP(c.echoCtx.Request())
+}
+
+func (c echoContext[B, P]) FullURL() string {
+	return fuego.FullURL(c.echoCtx.Request())
+}
+
+func (c echoContext[B, P]) ForwardedFor() []string {
+	return fuego.ForwardedFor(c.echoCtx.Request())
+}
+
+func (c echoContext[B, P]) AcceptOffers() []fuego.MediaOffer {
+	return fuego.ParseAccept(c.echoCtx.Request().Header)
+}
+
+func (c echoContext[B, P]) FormValues(name string) []string {
+	return fuego.FormValues(c.echoCtx.Request(), name)
+}
+
+func (c echoContext[B, P]) FormValuesInt(name string) ([]int, error) {
+	return fuego.FormValuesInt(c.echoCtx.Request(), name)
+}
+
+func (c echoContext[B, P]) Logger() *slog.Logger {
+	requestID := cmp.Or(c.echoCtx.Response().Header().Get("X-Request-ID"), c.echoCtx.Request().Header.Get("X-Request-ID"))
+	return fuego.RequestLogger(nil, c.echoCtx.Request(), c.echoCtx.Path(), requestID)
+}
+
+// traceContextKey is the key of the trace context of the request, created on first use.
+const traceContextKey = "fuego.traceContext"
+
+func (c echoContext[B, P]) traceContext() fuego.TraceContext {
+	if trace, ok := c.echoCtx.Get(traceContextKey).(fuego.TraceContext); ok {
+		return trace
+	}
+	trace := fuego.NewTraceContext(c.echoCtx.Request().Header)
+	c.echoCtx.Set(traceContextKey, trace)
+	return trace
+}
+
+func (c echoContext[B, P]) ClientCertificate() (*x509.Certificate, bool) {
+	return fuego.ClientCertificate(c.echoCtx.Request())
+}
+
+func (c echoContext[B, P]) TLSVersion() uint16 {
+	return fuego.TLSVersion(c.echoCtx.Request())
+}
+
+func (c echoContext[B, P]) TraceID() string {
+	return c.traceContext().TraceID
+}
+
+func (c echoContext[B, P]) SpanID() string {
+	return c.traceContext().SpanID
+}
+
+func (c echoContext[B, P]) PropagateTrace(req *http.Request) {
+	c.traceContext().Inject(req.Header)
+}
+
+func (c echoContext[B, P]) Tx() (any, bool) {
+	return fuego.ContextValue[fuego.Tx](c.Context())
+}
+
+func (c echoContext[B, P]) Cursor() (fuego.Cursor, error) {
+	return fuego.ParseCursor(c.QueryParam("cursor"))
+}
+
+func (c echoContext[B, P]) TimeRange(fromParam, toParam, layout string, options ...fuego.TimeRangeOption) (from, to time.Time, err error) {
+	return fuego.ParseTimeRange(c.QueryParam(fromParam), c.QueryParam(toParam), layout, options...)
+}
+
+func (c echoContext[B, P]) OnFinish(fn func(err error)) {
+	c.finishCallbacks.Add(fn)
+}
+
+func (c echoContext[B, P]) DebugInfo() map[string]any {
+	return fuego.RequestDebugInfo(c.echoCtx.Request(), c.echoCtx.Path(), fuego.DefaultDebugRedactedHeaders)
+}
+
+func (c echoContext[B, P]) ResponseCharset() string {
+	return fuego.NegotiateCharset(c.echoCtx.Request().Header)
+}
+
+func (c echoContext[B, P]) BytesWritten() int64 {
+	return c.echoCtx.Response().Size
+}
+
+func (c echoContext[B, P]) SetBandwidthLimit(bytesPerSec int64) {
+	response := c.echoCtx.Response()
+	if w, ok := response.Writer.(*fuego.ThrottledWriter); ok {
+		w.SetLimit(bytesPerSec)
+		return
+	}
+	response.Writer = fuego.NewThrottledWriter(c.echoCtx.Request().Context(), response.Writer, bytesPerSec)
+}
+
+// CheckRateLimit allows all requests, as rate limiters are configured on the Fuego server, see [fuego.WithRateLimiter].
+// Rate limit with an Echo middleware instead.
+func (c echoContext[B, P]) CheckRateLimit(key string) error {
+	return nil
+}
+
+// APIVersion uses the default [fuego.APIVersionConfig], as API versioning is configured on the Fuego server.
+func (c echoContext[B, P]) APIVersion() (string, error) {
+	return fuego.APIVersionFromRequest(c.echoCtx.Request(), fuego.APIVersionConfig{})
+}
+
 func (c echoContext[B, P]) SetStatus(code int) {
 	c.echoCtx.Response().WriteHeader(code)
 }
@@ -138,6 +452,25 @@ func (c echoContext[B, P]) Serialize(data any) error {
 	return nil
 }
 
+func (c echoContext[B, P]) SerializeFields(data any, fields []string) error {
+	if len(fields) == 0 {
+		return c.Serialize(data)
+	}
+	filtered, err := fuego.FilterFields(data, fields)
+	if err != nil {
+		return err
+	}
+	status := c.DefaultStatusCode
+	if status == 0 {
+		status = c.echoCtx.Response().Status
+	}
+	return c.echoCtx.Blob(status, "application/json", filtered)
+}
+
+func (c echoContext[B, P]) TailStream(ch <-chan string) error {
+	return fuego.WriteLines(c.echoCtx.Request().Context(), c.echoCtx.Response(), c.DefaultStatusCode, ch)
+}
+
 func (c echoContext[B, P]) SerializeError(err error) {
 	statusCode := http.StatusInternalServerError
 	var errorWithStatusCode fuego.ErrorWithStatus
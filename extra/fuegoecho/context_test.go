@@ -0,0 +1,17 @@
+package fuegoecho
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckRateLimit(t *testing.T) {
+	echoCtx := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+	c := echoContext[any, any]{echoCtx: echoCtx}
+
+	require.NoError(t, c.CheckRateLimit("127.0.0.1"))
+}
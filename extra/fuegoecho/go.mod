@@ -5,6 +5,7 @@ go 1.24.2
 require (
 	github.com/go-fuego/fuego v0.18.8
 	github.com/labstack/echo/v4 v4.13.3
+	github.com/stretchr/testify v1.10.0
 )
 
 require (
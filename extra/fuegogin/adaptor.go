@@ -154,14 +154,18 @@ func GinHandler[B, T, P any](engine *fuego.Engine, handler func(c fuego.Context[
 	return func(c *gin.Context) {
 		context := &ginContext[B, P]{
 			CommonContext: internal.CommonContext[B]{
-				CommonCtx:         c,
+				CommonCtx:         requestContext{Context: c.Request.Context(), ginCtx: c},
 				UrlValues:         c.Request.URL.Query(),
 				OpenAPIParams:     route.Params,
 				DefaultStatusCode: route.DefaultStatusCode,
 			},
-			ginCtx: c,
+			ginCtx:          c,
+			finishCallbacks: &fuego.FinishCallbacks{},
 		}
 
-		fuego.Flow(engine, context, handler)
+		var err error
+		defer context.finishCallbacks.Finish(&err)
+
+		err = fuego.FlowWithError(engine, context, handler)
 	}
 }
@@ -1,10 +1,19 @@
 package fuegogin
 
 import (
+	"archive/zip"
+	"cmp"
 	"context"
+	"crypto/x509"
 	"errors"
+	"html/template"
+	"iter"
+	"log/slog"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
@@ -15,6 +24,8 @@ import (
 type ginContext[B, P any] struct {
 	internal.CommonContext[B]
 	ginCtx *gin.Context
+
+	finishCallbacks *fuego.FinishCallbacks
 }
 
 var (
@@ -23,6 +34,21 @@ var (
 	_ fuego.ContextFlowable[any, any] = &ginContext[any, any]{}
 )
 
+// requestContext is the [context.Context] implemented by the Fuego context:
+// cancellation and deadline come from the *http.Request (a [gin.Context] is never canceled
+// unless the engine uses ContextWithFallback), values from the *http.Request, then from the [gin.Context] keys.
+type requestContext struct {
+	context.Context
+	ginCtx *gin.Context
+}
+
+func (c requestContext) Value(key any) any {
+	if value := c.Context.Value(key); value != nil {
+		return value
+	}
+	return c.ginCtx.Value(key)
+}
+
 func (c ginContext[B, P]) Body() (B, error) {
 	var body B
 	err := c.ginCtx.Bind(&body)
@@ -36,6 +62,22 @@ func (c ginContext[B, P]) Context() context.Context {
 	return c.ginCtx
 }
 
+func (c ginContext[B, P]) underlyingGinContext() *gin.Context {
+	return c.ginCtx
+}
+
+// GinContext returns the [gin.Context] of a request handled by Gin.
+// It returns false for other backends, like net/http, instead of panicking as a raw type assertion would.
+//
+//	ginCtx, ok := fuegogin.GinContext(c)
+func GinContext[B, P any](c fuego.Context[B, P]) (*gin.Context, bool) {
+	ginCtx, ok := c.(interface{ underlyingGinContext() *gin.Context })
+	if !ok {
+		return nil, false
+	}
+	return ginCtx.underlyingGinContext(), true
+}
+
 func (c ginContext[B, P]) Cookie(name string) (*http.Cookie, error) {
 	return c.ginCtx.Request.Cookie(name)
 }
@@ -52,9 +94,32 @@ func (c ginContext[B, P]) MustBody() B {
 	return body
 }
 
+func (c ginContext[B, P]) BodyAny() (any, error) {
+	return fuego.ReadBodyAny(c.ginCtx.Request)
+}
+
+func (c ginContext[B, P]) BodyLines() iter.Seq2[string, error] {
+	return fuego.ReadLines(c.ginCtx.Request)
+}
+
+func (c ginContext[B, P]) VerifyContentMD5() error {
+	return fuego.VerifyContentMD5(c.ginCtx.Request)
+}
+
+func (c ginContext[B, P]) MultipartRelated() ([]byte, []fuego.RelatedPart, error) {
+	return fuego.ReadMultipartRelated(c.ginCtx.Request)
+}
+
+func (c ginContext[B, P]) VerifyDigestSHA256() error {
+	return fuego.VerifyDigestSHA256(c.ginCtx.Request)
+}
+
+func (c ginContext[B, P]) VerifySignature(header string, secret []byte, algo string) error {
+	return fuego.VerifySignature(c.ginCtx.Request, header, secret, algo)
+}
+
 func (c ginContext[B, P]) Params() (P, error) {
-	var params P
-	return params, nil
+	return fuego.BindParams[P](c)
 }
 
 func (c ginContext[B, P]) MustParams() P {
@@ -69,10 +134,19 @@ func (c ginContext[B, P]) PathParam(name string) string {
 	return c.ginCtx.Param(name)
 }
 
+// PathParamRaw escapes the path parameter again, as Gin only gives the decoded value.
+func (c ginContext[B, P]) PathParamRaw(name string) string {
+	return url.PathEscape(c.ginCtx.Param(name))
+}
+
 func (c ginContext[B, P]) PathParamIntErr(name string) (int, error) {
 	return fuego.PathParamIntErr(c, name)
 }
 
+func (c ginContext[B, P]) PathParamIntArr(name, sep string) ([]int, error) {
+	return fuego.PathParamIntArr(c, name, sep)
+}
+
 func (c ginContext[B, P]) PathParamInt(name string) int {
 	param, _ := fuego.PathParamIntErr(c, name)
 	return param
@@ -86,15 +160,96 @@ func (c ginContext[B, P]) MainLocale() string {
 	return strings.Split(c.Request().Header.Get("Accept-Language"), ",")[0]
 }
 
+func (c ginContext[B, P]) SendRaw(contentType string, data []byte) (any, error) {
+	status := c.DefaultStatusCode
+	if status == 0 {
+		status = c.ginCtx.Writer.Status()
+	}
+	c.ginCtx.Header("Content-Length", strconv.Itoa(len(data)))
+	c.ginCtx.Data(status, contentType, data)
+	return nil, nil
+}
+
+func (c ginContext[B, P]) StreamNDJSON(items iter.Seq[any]) error {
+	return fuego.WriteNDJSON(c.ginCtx.Request.Context(), c.ginCtx.Writer, c.DefaultStatusCode, items)
+}
+
+func (c ginContext[B, P]) ZipStream(fn func(zw *zip.Writer) error) (any, error) {
+	return nil, fuego.WriteZip(c.ginCtx.Request.Context(), c.ginCtx.Writer, c.DefaultStatusCode, fn)
+}
+
+func (c ginContext[B, P]) MultipartResponse() (*fuego.MultipartWriter, error) {
+	if c.ginCtx.Writer.Written() {
+		return nil, fuego.ErrHeadersAlreadySent
+	}
+	writer := fuego.NewMultipartResponse(c.ginCtx.Writer)
+	if c.DefaultStatusCode != 0 {
+		c.ginCtx.Writer.WriteHeader(c.DefaultStatusCode)
+	}
+	return writer, nil
+}
+
+func (c ginContext[B, P]) ExpectContinue() bool {
+	return fuego.ExpectContinue(c.ginCtx.Request)
+}
+
+func (c ginContext[B, P]) SendContinue() {
+	// The gin writer would keep 100 as the final status.
+	if writer, ok := c.ginCtx.Writer.(interface{ Unwrap() http.ResponseWriter }); ok {
+		fuego.SendContinue(writer.Unwrap(), c.ginCtx.Request)
+	}
+}
+
+func (c ginContext[B, P]) EarlyHints(links ...string) {
+	// The gin writer would keep 103 as the final status.
+	if writer, ok := c.ginCtx.Writer.(interface{ Unwrap() http.ResponseWriter }); ok {
+		fuego.SendEarlyHints(writer.Unwrap(), c.ginCtx.Request, links...)
+	}
+}
+
+func (c ginContext[B, P]) Trailers() http.Header {
+	return fuego.RequestTrailers(c.ginCtx.Request)
+}
+
+func (c ginContext[B, P]) Trailer(key string) string {
+	return fuego.RequestTrailers(c.ginCtx.Request).Get(key)
+}
+
+func (c ginContext[B, P]) RedirectPreserveQuery(code int, path string) (any, error) {
+	location, err := fuego.MergeQuery(path, c.ginCtx.Request.URL.Query())
+	if err != nil {
+		return nil, fuego.BadRequestError{Title: "Invalid Redirect", Err: err, Detail: "cannot redirect to " + path}
+	}
+	return c.Redirect(code, location)
+}
+
 func (c ginContext[B, P]) Redirect(code int, url string) (any, error) {
 	c.ginCtx.Redirect(code, url)
 	return nil, nil
 }
 
+func (c ginContext[B, P]) Health(checks ...fuego.HealthCheck) (any, error) {
+	response := fuego.CheckHealth(c.ginCtx.Request.Context(), checks...)
+	c.SetStatus(response.StatusCode())
+	return response, nil
+}
+
 func (c ginContext[B, P]) Render(templateToExecute string, data any, templateGlobsToOverride ...string) (fuego.CtxRenderer, error) {
 	panic("unimplemented")
 }
 
+func (c ginContext[B, P]) QueryString() string {
+	return c.ginCtx.Request.URL.RawQuery
+}
+
+func (c ginContext[B, P]) RenderMarkdown(md string) template.HTML {
+	return template.HTML(template.HTMLEscapeString(md)) // #nosec G203 (escaped)
+}
+
+func (c ginContext[B, P]) Method() string {
+	return c.ginCtx.Request.Method
+}
+
 func (c ginContext[B, P]) Request() *http.Request {
 	return c.ginCtx.Request
 }
@@ -121,6 +276,195 @@ func (c ginContext[B, P]) SetHeader(key, value string) {
 	c.ginCtx.Header(key, value)
 }
 
+func (c ginContext[B, P]) SetContentLength(n int64) {
+	c.ginCtx.Header("Content-Length", strconv.FormatInt(n, 10))
+}
+
+func (c ginContext[B, P]) ResetHeaders() error {
+	if c.ginCtx.Writer.Written() {
+		return fuego.ErrHeadersAlreadySent
+	}
+	clear(c.ginCtx.Writer.Header())
+	return nil
+}
+
+func (c ginContext[B, P]) Prefer(name string) (string, bool) {
+	value, ok := fuego.ParsePreferHeader(c.ginCtx.Request.Header)[strings.ToLower(name)]
+	return value, ok
+}
+
+func (c ginContext[B, P]) StartTiming(name string) func() {
+	return fuego.StartTiming(c.ginCtx.Writer.Header(), name)
+}
+
+func (c ginContext[B, P]) Vary(headers ...string) {
+	fuego.AddVary(c.ginCtx.Writer.Header(), headers...)
+}
+
+func (c ginContext[B, P]) ClientHints() fuego.ClientHints {
+	fuego.RequestClientHints(c.ginCtx.Writer.Header())
+	return fuego.ParseClientHints(c.ginCtx.Request.Header)
+}
+
+func (c ginContext[B, P]) RequireContentType(allowed ...string) error {
+	return fuego.RequireContentType(c.ginCtx.Request.Header, allowed...)
+}
+
+func (c ginContext[B, P]) IfMatch(etag string) bool {
+	return fuego.IfMatch(c.ginCtx.Request.Header, etag)
+}
+
+func (c ginContext[B, P]) RequireIfMatch(current string) error {
+	return fuego.RequireIfMatch(c.ginCtx.Request.Header, current)
+}
+
+func (c ginContext[B, P]) SetPreferenceApplied(name, value string) {
+	if value != "" {
+		name += "=" + value
+	}
+	c.ginCtx.Writer.Header().Add("Preference-Applied", name)
+}
+
+func (c ginContext[B, P]) SetLinkHeader(links map[string]string) {
+	if len(links) == 0 {
+		return
+	}
+	c.ginCtx.Writer.Header().Set("Link", fuego.FormatLinkHeader(links))
+}
+
+func (c ginContext[B, P]) Deprecate(date time.Time, link string) {
+	fuego.SetDeprecationHeaders(c.ginCtx.Writer.Header(), date, link)
+}
+
+func (c ginContext[B, P]) SetExpires(t time.Time) {
+	fuego.SetExpiresHeader(c.ginCtx.Writer.Header(), t)
+}
+
+func (c ginContext[B, P]) RemoteIP() string {
+	return fuego.RemoteIP(c.ginCtx.Request)
+}
+
+func (c ginContext[B, P]) FullURL() string {
+	return fuego.FullURL(c.ginCtx.Request)
+}
+
+func (c ginContext[B, P]) ForwardedFor() []string {
+	return fuego.ForwardedFor(c.ginCtx.Request)
+}
+
+func (c ginContext[B, P]) AcceptOffers() []fuego.MediaOffer {
+	return fuego.ParseAccept(c.ginCtx.Request.Header)
+}
+
+func (c ginContext[B, P]) FormValues(name string) []string {
+	return fuego.FormValues(c.ginCtx.Request, name)
+}
+
+func (c ginContext[B, P]) FormValuesInt(name string) ([]int, error) {
+	return fuego.FormValuesInt(c.ginCtx.Request, name)
+}
+
+func (c ginContext[B, P]) Logger() *slog.Logger {
+	requestID := cmp.Or(c.ginCtx.Writer.Header().Get("X-Request-ID"), c.ginCtx.Request.Header.Get("X-Request-ID"))
+	return fuego.RequestLogger(nil, c.ginCtx.Request, c.ginCtx.FullPath(), requestID)
+}
+
+// traceContextKey is the key of the trace context of the request, created on first use.
+const traceContextKey = "fuego.traceContext"
+
+func (c ginContext[B, P]) traceContext() fuego.TraceContext {
+	if trace, ok := c.ginCtx.Value(traceContextKey).(fuego.TraceContext); ok {
+		return trace
+	}
+	trace := fuego.NewTraceContext(c.ginCtx.Request.Header)
+	c.ginCtx.Set(traceContextKey, trace)
+	return trace
+}
+
+func (c ginContext[B, P]) ClientCertificate() (*x509.Certificate, bool) {
+	return fuego.ClientCertificate(c.ginCtx.Request)
+}
+
+func (c ginContext[B, P]) TLSVersion() uint16 {
+	return fuego.TLSVersion(c.ginCtx.Request)
+}
+
+func (c ginContext[B, P]) TraceID() string {
+	return c.traceContext().TraceID
+}
+
+func (c ginContext[B, P]) SpanID() string {
+	return c.traceContext().SpanID
+}
+
+func (c ginContext[B, P]) PropagateTrace(req *http.Request) {
+	c.traceContext().Inject(req.Header)
+}
+
+func (c ginContext[B, P]) Tx() (any, bool) {
+	return fuego.ContextValue[fuego.Tx](c.Context())
+}
+
+func (c ginContext[B, P]) Cursor() (fuego.Cursor, error) {
+	return fuego.ParseCursor(c.QueryParam("cursor"))
+}
+
+func (c ginContext[B, P]) TimeRange(fromParam, toParam, layout string, options ...fuego.TimeRangeOption) (from, to time.Time, err error) {
+	return fuego.ParseTimeRange(c.QueryParam(fromParam), c.QueryParam(toParam), layout, options...)
+}
+
+func (c ginContext[B, P]) OnFinish(fn func(err error)) {
+	c.finishCallbacks.Add(fn)
+}
+
+func (c ginContext[B, P]) DebugInfo() map[string]any {
+	return fuego.RequestDebugInfo(c.ginCtx.Request, c.ginCtx.FullPath(), fuego.DefaultDebugRedactedHeaders)
+}
+
+func (c ginContext[B, P]) ResponseCharset() string {
+	return fuego.NegotiateCharset(c.ginCtx.Request.Header)
+}
+
+func (c ginContext[B, P]) BytesWritten() int64 {
+	return int64(max(c.ginCtx.Writer.Size(), 0))
+}
+
+func (c ginContext[B, P]) SetBandwidthLimit(bytesPerSec int64) {
+	if w, ok := c.ginCtx.Writer.(throttledWriter); ok {
+		w.throttled.SetLimit(bytesPerSec)
+		return
+	}
+	c.ginCtx.Writer = throttledWriter{
+		ResponseWriter: c.ginCtx.Writer,
+		throttled:      fuego.NewThrottledWriter(c.ginCtx.Request.Context(), c.ginCtx.Writer, bytesPerSec),
+	}
+}
+
+// throttledWriter limits the rate of the body written to the gin writer, see [fuego.ThrottledWriter].
+type throttledWriter struct {
+	gin.ResponseWriter
+	throttled *fuego.ThrottledWriter
+}
+
+func (w throttledWriter) Write(p []byte) (int, error) {
+	return w.throttled.Write(p)
+}
+
+func (w throttledWriter) WriteString(s string) (int, error) {
+	return w.throttled.Write([]byte(s))
+}
+
+// CheckRateLimit allows all requests, as rate limiters are configured on the Fuego server, see [fuego.WithRateLimiter].
+// Rate limit with a Gin middleware instead.
+func (c ginContext[B, P]) CheckRateLimit(key string) error {
+	return nil
+}
+
+// APIVersion uses the default [fuego.APIVersionConfig], as API versioning is configured on the Fuego server.
+func (c ginContext[B, P]) APIVersion() (string, error) {
+	return fuego.APIVersionFromRequest(c.ginCtx.Request, fuego.APIVersionConfig{})
+}
+
 func (c ginContext[B, P]) SetStatus(code int) {
 	c.ginCtx.Status(code)
 }
@@ -134,6 +478,26 @@ func (c ginContext[B, P]) Serialize(data any) error {
 	return nil
 }
 
+func (c ginContext[B, P]) SerializeFields(data any, fields []string) error {
+	if len(fields) == 0 {
+		return c.Serialize(data)
+	}
+	filtered, err := fuego.FilterFields(data, fields)
+	if err != nil {
+		return err
+	}
+	status := c.DefaultStatusCode
+	if status == 0 {
+		status = c.ginCtx.Writer.Status()
+	}
+	c.ginCtx.Data(status, "application/json", filtered)
+	return nil
+}
+
+func (c ginContext[B, P]) TailStream(ch <-chan string) error {
+	return fuego.WriteLines(c.ginCtx.Request.Context(), c.ginCtx.Writer, c.DefaultStatusCode, ch)
+}
+
 func (c ginContext[B, P]) SerializeError(err error) {
 	statusCode := http.StatusInternalServerError
 	var errorWithStatusCode fuego.ErrorWithStatus
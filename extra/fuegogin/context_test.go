@@ -0,0 +1,96 @@
+package fuegogin
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-fuego/fuego"
+)
+
+type testContextKey struct{}
+
+func TestCheckRateLimit(t *testing.T) {
+	ginCtx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c := ginContext[any, any]{ginCtx: ginCtx}
+
+	require.NoError(t, c.CheckRateLimit("127.0.0.1"))
+}
+
+func TestContextConformance(t *testing.T) {
+	e := fuego.NewEngine()
+	ginRouter := gin.New()
+	ginRouter.Use(func(c *gin.Context) {
+		c.Set("user", "john")
+		c.Next()
+	})
+
+	var done, canceled bool
+	var requestValue, ginValue any
+	Get(e, ginRouter, "/ctx", func(c fuego.ContextNoBody) (string, error) {
+		requestValue = c.Value(testContextKey{})
+		ginValue = c.Value("user")
+		select {
+		case <-c.Done():
+			done = true
+			canceled = c.Err() == context.Canceled
+		case <-time.After(time.Second):
+		}
+		return "", nil
+	})
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), testContextKey{}, "value"))
+	cancel()
+	r := httptest.NewRequest("GET", "/ctx", nil).WithContext(ctx)
+	ginRouter.ServeHTTP(httptest.NewRecorder(), r)
+
+	require.True(t, done, "Done() must close when the request is canceled")
+	require.True(t, canceled)
+	require.Equal(t, "value", requestValue)
+	require.Equal(t, "john", ginValue)
+}
+
+func TestGinContext(t *testing.T) {
+	t.Run("gin backend", func(t *testing.T) {
+		e := fuego.NewEngine()
+		ginRouter := gin.New()
+
+		var ginCtx *gin.Context
+		var ok bool
+		Get(e, ginRouter, "/ctx", func(c fuego.ContextNoBody) (string, error) {
+			ginCtx, ok = GinContext(c)
+			return "", nil
+		})
+
+		ginRouter.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/ctx", nil))
+
+		require.True(t, ok)
+		require.NotNil(t, ginCtx)
+		require.Equal(t, "/ctx", ginCtx.FullPath())
+	})
+
+	t.Run("net/http backend", func(t *testing.T) {
+		s := fuego.NewServer()
+
+		var ginCtx *gin.Context
+		ok := true
+		fuego.Get(s, "/ctx", func(c fuego.ContextNoBody) (string, error) {
+			ginCtx, ok = GinContext(c)
+			return "", nil
+		})
+
+		s.Mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/ctx", nil))
+
+		require.False(t, ok)
+		require.Nil(t, ginCtx)
+	})
+
+	t.Run("mock context", func(t *testing.T) {
+		_, ok := GinContext(fuego.NewMockContextNoBody())
+		require.False(t, ok)
+	})
+}
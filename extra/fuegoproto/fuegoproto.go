@@ -0,0 +1,72 @@
+// Package fuegoproto provides [fuego.ProtoCodec] implementations based on google.golang.org/protobuf,
+// for the binary format and for the canonical proto3 JSON mapping.
+// It is kept in its own module so that users not needing protobuf are not burdened by the dependency.
+//
+//	app := fuego.NewServer(
+//		fuego.WithProtoCodec(fuegoproto.Codec{}),
+//		fuego.WithProtoJSONCodec(fuegoproto.JSONCodec{}),
+//	)
+package fuegoproto
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/go-fuego/fuego"
+)
+
+// Codec marshals and unmarshals protobuf messages with [proto.Marshal] and [proto.Unmarshal].
+type Codec struct{}
+
+var _ fuego.ProtoCodec = Codec{}
+
+func (Codec) Marshal(m fuego.ProtoMessage) ([]byte, error) {
+	message, err := protoMessage(m)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(message)
+}
+
+func (Codec) Unmarshal(data []byte, m fuego.ProtoMessage) error {
+	message, err := protoMessage(m)
+	if err != nil {
+		return err
+	}
+	return proto.Unmarshal(data, message)
+}
+
+// JSONCodec marshals and unmarshals protobuf messages as JSON with [protojson], see [fuego.WithProtoJSONCodec].
+// The zero value uses the default options: unknown fields are rejected, and field names are sent in lowerCamelCase.
+type JSONCodec struct {
+	MarshalOptions   protojson.MarshalOptions
+	UnmarshalOptions protojson.UnmarshalOptions
+}
+
+var _ fuego.ProtoCodec = JSONCodec{}
+
+func (c JSONCodec) Marshal(m fuego.ProtoMessage) ([]byte, error) {
+	message, err := protoMessage(m)
+	if err != nil {
+		return nil, err
+	}
+	return c.MarshalOptions.Marshal(message)
+}
+
+func (c JSONCodec) Unmarshal(data []byte, m fuego.ProtoMessage) error {
+	message, err := protoMessage(m)
+	if err != nil {
+		return err
+	}
+	return c.UnmarshalOptions.Unmarshal(data, message)
+}
+
+func protoMessage(m fuego.ProtoMessage) (proto.Message, error) {
+	message, ok := m.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("%T does not implement proto.Message", m)
+	}
+	return message, nil
+}
@@ -0,0 +1,121 @@
+package fuegoproto
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/go-fuego/fuego"
+)
+
+func TestCodec(t *testing.T) {
+	data, err := Codec{}.Marshal(wrapperspb.String("fuego"))
+	require.NoError(t, err)
+
+	message := &wrapperspb.StringValue{}
+	err = Codec{}.Unmarshal(data, message)
+	require.NoError(t, err)
+	require.Equal(t, "fuego", message.GetValue())
+}
+
+func TestRoundTrip(t *testing.T) {
+	s := fuego.NewServer(
+		fuego.WithProtoCodec(Codec{}),
+	)
+
+	fuego.Post(s, "/echo", func(c fuego.ContextWithBody[*wrapperspb.StringValue]) (*wrapperspb.StringValue, error) {
+		body, err := c.Body()
+		if err != nil {
+			return nil, err
+		}
+		return wrapperspb.String("hello " + body.GetValue()), nil
+	})
+
+	payload, err := proto.Marshal(wrapperspb.String("fuego"))
+	require.NoError(t, err)
+
+	r := httptest.NewRequest(http.MethodPost, "/echo", bytes.NewReader(payload))
+	r.Header.Set("Content-Type", "application/x-protobuf")
+	r.Header.Set("Accept", "application/x-protobuf")
+	w := httptest.NewRecorder()
+
+	s.Mux.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "application/x-protobuf", w.Header().Get("Content-Type"))
+
+	response := &wrapperspb.StringValue{}
+	require.NoError(t, proto.Unmarshal(w.Body.Bytes(), response))
+	require.Equal(t, "hello fuego", response.GetValue())
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	s := fuego.NewServer(
+		fuego.WithProtoJSONCodec(JSONCodec{}),
+	)
+
+	// encoding/json would read and send the Timestamp as {"seconds":...,"nanos":...}.
+	fuego.Post(s, "/next-day", func(c fuego.ContextWithBody[*timestamppb.Timestamp]) (*timestamppb.Timestamp, error) {
+		body, err := c.Body()
+		if err != nil {
+			return nil, err
+		}
+		return timestamppb.New(body.AsTime().AddDate(0, 0, 1)), nil
+	})
+	fuego.Post(s, "/plain", func(c fuego.ContextWithBody[map[string]int]) (map[string]int, error) {
+		return c.Body()
+	})
+
+	post := func(t *testing.T, path, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		r := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(body))
+		r.Header.Set("Content-Type", "application/json")
+		r.Header.Set("Accept", "application/json")
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("proto3 JSON mapping", func(t *testing.T) {
+		w := post(t, "/next-day", `"2024-01-02T03:04:05.5Z"`)
+
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		require.Equal(t, `"2024-01-03T03:04:05.500Z"`, w.Body.String())
+	})
+
+	t.Run("invalid message", func(t *testing.T) {
+		w := post(t, "/next-day", `{"seconds":1}`)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("other types use encoding/json", func(t *testing.T) {
+		w := post(t, "/plain", `{"a":1}`)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.JSONEq(t, `{"a":1}`, w.Body.String())
+	})
+
+	t.Run("other servers use encoding/json", func(t *testing.T) {
+		other := fuego.NewServer()
+		fuego.Get(other, "/epoch", func(c fuego.ContextNoBody) (*timestamppb.Timestamp, error) {
+			return timestamppb.New(time.Unix(1, 0)), nil
+		})
+
+		r := httptest.NewRequest(http.MethodGet, "/epoch", nil)
+		r.Header.Set("Accept", "application/json")
+		w := httptest.NewRecorder()
+		other.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.JSONEq(t, `{"seconds":1}`, w.Body.String())
+	})
+}
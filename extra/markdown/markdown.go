@@ -6,11 +6,12 @@ import (
 	"github.com/gomarkdown/markdown"
 	"github.com/gomarkdown/markdown/html"
 	"github.com/gomarkdown/markdown/parser"
+	"github.com/microcosm-cc/bluemonday"
 )
 
 // Markdown converts a markdown string to HTML.
 // Note: fuego does not protect against malicious content
-// sanitation is up the caller of this function.
+// sanitation is up the caller of this function. Use [Renderer] to get sanitized HTML.
 func Markdown(content string) template.HTML {
 	if content == "" {
 		return template.HTML("")
@@ -21,3 +22,34 @@ func Markdown(content string) template.HTML {
 	//nolint:gosec // G203 // the caller of this function needs to sanitize their input
 	return template.HTML(markdown.ToHTML([]byte(content), mdParser, mdRenderer))
 }
+
+// Sanitizer sanitizes untrusted HTML.
+// It is satisfied by [github.com/microcosm-cc/bluemonday.Policy].
+type Sanitizer interface {
+	Sanitize(html string) string
+}
+
+// Renderer converts Markdown to sanitized HTML.
+// It implements [github.com/go-fuego/fuego.MarkdownRenderer]:
+//
+//	app := fuego.NewServer(
+//		fuego.WithMarkdownRenderer(markdown.Renderer{}),
+//	)
+type Renderer struct {
+	// Sanitizer applied to the generated HTML.
+	// Defaults to [bluemonday.UGCPolicy], suitable for user generated content.
+	Sanitizer Sanitizer
+}
+
+var defaultSanitizer = bluemonday.UGCPolicy()
+
+// RenderMarkdown converts the given Markdown to sanitized HTML.
+func (r Renderer) RenderMarkdown(content string) template.HTML {
+	sanitizer := r.Sanitizer
+	if sanitizer == nil {
+		sanitizer = defaultSanitizer
+	}
+
+	//nolint:gosec // G203 // the HTML is sanitized
+	return template.HTML(sanitizer.Sanitize(string(Markdown(content))))
+}
@@ -2,6 +2,7 @@ package markdown
 
 import (
 	"html/template"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -21,3 +22,31 @@ func TestMarkdown(t *testing.T) {
 		require.Equal(t, template.HTML("<h1 id=\"hello\">Hello</h1>\n\n<pre><code>Just **testing**.\n</code></pre>\n"), html)
 	})
 }
+
+func TestRenderer(t *testing.T) {
+	t.Run("can render markdown", func(t *testing.T) {
+		html := Renderer{}.RenderMarkdown("Just **testing**.")
+		require.Equal(t, template.HTML("<p>Just <strong>testing</strong>.</p>\n"), html)
+	})
+
+	t.Run("strips scripts", func(t *testing.T) {
+		html := Renderer{}.RenderMarkdown("Hello <script>alert('xss')</script>")
+		require.NotContains(t, string(html), "<script")
+		require.Contains(t, string(html), "Hello")
+	})
+
+	t.Run("strips javascript links", func(t *testing.T) {
+		html := Renderer{}.RenderMarkdown("[click me](javascript:alert('xss'))")
+		require.NotContains(t, string(html), "javascript:")
+		require.Contains(t, string(html), "click me")
+	})
+
+	t.Run("can use a custom sanitizer", func(t *testing.T) {
+		html := Renderer{Sanitizer: upperSanitizer{}}.RenderMarkdown("hello")
+		require.Equal(t, template.HTML("<P>HELLO</P>\n"), html)
+	})
+}
+
+type upperSanitizer struct{}
+
+func (upperSanitizer) Sanitize(html string) string { return strings.ToUpper(html) }
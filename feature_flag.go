@@ -0,0 +1,69 @@
+package fuego
+
+import (
+	"html/template"
+	"net/http"
+)
+
+// FeatureFlagProvider evaluates feature flags for a request, see [WithFeatureFlagProvider].
+// The request is given for user or cookie-based targeting.
+type FeatureFlagProvider interface {
+	FeatureEnabled(r *http.Request, flag string) bool
+}
+
+// FeatureFlagProviderFunc is an adapter to use a function as a [FeatureFlagProvider].
+type FeatureFlagProviderFunc func(r *http.Request, flag string) bool
+
+var _ FeatureFlagProvider = FeatureFlagProviderFunc(nil)
+
+// FeatureEnabled calls f(r, flag).
+func (f FeatureFlagProviderFunc) FeatureEnabled(r *http.Request, flag string) bool {
+	return f(r, flag)
+}
+
+// WithFeatureFlagProvider sets the provider used by [Server.FeatureEnabled]
+// and by the "featureEnabled" template function, available in templates loaded with [WithTemplateGlobs]:
+//
+//	{{ if featureEnabled "new-checkout" }}...{{ end }}
+//
+// Handlers can branch on feature flags without importing a flag SDK directly.
+func WithFeatureFlagProvider(provider FeatureFlagProvider) func(*Server) {
+	return func(s *Server) { s.featureFlags = provider }
+}
+
+// featureEnabled evaluates the flag with the given provider. Flags are disabled without provider.
+func featureEnabled(provider FeatureFlagProvider, r *http.Request, flag string) bool {
+	if provider == nil {
+		return false
+	}
+	return provider.FeatureEnabled(r, flag)
+}
+
+// FeatureEnabled returns true if the given feature flag is enabled for the request,
+// as evaluated by the provider set with [WithFeatureFlagProvider]. Flags are disabled without provider.
+// Example:
+//
+//	if s.FeatureEnabled(c.Request(), "new-checkout") {
+//		return newCheckout(c)
+//	}
+func (s *Server) FeatureEnabled(r *http.Request, flag string) bool {
+	return featureEnabled(s.featureFlags, r, flag)
+}
+
+// requestTemplates returns a clone of the server templates, with the template functions of the request.
+func (s *Server) requestTemplates(r *http.Request) *template.Template {
+	if s.template == nil {
+		return nil
+	}
+	return template.Must(s.template.Clone()).Funcs(requestTemplateFuncs(s.featureFlags, r))
+}
+
+// requestTemplateFuncs returns the template functions that depend on the request.
+// They override the placeholders of [Server.templateFuncs] in the templates cloned for the request.
+func requestTemplateFuncs(provider FeatureFlagProvider, r *http.Request) template.FuncMap {
+	return template.FuncMap{
+		"featureEnabled": func(flag string) bool {
+			return featureEnabled(provider, r, flag)
+		},
+	}
+}
@@ -0,0 +1,77 @@
+package fuego
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// cookieFeatureFlags enables the flags listed in the "features" cookie.
+var cookieFeatureFlags = FeatureFlagProviderFunc(func(r *http.Request, flag string) bool {
+	cookie, err := r.Cookie("features")
+	return err == nil && cookie.Value == flag
+})
+
+func TestServer_FeatureEnabled(t *testing.T) {
+	s := NewServer(
+		WithFeatureFlagProvider(cookieFeatureFlags),
+	)
+	Get(s, "/checkout", func(c ContextNoBody) (string, error) {
+		if s.FeatureEnabled(c.Request(), "new-checkout") {
+			return "new checkout", nil
+		}
+		return "old checkout", nil
+	})
+
+	t.Run("flag enabled by cookie", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/checkout", nil)
+		r.AddCookie(&http.Cookie{Name: "features", Value: "new-checkout"})
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, "new checkout", w.Body.String())
+	})
+
+	t.Run("flag disabled", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/checkout", nil))
+
+		require.Equal(t, "old checkout", w.Body.String())
+	})
+
+	t.Run("flags are disabled without provider", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.AddCookie(&http.Cookie{Name: "features", Value: "new-checkout"})
+
+		require.False(t, NewServer().FeatureEnabled(r, "new-checkout"))
+	})
+}
+
+func TestFeatureEnabledTemplateFunc(t *testing.T) {
+	s := NewServer(
+		WithTemplateFS(testdata),
+		WithTemplateGlobs("testdata/*.html"),
+		WithFeatureFlagProvider(cookieFeatureFlags),
+	)
+	Get(s, "/checkout", func(c ContextNoBody) (CtxRenderer, error) {
+		return c.Render("feature.html", nil)
+	})
+
+	t.Run("flag enabled by cookie", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/checkout", nil)
+		r.AddCookie(&http.Cookie{Name: "features", Value: "new-checkout"})
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, "<p>new checkout</p>\n", w.Body.String())
+	})
+
+	t.Run("flag disabled", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/checkout", nil))
+
+		require.Equal(t, "<p>old checkout</p>\n", w.Body.String())
+	})
+}
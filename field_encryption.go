@@ -0,0 +1,179 @@
+package fuego
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// FieldKeyProvider returns the AES key (16, 24 or 32 bytes) used to decrypt and encrypt
+// the fields tagged `encrypted:"true"`. The context of the request allows to choose a key per tenant.
+type FieldKeyProvider func(ctx context.Context) ([]byte, error)
+
+// WithFieldEncryption decrypts the string fields tagged `encrypted:"true"` of the JSON request bodies,
+// before they are transformed and validated. Values are expected to be encrypted with AES-GCM
+// and base64-encoded, as done by [EncryptField]. An invalid value is rejected with a 400 [BadRequestError].
+// Responses are not encrypted automatically: use [EncryptFields] in an [OutTransformer].
+//
+//	type Patient struct {
+//		Name string `json:"name"`
+//		SSN  string `json:"ssn" encrypted:"true"`
+//	}
+//
+//	func (p *Patient) OutTransform(ctx context.Context) error {
+//		return fuego.EncryptFields(ctx, p, keys)
+//	}
+//
+//	s := fuego.NewServer(fuego.WithFieldEncryption(keys))
+//
+// For other routers, set [ReadOptions].FieldKeys.
+func WithFieldEncryption(keys FieldKeyProvider) func(*Server) {
+	return func(s *Server) { s.fieldKeys = keys }
+}
+
+// EncryptField encrypts the value with AES-GCM, and returns the nonce and ciphertext encoded in base64.
+func EncryptField(key []byte, plaintext string) (string, error) {
+	gcm, err := newFieldCipher(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// DecryptField decrypts a value encrypted with [EncryptField].
+func DecryptField(key []byte, ciphertext string) (string, error) {
+	gcm, err := newFieldCipher(key)
+	if err != nil {
+		return "", err
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("invalid base64: %w", err)
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", errors.New("ciphertext too short")
+	}
+	plaintext, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+func newFieldCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// EncryptFields encrypts in place the non-empty string fields tagged `encrypted:"true"` of v,
+// a pointer to a struct. Nested structs, pointers and slices are walked.
+func EncryptFields(ctx context.Context, v any, keys FieldKeyProvider) error {
+	return transformEncryptedFields(ctx, reflect.ValueOf(v), keys, EncryptField)
+}
+
+// DecryptFields decrypts in place the non-empty string fields tagged `encrypted:"true"` of v,
+// a pointer to a struct, see [EncryptFields].
+func DecryptFields(ctx context.Context, v any, keys FieldKeyProvider) error {
+	return transformEncryptedFields(ctx, reflect.ValueOf(v), keys, DecryptField)
+}
+
+// decryptBody decrypts the tagged fields of a request body, see [WithFieldEncryption].
+func decryptBody[B any](ctx context.Context, body B, keys FieldKeyProvider) (B, error) {
+	err := DecryptFields(ctx, &body, keys)
+	var fieldErr encryptedFieldError
+	if errors.As(err, &fieldErr) {
+		return body, BadRequestError{
+			Title:  "Decryption Failed",
+			Err:    err,
+			Detail: "cannot decrypt request body: " + err.Error(),
+		}
+	}
+	return body, err
+}
+
+// encryptedFieldError is returned when a field value cannot be transformed, as opposed to a key provider failure.
+type encryptedFieldError struct {
+	field string
+	err   error
+}
+
+func (e encryptedFieldError) Error() string {
+	return "field " + e.field + ": " + e.err.Error()
+}
+
+func (e encryptedFieldError) Unwrap() error {
+	return e.err
+}
+
+func transformEncryptedFields(ctx context.Context, value reflect.Value, keys FieldKeyProvider, transform func(key []byte, value string) (string, error)) error {
+	var key []byte
+	var walk func(value reflect.Value) error
+	walk = func(value reflect.Value) error {
+		switch value.Kind() {
+		case reflect.Pointer, reflect.Interface:
+			if value.IsNil() {
+				return nil
+			}
+			return walk(value.Elem())
+		case reflect.Slice, reflect.Array:
+			if elemKind := value.Type().Elem().Kind(); elemKind == reflect.String || elemKind <= reflect.Complex128 {
+				return nil // No struct to walk, like []byte.
+			}
+			for i := range value.Len() {
+				if err := walk(value.Index(i)); err != nil {
+					return err
+				}
+			}
+		case reflect.Struct:
+			for i := range value.NumField() {
+				field := value.Type().Field(i)
+				if !field.IsExported() {
+					continue
+				}
+				if field.Tag.Get("encrypted") != "true" {
+					if err := walk(value.Field(i)); err != nil {
+						return err
+					}
+					continue
+				}
+
+				if field.Type.Kind() != reflect.String {
+					return fmt.Errorf("encrypted field %s must be a string, got %s", field.Name, field.Type)
+				}
+				if value.Field(i).String() == "" || !value.Field(i).CanSet() {
+					continue
+				}
+
+				// The key is only requested for bodies with encrypted values.
+				if key == nil {
+					var err error
+					if key, err = keys(ctx); err != nil {
+						return fmt.Errorf("cannot get field encryption key: %w", err)
+					}
+				}
+				transformed, err := transform(key, value.Field(i).String())
+				if err != nil {
+					return encryptedFieldError{field: field.Name, err: err}
+				}
+				value.Field(i).SetString(transformed)
+			}
+		}
+		return nil
+	}
+	return walk(value)
+}
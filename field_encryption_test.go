@@ -0,0 +1,129 @@
+package fuego
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type patient struct {
+	Name     string    `json:"name" validate:"required"`
+	SSN      string    `json:"ssn" encrypted:"true" validate:"len=11"`
+	Contacts []contact `json:"contacts"`
+}
+
+type contact struct {
+	Phone string `json:"phone" encrypted:"true"`
+	Note  string `json:"note"`
+}
+
+var testFieldKey = []byte("0123456789abcdef0123456789abcdef")
+
+func testFieldKeys(context.Context) ([]byte, error) {
+	return testFieldKey, nil
+}
+
+func TestFieldEncryption(t *testing.T) {
+	encrypt := func(t *testing.T, plaintext string) string {
+		t.Helper()
+		ciphertext, err := EncryptField(testFieldKey, plaintext)
+		require.NoError(t, err)
+		return ciphertext
+	}
+
+	s := NewServer(WithFieldEncryption(testFieldKeys))
+	Post(s, "/patients", func(c ContextWithBody[patient]) (patient, error) {
+		return c.Body()
+	})
+
+	post := func(t *testing.T, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		r := httptest.NewRequest(http.MethodPost, "/patients", strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("decrypts tagged fields and leaves untagged fields alone", func(t *testing.T) {
+		w := post(t, `{"name":"John","ssn":"`+encrypt(t, "123-45-6789")+`","contacts":[{"phone":"`+encrypt(t, "555-0100")+`","note":"home"}]}`)
+
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.JSONEq(t, `{"name":"John","ssn":"123-45-6789","contacts":[{"phone":"555-0100","note":"home"}]}`, w.Body.String())
+	})
+
+	t.Run("decrypts before validation", func(t *testing.T) {
+		w := post(t, `{"name":"John","ssn":"`+encrypt(t, "too short")+`"}`)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Contains(t, w.Body.String(), "SSN")
+	})
+
+	t.Run("invalid ciphertext", func(t *testing.T) {
+		w := post(t, `{"name":"John","ssn":"123-45-6789"}`)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Contains(t, w.Body.String(), "Decryption Failed")
+	})
+
+	t.Run("empty values are not decrypted", func(t *testing.T) {
+		var called bool
+		body, err := decryptBody(context.Background(), patient{Name: "John"}, func(context.Context) ([]byte, error) {
+			called = true
+			return testFieldKey, nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, patient{Name: "John"}, body)
+		require.False(t, called)
+	})
+
+	t.Run("key provider failure", func(t *testing.T) {
+		keysErr := errors.New("vault unavailable")
+		_, err := decryptBody(context.Background(), patient{SSN: "xxx"}, func(context.Context) ([]byte, error) {
+			return nil, keysErr
+		})
+		require.ErrorIs(t, err, keysErr)
+		require.NotErrorAs(t, err, &BadRequestError{})
+	})
+
+	t.Run("without field encryption, values are kept", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":"John","ssn":"123-45-6789"}`))
+		c := NewNetHTTPContext[patient, any](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+		body, err := c.Body()
+		require.NoError(t, err)
+		require.Equal(t, "123-45-6789", body.SSN)
+	})
+}
+
+func TestEncryptFields(t *testing.T) {
+	p := &patient{Name: "John", SSN: "123-45-6789", Contacts: []contact{{Phone: "555-0100", Note: "home"}}}
+
+	require.NoError(t, EncryptFields(context.Background(), p, testFieldKeys))
+	require.Equal(t, "John", p.Name)
+	require.Equal(t, "home", p.Contacts[0].Note)
+	require.NotEqual(t, "123-45-6789", p.SSN)
+	require.NotEqual(t, "555-0100", p.Contacts[0].Phone)
+
+	require.NoError(t, DecryptFields(context.Background(), p, testFieldKeys))
+	require.Equal(t, "123-45-6789", p.SSN)
+	require.Equal(t, "555-0100", p.Contacts[0].Phone)
+
+	t.Run("tagged field must be a string", func(t *testing.T) {
+		invalid := &struct {
+			Age int `encrypted:"true"`
+		}{Age: 42}
+		require.Error(t, EncryptFields(context.Background(), invalid, testFieldKeys))
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		ciphertext, err := EncryptField(testFieldKey, "secret")
+		require.NoError(t, err)
+		_, err = DecryptField([]byte("fedcba9876543210fedcba9876543210"), ciphertext)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,103 @@
+package fuego
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+)
+
+// errTooManyParts is returned by [partsLimitReader] when a multipart body has more parts than allowed.
+var errTooManyParts = errors.New("multipart body exceeds the maximum number of parts")
+
+// defaultMultipartMemory is the size of the multipart/form-data bodies kept in memory, the rest of the files being stored on disk.
+const defaultMultipartMemory = 32 << 20
+
+// WithMaxMultipartParts rejects the multipart/form-data request bodies with more parts (fields and files)
+// than the given number with a 400 [BadRequestError]. The parts are counted while the body is read,
+// so a body is rejected as soon as the limit is exceeded. It mitigates parameter-pollution DoS,
+// complementing [WithMaxBodySize]. Defaults to 0 (only the limits of the standard library apply).
+func WithMaxMultipartParts(maxParts int) func(*Server) {
+	return func(s *Server) { s.maxMultipartParts = maxParts }
+}
+
+// WithMaxFormFields rejects the x-www-form-urlencoded and multipart/form-data request bodies
+// with more field values than the given number with a 400 [BadRequestError].
+// Defaults to 0 (no limit).
+func WithMaxFormFields(maxFields int) func(*Server) {
+	return func(s *Server) { s.maxFormFields = maxFields }
+}
+
+// parseForm parses the urlencoded or multipart/form-data body of the request in r.PostForm,
+// enforcing the limits of the read options.
+func parseForm(r *http.Request, options readOptions) (url.Values, error) {
+	mediaType, params, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+
+	var err error
+	if mediaType == "multipart/form-data" {
+		if options.MaxMultipartParts > 0 && params["boundary"] != "" {
+			r.Body = &partsLimitReader{r: r.Body, delimiter: []byte("--" + params["boundary"]), maxParts: options.MaxMultipartParts}
+		}
+		err = r.ParseMultipartForm(defaultMultipartMemory)
+	} else {
+		err = r.ParseForm()
+	}
+	if errors.Is(err, errTooManyParts) {
+		return nil, BadRequestError{
+			Title:  "Too Many Parts",
+			Err:    err,
+			Detail: fmt.Sprintf("the multipart body must have at most %d parts", options.MaxMultipartParts),
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse form: %w", err)
+	}
+
+	if options.MaxFormFields > 0 {
+		fields := 0
+		for _, values := range r.PostForm {
+			fields += len(values)
+		}
+		if fields > options.MaxFormFields {
+			return nil, BadRequestError{
+				Title:  "Too Many Fields",
+				Detail: fmt.Sprintf("the form must have at most %d fields, got %d", options.MaxFormFields, fields),
+			}
+		}
+	}
+	return r.PostForm, nil
+}
+
+// partsLimitReader counts the boundary delimiters of the multipart body read through it,
+// and fails once there are more parts than allowed. Since the boundary cannot appear in the parts,
+// a body with n parts has n+1 delimiters, the last one closing the body.
+type partsLimitReader struct {
+	r         io.ReadCloser
+	delimiter []byte
+	maxParts  int
+	// delimiters counted so far
+	delimiters int
+	// end of the previous read, to find the delimiters split between two reads
+	tail []byte
+}
+
+func (p *partsLimitReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if n > 0 {
+		window := append(p.tail, b[:n]...)
+		p.delimiters += bytes.Count(window, p.delimiter)
+		if p.delimiters > p.maxParts+1 {
+			return 0, errTooManyParts
+		}
+		// Keeps the bytes that can start a delimiter, without counting a complete one twice.
+		p.tail = append(p.tail[:0], window[max(0, len(window)-len(p.delimiter)+1):]...)
+	}
+	return n, err
+}
+
+func (p *partsLimitReader) Close() error {
+	return p.r.Close()
+}
@@ -0,0 +1,100 @@
+package fuego
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormLimits(t *testing.T) {
+	type form struct {
+		Name string   `schema:"name"`
+		Tags []string `schema:"tag"`
+	}
+
+	s := NewServer(
+		WithMaxMultipartParts(3),
+		WithMaxFormFields(4),
+	)
+	Post(s, "/form", func(c ContextWithBody[form]) (form, error) {
+		return c.Body()
+	})
+
+	multipartBody := func(t *testing.T, fields int) (*bytes.Buffer, string) {
+		t.Helper()
+		var buf bytes.Buffer
+		w := multipart.NewWriter(&buf)
+		require.NoError(t, w.WriteField("name", "pizza"))
+		for i := 1; i < fields; i++ {
+			require.NoError(t, w.WriteField("tag", fmt.Sprintf("tag%d", i)))
+		}
+		require.NoError(t, w.Close())
+		return &buf, w.FormDataContentType()
+	}
+
+	post := func(t *testing.T, body *bytes.Buffer, contentType string) *httptest.ResponseRecorder {
+		t.Helper()
+		r := httptest.NewRequest(http.MethodPost, "/form", body)
+		r.Header.Set("Content-Type", contentType)
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("multipart within the limits", func(t *testing.T) {
+		body, contentType := multipartBody(t, 3)
+		w := post(t, body, contentType)
+
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.JSONEq(t, `{"Name":"pizza","Tags":["tag1","tag2"]}`, w.Body.String())
+	})
+
+	t.Run("too many multipart parts", func(t *testing.T) {
+		body, contentType := multipartBody(t, 4)
+		w := post(t, body, contentType)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Contains(t, w.Body.String(), "Too Many Parts")
+	})
+
+	t.Run("urlencoded within the limit", func(t *testing.T) {
+		values := url.Values{"name": {"pizza"}, "tag": {"a", "b", "c"}}
+		w := post(t, bytes.NewBufferString(values.Encode()), "application/x-www-form-urlencoded")
+
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	})
+
+	t.Run("too many urlencoded fields", func(t *testing.T) {
+		values := url.Values{"name": {"pizza"}, "tag": {"a", "b", "c", "d"}}
+		w := post(t, bytes.NewBufferString(values.Encode()), "application/x-www-form-urlencoded")
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Contains(t, w.Body.String(), "Too Many Fields")
+	})
+}
+
+func TestPartsLimitReader(t *testing.T) {
+	// A delimiter split between two reads is counted once.
+	body := "--b\r\n\r\n1\r\n--b\r\n\r\n2\r\n--b--"
+	for _, maxParts := range []int{1, 2} {
+		reader := &partsLimitReader{r: io.NopCloser(strings.NewReader(body)), delimiter: []byte("--b"), maxParts: maxParts}
+		buf := make([]byte, 2)
+		var err error
+		for err == nil {
+			_, err = reader.Read(buf)
+		}
+		if maxParts == 1 {
+			require.ErrorIs(t, err, errTooManyParts)
+		} else {
+			require.Equal(t, 3, reader.delimiters)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package fuego
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// FormValues returns all the values of the given field of the urlencoded or multipart/form-data request body,
+// like the options of a multi-select field. The form is parsed once, [Context.Body] can still be called afterwards.
+// Query parameters are not included, see [Context.QueryParamArr].
+// It returns nil if the field is missing or the body cannot be parsed.
+// Customizable by modifying ReadOptions.
+func FormValues(r *http.Request, name string) []string {
+	values, _ := formValues(r, name, ReadOptions)
+	return values
+}
+
+// FormValuesInt returns all the values of the given field of the form request body as ints, see [FormValues].
+// It returns a [BadRequestError] if a value is not an int or the body cannot be parsed.
+func FormValuesInt(r *http.Request, name string) ([]int, error) {
+	return formValuesInt(r, name, ReadOptions)
+}
+
+func formValues(r *http.Request, name string, options readOptions) ([]string, error) {
+	if r.PostForm == nil && options.MaxBodySize != 0 && r.Body != nil {
+		r.Body = http.MaxBytesReader(nil, r.Body, options.MaxBodySize)
+	}
+	form, err := parseForm(r, options)
+	if err != nil {
+		return nil, err
+	}
+	return form[name], nil
+}
+
+func formValuesInt(r *http.Request, name string, options readOptions) ([]int, error) {
+	values, err := formValues(r, name, options)
+	if err != nil {
+		return nil, err
+	}
+
+	ints := make([]int, 0, len(values))
+	for _, value := range values {
+		i, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, BadRequestError{
+				Title:  "Invalid Form Value",
+				Err:    err,
+				Detail: fmt.Sprintf("form field %s=%s is not of type int", name, value),
+				Errors: []ErrorItem{{Name: name, Reason: "must be an int"}},
+			}
+		}
+		ints = append(ints, i)
+	}
+	return ints, nil
+}
+
+// FormValues returns all the values of the given field of the form request body, see [FormValues].
+func (c netHttpContext[B, P]) FormValues(name string) []string {
+	values, _ := formValues(c.Req, name, c.readOptions)
+	return values
+}
+
+// FormValuesInt returns all the values of the given field of the form request body as ints, see [FormValuesInt].
+func (c netHttpContext[B, P]) FormValuesInt(name string) ([]int, error) {
+	return formValuesInt(c.Req, name, c.readOptions)
+}
@@ -0,0 +1,84 @@
+package fuego
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormValues(t *testing.T) {
+	type profile struct {
+		Name    string   `schema:"name"`
+		Roles   []string `schema:"role"`
+		TeamIDs []string `schema:"team_id"`
+	}
+
+	s := NewServer()
+	Post(s, "/profile", func(c ContextWithBody[profile]) (map[string]any, error) {
+		ids, err := c.FormValuesInt("team_id")
+		if err != nil {
+			return nil, err
+		}
+		body, err := c.Body()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{
+			"name":    body.Name,
+			"roles":   c.FormValues("role"),
+			"teams":   ids,
+			"missing": c.FormValues("missing"),
+		}, nil
+	})
+
+	post := func(t *testing.T, body *bytes.Buffer, contentType string) *httptest.ResponseRecorder {
+		t.Helper()
+		r := httptest.NewRequest(http.MethodPost, "/profile?role=ignored", body)
+		r.Header.Set("Content-Type", contentType)
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("urlencoded repeated fields", func(t *testing.T) {
+		w := post(t, bytes.NewBufferString("name=Ada&role=admin&role=editor&team_id=1&team_id=42"), "application/x-www-form-urlencoded")
+
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.JSONEq(t, `{"name":"Ada","roles":["admin","editor"],"teams":[1,42],"missing":null}`, w.Body.String())
+	})
+
+	t.Run("multipart repeated fields", func(t *testing.T) {
+		var buf bytes.Buffer
+		mw := multipart.NewWriter(&buf)
+		for _, field := range [][2]string{{"name", "Ada"}, {"role", "admin"}, {"role", "viewer"}, {"team_id", "7"}} {
+			require.NoError(t, mw.WriteField(field[0], field[1]))
+		}
+		require.NoError(t, mw.Close())
+		w := post(t, &buf, mw.FormDataContentType())
+
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.JSONEq(t, `{"name":"Ada","roles":["admin","viewer"],"teams":[7],"missing":null}`, w.Body.String())
+	})
+
+	t.Run("invalid int", func(t *testing.T) {
+		w := post(t, bytes.NewBufferString("name=Ada&team_id=1&team_id=abc"), "application/x-www-form-urlencoded")
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Contains(t, w.Body.String(), "team_id=abc is not of type int")
+	})
+
+	t.Run("independently of Fuego", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("tag=a&tag=b"))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		require.Equal(t, []string{"a", "b"}, FormValues(r, "tag"))
+		ints, err := FormValuesInt(r, "other")
+		require.NoError(t, err)
+		require.Empty(t, ints)
+	})
+}
@@ -0,0 +1,205 @@
+package fuego
+
+import (
+	"net"
+	"net/http"
+	"strings"
+)
+
+// ForwardedElement is a hop of the Forwarded header (RFC 7239), set by each proxy.
+// For and By are node identifiers without port:
+// an IP address, "unknown" or an obfuscated identifier like "_hidden".
+type ForwardedElement struct {
+	For   string
+	By    string
+	Host  string
+	Proto string
+}
+
+// ParseForwardedHeader parses the Forwarded header (RFC 7239).
+// Elements are returned in order, the first one being added by the proxy closest to the client.
+// Malformed pairs are ignored.
+//
+//	Forwarded: for=192.0.2.60;proto=https;host=example.com, for="[2001:db8::1]:4711"
+func ParseForwardedHeader(header http.Header) []ForwardedElement {
+	var elements []ForwardedElement
+	for _, value := range header.Values("Forwarded") {
+		for _, hop := range splitQuoted(value, ',') {
+			var element ForwardedElement
+			for _, pair := range splitQuoted(hop, ';') {
+				key, val, ok := strings.Cut(pair, "=")
+				if !ok {
+					continue
+				}
+				val = unquoteForwarded(strings.TrimSpace(val))
+				switch strings.ToLower(strings.TrimSpace(key)) {
+				case "for":
+					element.For = forwardedNode(val)
+				case "by":
+					element.By = forwardedNode(val)
+				case "host":
+					element.Host = val
+				case "proto":
+					element.Proto = strings.ToLower(val)
+				}
+			}
+			if element != (ForwardedElement{}) {
+				elements = append(elements, element)
+			}
+		}
+	}
+	return elements
+}
+
+// ForwardedFor returns the client and proxies addresses, from the Forwarded header (RFC 7239)
+// or the X-Forwarded-For header if there is no Forwarded header.
+// The first address is the original client.
+func ForwardedFor(r *http.Request) []string {
+	var addresses []string
+	for _, element := range ParseForwardedHeader(r.Header) {
+		if element.For != "" {
+			addresses = append(addresses, element.For)
+		}
+	}
+	if len(addresses) > 0 {
+		return addresses
+	}
+
+	for _, value := range r.Header.Values("X-Forwarded-For") {
+		for address := range strings.SplitSeq(value, ",") {
+			if address = strings.TrimSpace(address); address != "" {
+				addresses = append(addresses, address)
+			}
+		}
+	}
+	return addresses
+}
+
+// RemoteIP returns the IP address of the client.
+// The Forwarded header takes precedence over the X-Forwarded-For header,
+// which takes precedence over the remote address of the connection.
+// Clients can set these headers: only rely on them behind a proxy that overwrites them.
+// The first address is chosen by the client, so do not use it for rate limiting or access control:
+// any client could change its address by sending another header.
+func RemoteIP(r *http.Request) string {
+	if addresses := ForwardedFor(r); len(addresses) > 0 {
+		return addresses[0]
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// FullURL returns the URL requested by the client, as seen before the proxies.
+// The scheme and host are taken from the Forwarded header, then the X-Forwarded-Proto
+// and X-Forwarded-Host headers, then the request itself.
+func FullURL(r *http.Request) string {
+	var scheme, host string
+	for _, element := range ParseForwardedHeader(r.Header) {
+		if scheme == "" {
+			scheme = element.Proto
+		}
+		if host == "" {
+			host = element.Host
+		}
+	}
+
+	if scheme == "" {
+		scheme = firstHeaderValue(r.Header, "X-Forwarded-Proto")
+	}
+	if scheme == "" {
+		scheme = "http"
+		if r.TLS != nil {
+			scheme = "https"
+		}
+	}
+
+	if host == "" {
+		host = firstHeaderValue(r.Header, "X-Forwarded-Host")
+	}
+	if host == "" {
+		host = r.Host
+	}
+
+	return scheme + "://" + host + r.URL.RequestURI()
+}
+
+// RemoteIP returns the IP address of the client, see [RemoteIP].
+func (c netHttpContext[B, P]) RemoteIP() string {
+	return RemoteIP(c.Req)
+}
+
+// FullURL returns the URL requested by the client, see [FullURL].
+func (c netHttpContext[B, P]) FullURL() string {
+	return FullURL(c.Req)
+}
+
+// ForwardedFor returns the client and proxies addresses, see [ForwardedFor].
+func (c netHttpContext[B, P]) ForwardedFor() []string {
+	return ForwardedFor(c.Req)
+}
+
+// firstHeaderValue returns the first element of a comma-separated header.
+func firstHeaderValue(header http.Header, key string) string {
+	value, _, _ := strings.Cut(header.Get(key), ",")
+	return strings.TrimSpace(value)
+}
+
+// forwardedNode returns the node identifier without port,
+// like "2001:db8::1" for "[2001:db8::1]:4711" or "192.0.2.43" for "192.0.2.43:47011".
+func forwardedNode(node string) string {
+	if strings.HasPrefix(node, "[") {
+		if end := strings.Index(node, "]"); end > 0 {
+			return node[1:end]
+		}
+		return node
+	}
+	if strings.Count(node, ":") == 1 {
+		node, _, _ = strings.Cut(node, ":")
+	}
+	return node
+}
+
+// unquoteForwarded removes the quotes and escapes of a quoted-string value.
+func unquoteForwarded(value string) string {
+	if len(value) < 2 || value[0] != '"' || value[len(value)-1] != '"' {
+		return value
+	}
+	value = value[1 : len(value)-1]
+
+	var unquoted strings.Builder
+	escaped := false
+	for _, r := range value {
+		if r == '\\' && !escaped {
+			escaped = true
+			continue
+		}
+		escaped = false
+		unquoted.WriteRune(r)
+	}
+	return unquoted.String()
+}
+
+// splitQuoted splits s around sep, ignoring separators inside quoted strings.
+func splitQuoted(s string, sep rune) []string {
+	var parts []string
+	inQuotes, escaped := false, false
+	start := 0
+	for i, r := range s {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\' && inQuotes:
+			escaped = true
+		case r == '"':
+			inQuotes = !inQuotes
+		case r == sep && !inQuotes:
+			parts = append(parts, strings.TrimSpace(s[start:i]))
+			start = i + 1
+		}
+	}
+	return append(parts, strings.TrimSpace(s[start:]))
+}
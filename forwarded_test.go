@@ -0,0 +1,162 @@
+package fuego
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseForwardedHeader(t *testing.T) {
+	t.Run("multi-hop", func(t *testing.T) {
+		header := http.Header{}
+		header.Add("Forwarded", `for=192.0.2.60;proto=HTTPS;host=example.com;by=203.0.113.43, for="[2001:db8:cafe::17]:4711"`)
+		header.Add("Forwarded", `For="198.51.100.17:8080";By=_hidden`)
+
+		require.Equal(t, []ForwardedElement{
+			{For: "192.0.2.60", By: "203.0.113.43", Host: "example.com", Proto: "https"},
+			{For: "2001:db8:cafe::17"},
+			{For: "198.51.100.17", By: "_hidden"},
+		}, ParseForwardedHeader(header))
+	})
+
+	t.Run("quoted values with separators", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("Forwarded", `for=unknown;host="example.com:8443";proto=https, host="a\"b;c,d"`)
+
+		require.Equal(t, []ForwardedElement{
+			{For: "unknown", Host: "example.com:8443", Proto: "https"},
+			{Host: `a"b;c,d`},
+		}, ParseForwardedHeader(header))
+	})
+
+	t.Run("malformed pairs are ignored", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("Forwarded", `for, ;;, proto=http`)
+
+		require.Equal(t, []ForwardedElement{{Proto: "http"}}, ParseForwardedHeader(header))
+	})
+
+	t.Run("no header", func(t *testing.T) {
+		require.Empty(t, ParseForwardedHeader(http.Header{}))
+	})
+}
+
+func TestForwardedFor(t *testing.T) {
+	t.Run("from Forwarded", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Forwarded", `for=192.0.2.60, for="[2001:db8::1]"`)
+
+		require.Equal(t, []string{"192.0.2.60", "2001:db8::1"}, ForwardedFor(r))
+	})
+
+	t.Run("from X-Forwarded-For", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Add("X-Forwarded-For", "192.0.2.60, 198.51.100.17")
+		r.Header.Add("X-Forwarded-For", "203.0.113.43")
+
+		require.Equal(t, []string{"192.0.2.60", "198.51.100.17", "203.0.113.43"}, ForwardedFor(r))
+	})
+
+	t.Run("Forwarded takes precedence", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Forwarded", "for=192.0.2.60")
+		r.Header.Set("X-Forwarded-For", "198.51.100.17")
+
+		require.Equal(t, []string{"192.0.2.60"}, ForwardedFor(r))
+	})
+
+	t.Run("Forwarded without for", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Forwarded", "proto=https")
+		r.Header.Set("X-Forwarded-For", "198.51.100.17")
+
+		require.Equal(t, []string{"198.51.100.17"}, ForwardedFor(r))
+	})
+}
+
+func TestRemoteIP(t *testing.T) {
+	t.Run("from the connection", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.RemoteAddr = "203.0.113.43:1234"
+
+		require.Equal(t, "203.0.113.43", RemoteIP(r))
+	})
+
+	t.Run("remote address without port", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.RemoteAddr = "203.0.113.43"
+
+		require.Equal(t, "203.0.113.43", RemoteIP(r))
+	})
+
+	t.Run("client of the first hop", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Forwarded", `for="[2001:db8::1]:4711", for=198.51.100.17`)
+		r.Header.Set("X-Forwarded-For", "192.0.2.60")
+
+		require.Equal(t, "2001:db8::1", RemoteIP(r))
+	})
+
+	t.Run("from X-Forwarded-For", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("X-Forwarded-For", "192.0.2.60, 198.51.100.17")
+
+		require.Equal(t, "192.0.2.60", RemoteIP(r))
+	})
+}
+
+func TestFullURL(t *testing.T) {
+	t.Run("from the request", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://localhost:9999/recipes?page=2", nil)
+
+		require.Equal(t, "http://localhost:9999/recipes?page=2", FullURL(r))
+	})
+
+	t.Run("TLS request", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://localhost/recipes", nil)
+		r.TLS = &tls.ConnectionState{}
+
+		require.Equal(t, "https://localhost/recipes", FullURL(r))
+	})
+
+	t.Run("from Forwarded", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://localhost/recipes?page=2", nil)
+		r.Header.Set("Forwarded", `for=192.0.2.60;proto=https;host="example.com"`)
+
+		require.Equal(t, "https://example.com/recipes?page=2", FullURL(r))
+	})
+
+	t.Run("from X-Forwarded-Proto and X-Forwarded-Host", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://localhost/recipes", nil)
+		r.Header.Set("X-Forwarded-Proto", "https")
+		r.Header.Set("X-Forwarded-Host", "example.com, proxy.internal")
+
+		require.Equal(t, "https://example.com/recipes", FullURL(r))
+	})
+
+	t.Run("Forwarded takes precedence over each X- header", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://localhost/recipes", nil)
+		r.Header.Set("Forwarded", "for=192.0.2.60;host=example.com")
+		r.Header.Set("X-Forwarded-Proto", "https")
+		r.Header.Set("X-Forwarded-Host", "other.example.com")
+
+		require.Equal(t, "https://example.com/recipes", FullURL(r))
+	})
+}
+
+func TestContextForwarded(t *testing.T) {
+	s := NewServer()
+	Get(s, "/recipes", func(c ContextNoBody) ([]string, error) {
+		return append([]string{c.RemoteIP(), c.FullURL()}, c.ForwardedFor()...), nil
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "http://localhost/recipes", nil)
+	r.Header.Set("Forwarded", "for=192.0.2.60;proto=https;host=example.com, for=198.51.100.17")
+	w := httptest.NewRecorder()
+	s.Mux.ServeHTTP(w, r)
+
+	require.JSONEq(t, `["192.0.2.60", "https://example.com/recipes", "192.0.2.60", "198.51.100.17"]`, w.Body.String())
+}
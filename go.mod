@@ -10,6 +10,7 @@ require (
 	github.com/gorilla/schema v1.4.1
 	github.com/stretchr/testify v1.10.0
 	github.com/thejerf/slogassert v0.3.4
+	golang.org/x/text v0.25.0
 	gopkg.in/yaml.v3 v3.0.1
 )
 
@@ -32,7 +33,6 @@ require (
 	golang.org/x/crypto v0.37.0 // indirect
 	golang.org/x/net v0.38.0 // indirect
 	golang.org/x/sys v0.32.0 // indirect
-	golang.org/x/text v0.25.0 // indirect
 )
 
 retract (
@@ -0,0 +1,74 @@
+package fuego
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	// grpcWebHeaderSize is the size of the gRPC-Web frame header: 1 byte of flags and 4 bytes of length.
+	grpcWebHeaderSize = 5
+	// grpcWebFlagCompressed is set when the message of the frame is compressed.
+	grpcWebFlagCompressed = 0x01
+	// grpcWebFlagTrailers is set when the frame contains trailers instead of a message.
+	grpcWebFlagTrailers = 0x80
+)
+
+// ReadGRPCWeb reads the request body as a gRPC-Web framed protobuf message ("application/grpc-web+proto").
+// The body must contain a single uncompressed message, as sent by browser gRPC-Web clients for unary calls.
+// The message is decoded with the [ProtoCodec], see [ReadProto].
+// Customizable by modifying ReadOptions.
+func ReadGRPCWeb[B any](ctx context.Context, input io.Reader) (B, error) {
+	return readGRPCWeb[B](ctx, input, ReadOptions)
+}
+
+// readGRPCWeb reads the message of a single gRPC-Web frame, then decodes it as protobuf.
+func readGRPCWeb[B any](ctx context.Context, input io.Reader, options readOptions) (B, error) {
+	var body B
+
+	message, err := readGRPCWebFrame(input)
+	if err != nil {
+		return body, BadRequestError{
+			Title:  "Decoding Failed",
+			Err:    err,
+			Detail: "cannot read gRPC-Web request body: " + err.Error(),
+		}
+	}
+
+	return readProto[B](ctx, bytes.NewReader(message), options)
+}
+
+func readGRPCWebFrame(input io.Reader) ([]byte, error) {
+	header := make([]byte, grpcWebHeaderSize)
+	if _, err := io.ReadFull(input, header); err != nil {
+		return nil, fmt.Errorf("invalid frame header: %w", err)
+	}
+
+	flags := header[0]
+	switch {
+	case flags&grpcWebFlagTrailers != 0:
+		return nil, errors.New("unexpected trailers frame")
+	case flags&grpcWebFlagCompressed != 0:
+		return nil, errors.New("compressed messages are not supported")
+	}
+
+	// The message is read up to its declared length: a large length does not allocate until the data is received.
+	length := int64(binary.BigEndian.Uint32(header[1:]))
+	message, err := io.ReadAll(io.LimitReader(input, length))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(message)) != length {
+		return nil, fmt.Errorf("truncated message: expected %d bytes, got %d", length, len(message))
+	}
+
+	if n, _ := input.Read(make([]byte, 1)); n != 0 {
+		return nil, errors.New("only a single message is supported")
+	}
+
+	return message, nil
+}
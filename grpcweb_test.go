@@ -0,0 +1,68 @@
+package fuego
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// grpcWebFrame frames the message as sent by gRPC-Web clients.
+func grpcWebFrame(flags byte, message string) []byte {
+	frame := []byte{flags, 0, 0, 0, 0}
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(message)))
+	return append(frame, message...)
+}
+
+func TestReadGRPCWeb(t *testing.T) {
+	t.Run("can read a single framed message", func(t *testing.T) {
+		body, err := readGRPCWeb[*mockProtoMessage](context.Background(), bytes.NewReader(grpcWebFrame(0, "name:fuego")), mockProtoOptions)
+		require.NoError(t, err)
+		require.Equal(t, "fuego", body.Name)
+	})
+
+	t.Run("empty message", func(t *testing.T) {
+		body, err := ReadGRPCWeb[[]byte](context.Background(), bytes.NewReader(grpcWebFrame(0, "")))
+		require.NoError(t, err)
+		require.Empty(t, body)
+	})
+
+	t.Run("invalid frames", func(t *testing.T) {
+		for name, frame := range map[string][]byte{
+			"truncated header":  {0, 0, 0},
+			"truncated message": grpcWebFrame(0, "name:fuego")[:8],
+			"compressed":        grpcWebFrame(grpcWebFlagCompressed, "name:fuego"),
+			"trailers":          grpcWebFrame(grpcWebFlagTrailers, "grpc-status: 0"),
+			"several messages":  append(grpcWebFrame(0, "name:a"), grpcWebFrame(0, "name:b")...),
+		} {
+			t.Run(name, func(t *testing.T) {
+				_, err := readGRPCWeb[*mockProtoMessage](context.Background(), bytes.NewReader(frame), mockProtoOptions)
+				require.ErrorAs(t, err, &BadRequestError{})
+			})
+		}
+	})
+
+	t.Run("decodes the body of a route", func(t *testing.T) {
+		s := NewServer(WithProtoCodec(mockProtoCodec{}))
+		Post(s, "/greet", func(c ContextWithBody[*mockProtoMessage]) (string, error) {
+			body, err := c.Body()
+			if err != nil {
+				return "", err
+			}
+			return "Hello " + body.Name, nil
+		})
+
+		r := httptest.NewRequest(http.MethodPost, "/greet", bytes.NewReader(grpcWebFrame(0, "name:fuego")))
+		r.Header.Set("Content-Type", "application/grpc-web+proto")
+		r.Header.Set("Accept", "text/plain")
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "Hello fuego", w.Body.String())
+	})
+}
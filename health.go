@@ -0,0 +1,79 @@
+package fuego
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+// Statuses of a [HealthResponse] and of its checks.
+const (
+	HealthStatusPass = "pass"
+	HealthStatusFail = "fail"
+)
+
+// HealthCheck is a named check of a dependency of the server (database, cache, external API...), see [Context.Health].
+// The check returns an error if the dependency is not healthy.
+type HealthCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// HealthResponse is the body of health and readiness endpoints, see [CheckHealth].
+type HealthResponse struct {
+	// Status is "pass" if all the checks pass, "fail" otherwise.
+	Status string              `json:"status" xml:"status" yaml:"status" example:"pass"`
+	Checks []HealthCheckResult `json:"checks" xml:"check" yaml:"checks"`
+}
+
+// HealthCheckResult is the result of a [HealthCheck].
+type HealthCheckResult struct {
+	Name   string `json:"name" xml:"name" yaml:"name" example:"database"`
+	Status string `json:"status" xml:"status" yaml:"status" example:"pass"`
+	Error  string `json:"error,omitempty" xml:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// CheckHealth runs the checks concurrently, and aggregates their results in the order of the checks.
+func CheckHealth(ctx context.Context, checks ...HealthCheck) HealthResponse {
+	response := HealthResponse{
+		Status: HealthStatusPass,
+		Checks: make([]HealthCheckResult, len(checks)),
+	}
+
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result := HealthCheckResult{Name: check.Name, Status: HealthStatusPass}
+			if err := check.Check(ctx); err != nil {
+				result.Status = HealthStatusFail
+				result.Error = err.Error()
+			}
+			response.Checks[i] = result
+		}()
+	}
+	wg.Wait()
+
+	for _, result := range response.Checks {
+		if result.Status == HealthStatusFail {
+			response.Status = HealthStatusFail
+		}
+	}
+	return response
+}
+
+// StatusCode returns the status code of the health response: 503 Service Unavailable if a check fails, 200 otherwise.
+func (response HealthResponse) StatusCode() int {
+	if response.Status == HealthStatusFail {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusOK
+}
+
+// Health runs the health checks, see [CheckHealth], and sets the status code of the response.
+func (c netHttpContext[B, P]) Health(checks ...HealthCheck) (any, error) {
+	response := CheckHealth(c.Req.Context(), checks...)
+	c.SetStatus(response.StatusCode())
+	return response, nil
+}
@@ -0,0 +1,96 @@
+package fuego
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckHealth(t *testing.T) {
+	t.Run("runs the checks concurrently and keeps their order", func(t *testing.T) {
+		slow := func(ctx context.Context) error {
+			time.Sleep(50 * time.Millisecond)
+			return nil
+		}
+
+		start := time.Now()
+		response := CheckHealth(context.Background(),
+			HealthCheck{Name: "database", Check: slow},
+			HealthCheck{Name: "cache", Check: slow},
+			HealthCheck{Name: "queue", Check: slow},
+		)
+
+		require.Less(t, time.Since(start), 140*time.Millisecond)
+		require.Equal(t, HealthResponse{
+			Status: HealthStatusPass,
+			Checks: []HealthCheckResult{
+				{Name: "database", Status: HealthStatusPass},
+				{Name: "cache", Status: HealthStatusPass},
+				{Name: "queue", Status: HealthStatusPass},
+			},
+		}, response)
+		require.Equal(t, http.StatusOK, response.StatusCode())
+	})
+
+	t.Run("without checks", func(t *testing.T) {
+		response := CheckHealth(context.Background())
+
+		require.Equal(t, HealthStatusPass, response.Status)
+		require.Empty(t, response.Checks)
+		require.Equal(t, http.StatusOK, response.StatusCode())
+	})
+}
+
+func TestContextHealth(t *testing.T) {
+	healthy := HealthCheck{Name: "database", Check: func(ctx context.Context) error { return nil }}
+	failing := HealthCheck{Name: "cache", Check: func(ctx context.Context) error { return errors.New("connection refused") }}
+
+	s := NewServer()
+	Get(s, "/health", func(c ContextNoBody) (any, error) {
+		return c.Health(healthy)
+	})
+	Get(s, "/ready", func(c ContextNoBody) (any, error) {
+		return c.Health(healthy, failing)
+	})
+
+	t.Run("all healthy", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		require.JSONEq(t, `{"status":"pass","checks":[{"name":"database","status":"pass"}]}`, w.Body.String())
+	})
+
+	t.Run("one failing", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ready", nil))
+
+		require.Equal(t, http.StatusServiceUnavailable, w.Code)
+		require.JSONEq(t, `{"status":"fail","checks":[{"name":"database","status":"pass"},{"name":"cache","status":"fail","error":"connection refused"}]}`, w.Body.String())
+	})
+
+	t.Run("negotiates the output", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/ready", nil)
+		r.Header.Set("Accept", "application/xml")
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusServiceUnavailable, w.Code)
+		require.Equal(t, "application/xml", w.Header().Get("Content-Type"))
+		require.Contains(t, w.Body.String(), "<status>fail</status>")
+		require.Contains(t, w.Body.String(), "<error>connection refused</error>")
+	})
+
+	t.Run("mock context", func(t *testing.T) {
+		response, err := NewMockContextNoBody().Health(failing)
+
+		require.NoError(t, err)
+		require.Equal(t, HealthStatusFail, response.(HealthResponse).Status)
+	})
+}
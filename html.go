@@ -2,6 +2,7 @@ package fuego
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"html/template"
 	"io"
@@ -111,9 +112,80 @@ func (s StdRenderer) Render(ctx context.Context, w io.Writer) error {
 	return err
 }
 
+// IsPartialRequest returns true if the request only expects a fragment of the page,
+// as sent by HTMX ("HX-Request: true"). Boosted HTMX requests ("HX-Boosted: true")
+// replace the whole page, so they are not partial.
+// See [RenderAuto].
+func IsPartialRequest(header http.Header) bool {
+	return header.Get("HX-Request") == "true" && header.Get("HX-Boosted") != "true"
+}
+
+// RenderCtx is the subset of [Context] needed to render a template, see [RenderAuto].
+type RenderCtx interface {
+	Request() *http.Request
+	Response() http.ResponseWriter
+	Render(templateToExecute string, data any, templateGlobsToOverride ...string) (CtxRenderer, error)
+}
+
+// RenderAuto renders [partialTemplate] for partial page requests (HTMX requests, see [IsPartialRequest]),
+// and [fullTemplate] with the given layouts otherwise.
+// Example:
+//
+//	fuego.Get(s, "/recipes", func(c fuego.ContextNoBody) (fuego.CtxRenderer, error) {
+//		recipes := ...
+//		return fuego.RenderAuto(c, "pages/recipes.page.html", "recipes-list.partial.html", recipes)
+//	})
+func RenderAuto(c RenderCtx, fullTemplate, partialTemplate string, data any, layoutsGlobs ...string) (CtxRenderer, error) {
+	AddVary(c.Response().Header(), "HX-Request")
+	if IsPartialRequest(c.Request().Header) {
+		return c.Render(partialTemplate, data)
+	}
+	return c.Render(fullTemplate, data, layoutsGlobs...)
+}
+
+// MarkdownRenderer converts Markdown to HTML.
+// Implementations MUST sanitize the output, as the returned HTML is not escaped by templates:
+// user content rendered without sanitization is an XSS vector.
+// See [github.com/go-fuego/fuego/extra/markdown] for an implementation.
+type MarkdownRenderer interface {
+	RenderMarkdown(md string) template.HTML
+}
+
+// WithMarkdownRenderer sets the renderer used by [Context.RenderMarkdown]
+// and by the "markdown" template function, available in templates loaded with [WithTemplateGlobs]:
+//
+//	<article>{{ markdown .Content }}</article>
+func WithMarkdownRenderer(renderer MarkdownRenderer) func(*Server) {
+	return func(s *Server) { s.markdownRenderer = renderer }
+}
+
+// templateFuncs returns the functions available in templates loaded by the server.
+// They are resolved at execution time, so the server options can be given in any order.
+func (s *Server) templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"markdown": func(md string) (template.HTML, error) {
+			if s.markdownRenderer == nil {
+				return "", errors.New("no markdown renderer configured, please use fuego.WithMarkdownRenderer")
+			}
+			return s.markdownRenderer.RenderMarkdown(md), nil
+		},
+		// Overridden for each request, see requestTemplateFuncs.
+		"featureEnabled": func(string) bool { return false },
+	}
+}
+
+// renderMarkdown renders Markdown with the given renderer.
+// Without renderer, the content is escaped and returned as is.
+func renderMarkdown(renderer MarkdownRenderer, md string) template.HTML {
+	if renderer == nil {
+		return template.HTML(template.HTMLEscapeString(md)) // #nosec G203 (escaped)
+	}
+	return renderer.RenderMarkdown(md)
+}
+
 // loadTemplates
 func (s *Server) loadTemplates(patterns ...string) error {
-	tmpl, err := template.ParseFS(s.fs, patterns...)
+	tmpl, err := template.New("").Funcs(s.templateFuncs()).ParseFS(s.fs, patterns...)
 	if err != nil {
 		return fmt.Errorf("failed to parse templates: %w", err)
 	}
@@ -2,8 +2,10 @@ package fuego
 
 import (
 	"embed"
+	"html/template"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -136,3 +138,118 @@ func TestServer_loadTemplates(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+// stubMarkdownRenderer renders **bold** text and drops <script> tags.
+type stubMarkdownRenderer struct{}
+
+func (stubMarkdownRenderer) RenderMarkdown(md string) template.HTML {
+	md = strings.ReplaceAll(md, "<script>", "")
+	md = strings.ReplaceAll(md, "</script>", "")
+	bold := strings.Split(md, "**")
+	for i := 1; i < len(bold); i += 2 {
+		bold[i] = "<strong>" + bold[i] + "</strong>"
+	}
+	return template.HTML("<p>" + strings.Join(bold, "") + "</p>") // #nosec G203
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	t.Run("template function", func(t *testing.T) {
+		s := NewServer(
+			WithTemplateFS(testdata),
+			WithTemplateGlobs("testdata/*.html"),
+			WithMarkdownRenderer(stubMarkdownRenderer{}), // can be set after the templates
+		)
+
+		Get(s, "/markdown", func(ctx ContextNoBody) (CtxRenderer, error) {
+			return ctx.Render("markdown.html", H{"Content": "Just **testing** <script>alert(1)</script>"})
+		})
+
+		r := httptest.NewRequest(http.MethodGet, "/markdown", nil)
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "<article><p>Just <strong>testing</strong> alert(1)</p></article>\n", w.Body.String())
+	})
+
+	t.Run("template function without renderer", func(t *testing.T) {
+		s := NewServer(
+			WithTemplateFS(testdata),
+			WithTemplateGlobs("testdata/*.html"),
+		)
+
+		Get(s, "/markdown", func(ctx ContextNoBody) (CtxRenderer, error) {
+			return ctx.Render("markdown.html", H{"Content": "Just **testing**"})
+		})
+
+		r := httptest.NewRequest(http.MethodGet, "/markdown", nil)
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		// The template execution fails after the beginning of the response has been written
+		require.NotContains(t, w.Body.String(), "testing")
+	})
+
+	t.Run("context helper", func(t *testing.T) {
+		s := NewServer(
+			WithMarkdownRenderer(stubMarkdownRenderer{}),
+		)
+
+		Get(s, "/markdown", func(ctx ContextNoBody) (HTML, error) {
+			return HTML(ctx.RenderMarkdown("Just **testing**")), nil
+		})
+
+		r := httptest.NewRequest(http.MethodGet, "/markdown", nil)
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, "<p>Just <strong>testing</strong></p>", w.Body.String())
+	})
+
+	t.Run("context helper without renderer escapes the content", func(t *testing.T) {
+		c := NewNetHTTPContext[any, any](BaseRoute{}, httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil), readOptions{})
+
+		require.Equal(t, template.HTML("Hello &lt;script&gt;alert(1)&lt;/script&gt;"), c.RenderMarkdown("Hello <script>alert(1)</script>"))
+	})
+}
+
+func TestRenderAuto(t *testing.T) {
+	s := NewServer(
+		WithTemplateFS(testdata),
+		WithTemplateGlobs("testdata/*.html"),
+	)
+	Get(s, "/recipes", func(c ContextNoBody) (CtxRenderer, error) {
+		return RenderAuto(c, "recipes.page.html", "recipes.partial.html", []string{"pizza", "pasta"})
+	})
+
+	t.Run("full page for a browser request", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/recipes", nil)
+		r.Header.Set("Accept", "text/html")
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "<html><body><ul><li>pizza</li><li>pasta</li></ul>\n</body></html>\n", w.Body.String())
+		require.Equal(t, "HX-Request, Accept", w.Header().Get("Vary"))
+	})
+
+	t.Run("partial for an HTMX request", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/recipes", nil)
+		r.Header.Set("HX-Request", "true")
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "<ul><li>pizza</li><li>pasta</li></ul>\n", w.Body.String())
+	})
+
+	t.Run("full page for a boosted HTMX request", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/recipes", nil)
+		r.Header.Set("HX-Request", "true")
+		r.Header.Set("HX-Boosted", "true")
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.Contains(t, w.Body.String(), "<html>")
+	})
+}
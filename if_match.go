@@ -0,0 +1,74 @@
+package fuego
+
+import (
+	"net/http"
+	"strings"
+)
+
+// IfMatch reports whether the If-Match header (RFC 9110) matches the given ETag, with a strong comparison:
+// weak ETags (W/"...") never match. "*" matches any existing resource, that is a non-empty ETag.
+// The ETag can be given with or without its surrounding quotes.
+// It returns true when the header is absent, as there is no precondition.
+//
+//	If-Match: "v1", "v2"
+//	-> true for "v2" or `"v2"`, false for "v3"
+func IfMatch(header http.Header, etag string) bool {
+	values := header.Values("If-Match")
+	if len(values) == 0 {
+		return true
+	}
+
+	etag = quoteETag(etag)
+	for _, line := range values {
+		for candidate := range strings.SplitSeq(line, ",") {
+			candidate = strings.TrimSpace(candidate)
+			switch {
+			case candidate == "*":
+				if etag != "" {
+					return true
+				}
+			case strings.HasPrefix(candidate, "W/"):
+				continue
+			case etag != "" && candidate == etag:
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RequireIfMatch returns a 412 [PreconditionFailedError] if the If-Match header is absent
+// or does not match the current ETag of the resource, see [IfMatch].
+func RequireIfMatch(header http.Header, current string) error {
+	if header.Get("If-Match") == "" {
+		return PreconditionFailedError{
+			Title:  "Precondition Failed",
+			Detail: "the If-Match header is required to update this resource",
+		}
+	}
+	if !IfMatch(header, current) {
+		return PreconditionFailedError{
+			Title:  "Precondition Failed",
+			Detail: "the resource has been modified: its current ETag does not match the If-Match header",
+		}
+	}
+	return nil
+}
+
+// quoteETag adds the quotes of an entity-tag, if missing.
+func quoteETag(etag string) string {
+	if etag == "" || strings.HasSuffix(etag, `"`) {
+		return etag
+	}
+	return `"` + etag + `"`
+}
+
+// IfMatch reports whether the If-Match header of the request matches the given ETag, see [IfMatch].
+func (c netHttpContext[B, P]) IfMatch(etag string) bool {
+	return IfMatch(c.Req.Header, etag)
+}
+
+// RequireIfMatch checks the If-Match header of the request against the current ETag, see [RequireIfMatch].
+func (c netHttpContext[B, P]) RequireIfMatch(current string) error {
+	return RequireIfMatch(c.Req.Header, current)
+}
@@ -0,0 +1,95 @@
+package fuego
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIfMatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		ifMatch  []string
+		etag     string
+		expected bool
+	}{
+		{name: "no header", etag: `"v1"`, expected: true},
+		{name: "match", ifMatch: []string{`"v1"`}, etag: `"v1"`, expected: true},
+		{name: "match without quotes", ifMatch: []string{`"v1"`}, etag: "v1", expected: true},
+		{name: "match in list", ifMatch: []string{`"v0", "v1"`}, etag: `"v1"`, expected: true},
+		{name: "match in several headers", ifMatch: []string{`"v0"`, `"v1"`}, etag: `"v1"`, expected: true},
+		{name: "mismatch", ifMatch: []string{`"v0"`}, etag: `"v1"`, expected: false},
+		{name: "weak etags never match", ifMatch: []string{`W/"v1"`}, etag: `"v1"`, expected: false},
+		{name: "wildcard matches an existing resource", ifMatch: []string{"*"}, etag: `"v1"`, expected: true},
+		{name: "wildcard does not match a missing resource", ifMatch: []string{"*"}, etag: "", expected: false},
+		{name: "no current etag", ifMatch: []string{`"v1"`}, etag: "", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			for _, value := range tt.ifMatch {
+				header.Add("If-Match", value)
+			}
+			require.Equal(t, tt.expected, IfMatch(header, tt.etag))
+		})
+	}
+}
+
+func TestContext_RequireIfMatch(t *testing.T) {
+	s := NewServer()
+	Put(s, "/recipes/1", func(c ContextNoBody) (string, error) {
+		if err := c.RequireIfMatch(`"v2"`); err != nil {
+			return "", err
+		}
+		return "updated", nil
+	})
+
+	put := func(ifMatch string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPut, "/recipes/1", nil)
+		if ifMatch != "" {
+			r.Header.Set("If-Match", ifMatch)
+		}
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("match", func(t *testing.T) {
+		w := put(`"v2"`)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, w.Body.String(), "updated")
+	})
+
+	t.Run("wildcard", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, put("*").Code)
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		w := put(`"v1"`)
+		require.Equal(t, http.StatusPreconditionFailed, w.Code)
+		require.Contains(t, w.Body.String(), "has been modified")
+	})
+
+	t.Run("missing header", func(t *testing.T) {
+		w := put("")
+		require.Equal(t, http.StatusPreconditionFailed, w.Code)
+		require.Contains(t, w.Body.String(), "If-Match header is required")
+	})
+
+	t.Run("typed error", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPut, "/", nil)
+		r.Header.Set("If-Match", `"v1"`)
+		c := NewNetHTTPContext[any, any](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+
+		require.True(t, c.IfMatch(`"v1"`))
+		require.NoError(t, c.RequireIfMatch(`"v1"`))
+
+		err := c.RequireIfMatch(`"v2"`)
+		var preconditionErr PreconditionFailedError
+		require.ErrorAs(t, err, &preconditionErr)
+		require.Equal(t, http.StatusPreconditionFailed, preconditionErr.StatusCode())
+	})
+}
@@ -4,6 +4,7 @@
 package internal
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"log/slog"
@@ -12,6 +13,7 @@ import (
 	"net/url"
 	"slices"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -56,6 +58,15 @@ func (c CommonContext[B]) GetOpenAPIParams() map[string]OpenAPIParam {
 	return c.OpenAPIParams
 }
 
+// ParamsSpec returns the parameters declared for the route, sorted by location then name.
+func (c CommonContext[B]) ParamsSpec() []OpenAPIParam {
+	params := slices.Collect(maps.Values(c.OpenAPIParams))
+	slices.SortFunc(params, func(a, b OpenAPIParam) int {
+		return cmp.Or(cmp.Compare(a.Type, b.Type), cmp.Compare(a.Name, b.Name))
+	})
+	return params
+}
+
 func (c CommonContext[B]) Context() context.Context {
 	return c.CommonCtx
 }
@@ -85,6 +96,17 @@ func (c CommonContext[B]) QueryParams() url.Values {
 	return c.UrlValues
 }
 
+// QueryParamsSorted returns a canonical form of the query parameters:
+// keys and values are sorted and re-encoded, so two requests carrying
+// the same parameters in a different order produce the same string.
+func (c CommonContext[B]) QueryParamsSorted() string {
+	sorted := make(url.Values, len(c.UrlValues))
+	for key, values := range c.UrlValues {
+		sorted[key] = slices.Sorted(slices.Values(values))
+	}
+	return sorted.Encode() // Encode sorts by key
+}
+
 // HasQueryParam returns true if the query parameter with the given name exists.
 func (c CommonContext[B]) HasQueryParam(name string) bool {
 	_, ok := c.UrlValues[name]
@@ -238,3 +260,20 @@ func (c CommonContext[B]) QueryParamBool(name string) bool {
 	param, _ := c.QueryParamBoolErr(name)
 	return param
 }
+
+// QueryParamBoolLenient returns the query parameter with the given name as a bool,
+// accepting the spellings of HTML forms and query strings, case-insensitively:
+// "1", "true", "t", "yes", "y", "on" for true and "0", "false", "f", "no", "n", "off" for false.
+// If the query parameter does not exist or is not one of them, it returns def.
+// Example:
+//
+//	c.QueryParamBoolLenient("active", false) // ?active=yes -> true, ?active=OFF -> false, ?active=maybe -> false
+func (c CommonContext[B]) QueryParamBoolLenient(name string, def bool) bool {
+	switch strings.ToLower(strings.TrimSpace(c.QueryParam(name))) {
+	case "1", "true", "t", "yes", "y", "on":
+		return true
+	case "0", "false", "f", "no", "n", "off":
+		return false
+	}
+	return def
+}
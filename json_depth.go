@@ -0,0 +1,60 @@
+package fuego
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// errJSONTooDeep is returned by [jsonDepthReader] when the JSON document is nested deeper than allowed.
+var errJSONTooDeep = errors.New("JSON document exceeds the maximum nesting depth")
+
+// WithMaxJSONDepth rejects the JSON request bodies nested deeper than the given depth (objects and arrays)
+// with a 400 [BadRequestError], before they are decoded. It mitigates stack and memory exhaustion
+// from maliciously nested payloads, complementing [WithMaxBodySize].
+// Defaults to 0 (no limit). For example, with a depth of 2, {"a":[1]} is accepted but {"a":[[1]]} is rejected.
+func WithMaxJSONDepth(depth int) func(*Server) {
+	return func(s *Server) { s.maxJSONDepth = depth }
+}
+
+// jsonDepthReader tracks the nesting depth of the JSON document read through it,
+// failing with [errJSONTooDeep] as soon as the maximum depth is exceeded.
+// Brackets inside strings are ignored.
+type jsonDepthReader struct {
+	r        io.Reader
+	maxDepth int
+	depth    int
+	inString bool
+	escaped  bool
+}
+
+func newJSONDepthReader(r io.Reader, maxDepth int) *jsonDepthReader {
+	return &jsonDepthReader{r: r, maxDepth: maxDepth}
+}
+
+func (r *jsonDepthReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	for _, b := range p[:n] {
+		switch {
+		case r.escaped:
+			r.escaped = false
+		case r.inString:
+			switch b {
+			case '\\':
+				r.escaped = true
+			case '"':
+				r.inString = false
+			}
+		case b == '"':
+			r.inString = true
+		case b == '{' || b == '[':
+			r.depth++
+			if r.depth > r.maxDepth {
+				return 0, fmt.Errorf("%w of %d", errJSONTooDeep, r.maxDepth)
+			}
+		case b == '}' || b == ']':
+			r.depth--
+		}
+	}
+	return n, err
+}
@@ -0,0 +1,71 @@
+package fuego
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// nestedJSON returns a JSON document with the given number of nested arrays, like [[[1]]].
+func nestedJSON(depth int) string {
+	return strings.Repeat("[", depth) + "1" + strings.Repeat("]", depth)
+}
+
+func TestMaxJSONDepth(t *testing.T) {
+	options := readOptions{MaxJSONDepth: 10}
+
+	t.Run("at the limit", func(t *testing.T) {
+		_, err := readJSON[any](context.Background(), strings.NewReader(nestedJSON(10)), options)
+		require.NoError(t, err)
+	})
+
+	t.Run("just over the limit", func(t *testing.T) {
+		_, err := readJSON[any](context.Background(), strings.NewReader(nestedJSON(11)), options)
+
+		var badRequest BadRequestError
+		require.ErrorAs(t, err, &badRequest)
+		require.Equal(t, "JSON Too Deep", badRequest.Title)
+		require.ErrorIs(t, err, errJSONTooDeep)
+	})
+
+	t.Run("objects and arrays are counted", func(t *testing.T) {
+		body := strings.Repeat(`{"a":[`, 5) + "1" + strings.Repeat("]}", 5)
+		_, err := readJSON[any](context.Background(), strings.NewReader(body), options)
+		require.NoError(t, err)
+
+		body = strings.Repeat(`{"a":[`, 5) + "{}" + strings.Repeat("]}", 5)
+		_, err = readJSON[any](context.Background(), strings.NewReader(body), options)
+		require.ErrorIs(t, err, errJSONTooDeep)
+	})
+
+	t.Run("brackets in strings are ignored", func(t *testing.T) {
+		body := `{"name":"[[[[[[[[[[[[\"{{{{{{{{{{{{"}`
+		recipe, err := readJSON[map[string]string](context.Background(), strings.NewReader(body), options)
+		require.NoError(t, err)
+		require.Equal(t, `[[[[[[[[[[[["{{{{{{{{{{{{`, recipe["name"])
+	})
+
+	t.Run("no limit by default", func(t *testing.T) {
+		_, err := readJSON[any](context.Background(), strings.NewReader(nestedJSON(1000)), readOptions{})
+		require.NoError(t, err)
+	})
+
+	t.Run("with server option", func(t *testing.T) {
+		s := NewServer(WithMaxJSONDepth(3))
+		Post(s, "/", func(c ContextWithBody[any]) (any, error) {
+			return c.Body()
+		})
+
+		for depth, status := range map[int]int{3: http.StatusOK, 4: http.StatusBadRequest} {
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(nestedJSON(depth)))
+			r.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			s.Mux.ServeHTTP(w, r)
+			require.Equal(t, status, w.Code, w.Body.String())
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package fuego
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// WithDecimalJSONFloats sends the numbers of the JSON responses in decimal notation:
+// encoding/json uses the scientific notation for very large and very small floats,
+// like 1e+21 or 1e-07, that some clients mishandle, for example for financial data.
+// With this option, they are sent as 1000000000000000000000 and 0.0000001. Strings are not modified.
+// To change the notation of some fields only, use [DecimalFloat].
+func WithDecimalJSONFloats() func(*Server) {
+	return func(s *Server) { s.decimalJSONFloats = true }
+}
+
+// DecimalFloat is a float64 always serialized to JSON in decimal notation, never in scientific notation.
+//
+//	type Account struct {
+//		Balance fuego.DecimalFloat `json:"balance"` // 1e21 -> 1000000000000000000000
+//	}
+type DecimalFloat float64
+
+// MarshalJSON encodes the float in decimal notation, with the minimal number of digits to represent it exactly.
+func (f DecimalFloat) MarshalJSON() ([]byte, error) {
+	if math.IsNaN(float64(f)) || math.IsInf(float64(f), 0) {
+		return nil, fmt.Errorf("unsupported float value: %v", float64(f))
+	}
+	return strconv.AppendFloat(nil, float64(f), 'f', -1, 64), nil
+}
+
+// decimalJSONNumbers rewrites the numbers in scientific notation of a valid JSON document in decimal notation.
+func decimalJSONNumbers(data []byte) []byte {
+	if !bytes.ContainsAny(data, "eE") {
+		return data
+	}
+
+	out := make([]byte, 0, len(data)+16)
+	inString := false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case inString:
+			out = append(out, c)
+			if c == '\\' && i+1 < len(data) {
+				i++
+				out = append(out, data[i])
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+			out = append(out, c)
+		case c == '-' || (c >= '0' && c <= '9'):
+			end := i
+			for end < len(data) && bytes.IndexByte([]byte("0123456789+-.eE"), data[end]) >= 0 {
+				end++
+			}
+			number := data[i:end]
+			if f, err := strconv.ParseFloat(string(number), 64); err == nil && bytes.ContainsAny(number, "eE") {
+				out = strconv.AppendFloat(out, f, 'f', -1, 64)
+			} else {
+				out = append(out, number...)
+			}
+			i = end - 1
+		default:
+			out = append(out, c)
+		}
+	}
+	return out
+}
@@ -0,0 +1,78 @@
+package fuego
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecimalJSONNumbers(t *testing.T) {
+	for input, expected := range map[string]string{
+		`1000000`:                  `1000000`,
+		`1e+21`:                    `1000000000000000000000`,
+		`-1.5e-07`:                 `-0.00000015`,
+		`[1e-7,2.5E+22,3]`:         `[0.0000001,25000000000000000000000,3]`,
+		`{"price":1.23e+21,"n":0}`: `{"price":1230000000000000000000,"n":0}`,
+		`{"e":"1e+21","k":1e21}`:   `{"e":"1e+21","k":1000000000000000000000}`,
+		`["\"1e+21\"",1e21]`:       `["\"1e+21\"",1000000000000000000000]`,
+		`{"key":true,"else":null}`: `{"key":true,"else":null}`,
+	} {
+		require.Equal(t, expected, string(decimalJSONNumbers([]byte(input))), input)
+	}
+}
+
+func TestDecimalFloat(t *testing.T) {
+	data, err := json.Marshal(map[string]DecimalFloat{"a": 1000000.0, "b": 1e21, "c": 1e-7})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"a":1000000,"b":1000000000000000000000,"c":0.0000001}`, string(data))
+	require.Contains(t, string(data), `"b":1000000000000000000000`)
+
+	_, err = json.Marshal(DecimalFloat(math.Inf(1)))
+	require.Error(t, err)
+}
+
+func TestWithDecimalJSONFloats(t *testing.T) {
+	type measure struct {
+		Value float64 `json:"value"`
+		Label string  `json:"label"`
+	}
+	measures := []measure{{1000000.0, "million"}, {1e21, "1e+21"}, {0.00000001, "tiny"}}
+
+	t.Run("default encoding/json notation", func(t *testing.T) {
+		s := NewServer()
+		Get(s, "/measures", func(c ContextNoBody) ([]measure, error) { return measures, nil })
+
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/measures", nil))
+		require.Contains(t, w.Body.String(), `"value":1e+21`)
+	})
+
+	t.Run("decimal notation", func(t *testing.T) {
+		s := NewServer(WithDecimalJSONFloats())
+		Get(s, "/measures", func(c ContextNoBody) ([]measure, error) { return measures, nil })
+
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/measures", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, `[{"value":1000000,"label":"million"},{"value":1000000000000000000000,"label":"1e+21"},{"value":0.00000001,"label":"tiny"}]`+"\n", w.Body.String())
+		require.NotContains(t, w.Body.String(), "1e+06")
+	})
+
+	t.Run("errors in decimal notation", func(t *testing.T) {
+		s := NewServer(WithDecimalJSONFloats())
+		Get(s, "/error", func(c ContextNoBody) (any, error) {
+			return nil, HTTPError{Status: http.StatusBadRequest, Detail: "too large", Errors: []ErrorItem{{Name: "value", More: map[string]any{"max": 1e21}}}}
+		})
+
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/error", nil))
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Contains(t, w.Body.String(), `"max":1000000000000000000000`)
+	})
+}
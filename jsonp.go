@@ -0,0 +1,49 @@
+package fuego
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+)
+
+// jsonpCallbackPattern matches the JavaScript identifiers, optionally dotted, like "callback" or "jQuery.handlers.done".
+// Anything else could inject a script in the page of the client.
+var jsonpCallbackPattern = regexp.MustCompile(`^[a-zA-Z_$][a-zA-Z0-9_$]{0,63}(\.[a-zA-Z_$][a-zA-Z0-9_$]{0,63}){0,7}$`)
+
+// WithJSONP enables JSONP responses, for legacy browser clients loading the API with a <script> tag:
+// when the query parameter (default "callback") is set, successful responses are sent as JSON
+// wrapped in a call to the given function, with the Content-Type "application/javascript".
+// The callback must be a JavaScript identifier, otherwise the request is rejected with a 400 [BadRequestError].
+// Errors are sent as usual. Only enable it for public data: any website can read JSONP responses.
+//
+//	s := fuego.NewServer(fuego.WithJSONP(""))
+//	// GET /recipes?callback=showRecipes -> /**/showRecipes([{"name":"pizza"}]);
+func WithJSONP(param string) func(*Server) {
+	return func(s *Server) { s.jsonpParam = cmp.Or(param, "callback") }
+}
+
+// SendJSONP sends the response as JSON wrapped in a call to the callback function, see [WithJSONP].
+// It returns a [BadRequestError] if the callback is not a valid JavaScript identifier, without writing the response.
+func SendJSONP(w http.ResponseWriter, r *http.Request, callback string, ans any) error {
+	if !jsonpCallbackPattern.MatchString(callback) {
+		return BadRequestError{
+			Title:  "Invalid Callback",
+			Detail: fmt.Sprintf("the JSONP callback %q must be a JavaScript identifier", callback),
+		}
+	}
+
+	data, err := json.Marshal(ans)
+	if err != nil {
+		return NotAcceptableError{Err: err, Detail: fmt.Sprintf("Cannot serialize type %T to JSON", ans)}
+	}
+
+	header := w.Header()
+	header.Set("Content-Type", "application/javascript; charset=utf-8")
+	header.Set("X-Content-Type-Options", "nosniff")
+	AddVary(header, "Accept")
+	// The leading comment prevents the response from being interpreted as a Flash file.
+	_, err = fmt.Fprintf(w, "/**/%s(%s);", callback, data)
+	return err
+}
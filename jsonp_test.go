@@ -0,0 +1,66 @@
+package fuego
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONP(t *testing.T) {
+	s := NewServer(WithJSONP(""))
+	Get(s, "/recipes", func(c ContextNoBody) ([]string, error) {
+		return []string{"pizza", "</script>"}, nil
+	})
+
+	get := func(t *testing.T, path string) *httptest.ResponseRecorder {
+		t.Helper()
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("valid callback", func(t *testing.T) {
+		for _, callback := range []string{"showRecipes", "jQuery123_$.handlers.done"} {
+			w := get(t, "/recipes?callback="+callback)
+
+			require.Equal(t, http.StatusOK, w.Code)
+			require.Equal(t, "application/javascript; charset=utf-8", w.Header().Get("Content-Type"))
+			require.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+			require.Equal(t, `/**/`+callback+`(["pizza","\u003c/script\u003e"]);`, w.Body.String())
+		}
+	})
+
+	t.Run("invalid callback", func(t *testing.T) {
+		for _, callback := range []string{"alert(1)//", "a;b", "1abc", "a..b", "<script>"} {
+			w := get(t, "/recipes?callback="+url.QueryEscape(callback))
+
+			require.Equal(t, http.StatusBadRequest, w.Code, callback)
+			require.Contains(t, w.Body.String(), "Invalid Callback", callback)
+			require.NotContains(t, w.Header().Get("Content-Type"), "javascript", callback)
+		}
+	})
+
+	t.Run("no callback", func(t *testing.T) {
+		w := get(t, "/recipes")
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		require.JSONEq(t, `["pizza","</script>"]`, w.Body.String())
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		s := NewServer()
+		Get(s, "/recipes", func(c ContextNoBody) ([]string, error) {
+			return []string{"pizza"}, nil
+		})
+		r := httptest.NewRequest(http.MethodGet, "/recipes?callback=fn", nil)
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	})
+}
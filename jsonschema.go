@@ -0,0 +1,165 @@
+package fuego
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// JSONSchemaViolation describes why a JSON document does not match a schema.
+type JSONSchemaViolation struct {
+	// JSON Pointer (RFC 6901) to the invalid value, for example "/items/0/price".
+	// Empty for the root of the document.
+	Pointer string
+	// Human readable reason of the violation.
+	Message string
+}
+
+// JSONSchemaValidator validates a raw JSON request body, before it is deserialized.
+// The schema library is up to the implementation: [OpenAPISchemaValidator] is provided
+// out of the box, and any JSON Schema library can be plugged by implementing this interface.
+type JSONSchemaValidator interface {
+	// ValidateJSON returns the violations found in the document, or nil if it is valid.
+	ValidateJSON(document []byte) []JSONSchemaViolation
+}
+
+// OptionJSONSchema validates the raw JSON request body of the route against the given schema,
+// before decoding it into the body type. The request is rejected with a 422 listing
+// every violation, catching issues Go types cannot express (min/max, patterns...).
+// Only JSON bodies are validated.
+//
+//	fuego.Post(s, "/recipes", createRecipe,
+//		option.JSONSchema(fuego.OpenAPISchemaValidator{Schema: recipeSchema}),
+//	)
+func OptionJSONSchema(validator JSONSchemaValidator) func(*BaseRoute) {
+	return func(r *BaseRoute) {
+		r.JSONSchema = validator
+	}
+}
+
+// OpenAPISchemaValidator is a [JSONSchemaValidator] based on OpenAPI 3 schemas.
+type OpenAPISchemaValidator struct {
+	Schema *openapi3.Schema
+}
+
+var _ JSONSchemaValidator = OpenAPISchemaValidator{}
+
+// ValidateJSON validates the document against the OpenAPI schema.
+// Documents that are not valid JSON are not reported, as they will be rejected when decoded.
+func (v OpenAPISchemaValidator) ValidateJSON(document []byte) []JSONSchemaViolation {
+	var value any
+	if err := json.Unmarshal(document, &value); err != nil {
+		return nil
+	}
+
+	err := v.Schema.VisitJSON(value, openapi3.MultiErrors())
+	if err == nil {
+		return nil
+	}
+
+	var violations []JSONSchemaViolation
+	for _, err := range flattenSchemaErrors(err) {
+		violation := JSONSchemaViolation{Message: err.Error()}
+		var schemaErr *openapi3.SchemaError
+		if errors.As(err, &schemaErr) {
+			violation.Message = schemaErr.Reason
+			if pointer := schemaErr.JSONPointer(); len(pointer) > 0 {
+				violation.Pointer = "/" + strings.Join(pointer, "/")
+			}
+		}
+		violations = append(violations, violation)
+	}
+	return violations
+}
+
+func flattenSchemaErrors(err error) []error {
+	var multiErr openapi3.MultiError
+	if !errors.As(err, &multiErr) {
+		return []error{err}
+	}
+
+	var flattened []error
+	for _, err := range multiErr {
+		flattened = append(flattened, flattenSchemaErrors(err)...)
+	}
+	return flattened
+}
+
+// readJSONWithSchema validates the raw request body against options.JSONSchema,
+// then deserializes it as JSON.
+func readJSONWithSchema[B any](r *http.Request, options readOptions) (B, error) {
+	var body B
+	// Rejects deeply nested documents before they are decoded by the unwrapping and the validation.
+	var input io.Reader = r.Body
+	if options.MaxJSONDepth > 0 {
+		input = newJSONDepthReader(input, options.MaxJSONDepth)
+		options.MaxJSONDepth = 0
+	}
+	document, err := io.ReadAll(input)
+	if errors.Is(err, errJSONTooDeep) {
+		return body, BadRequestError{
+			Title:  "JSON Too Deep",
+			Err:    err,
+			Detail: "cannot decode request body: " + err.Error(),
+		}
+	}
+	if err != nil {
+		return body, BadRequestError{
+			Err:    err,
+			Detail: "cannot read request body: " + err.Error(),
+		}
+	}
+
+	if options.ValidateUTF8 && !utf8.Valid(document) {
+		return body, invalidUTF8Error(errInvalidUTF8)
+	}
+
+	// The schema describes the body type, not the envelope.
+	if options.BodyUnwrapKey != "" {
+		document, err = unwrapJSONBody(bytes.NewReader(document), options.BodyUnwrapKey)
+		if err != nil {
+			return body, err
+		}
+		options.BodyUnwrapKey = ""
+	}
+
+	if err := validateJSONSchema(options.JSONSchema, document); err != nil {
+		return body, err
+	}
+
+	return readJSON[B](r.Context(), bytes.NewReader(document), options)
+}
+
+// validateJSONSchema returns a 422 [HTTPError] if the document does not match the schema.
+func validateJSONSchema(validator JSONSchemaValidator, document []byte) error {
+	violations := validator.ValidateJSON(document)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	validationError := HTTPError{
+		Err:    errors.New("request body does not match the JSON schema"),
+		Status: http.StatusUnprocessableEntity,
+		Title:  "Schema Validation Error",
+	}
+	var errorsSummary []string
+	for _, violation := range violations {
+		errorsSummary = append(errorsSummary, violation.Pointer+": "+violation.Message)
+		validationError.Errors = append(validationError.Errors, ErrorItem{
+			Name:   violation.Pointer,
+			Reason: violation.Message,
+			More: map[string]any{
+				"pointer": violation.Pointer,
+			},
+		})
+	}
+	validationError.Detail = strings.Join(errorsSummary, ", ")
+
+	return validationError
+}
@@ -0,0 +1,112 @@
+package fuego
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+)
+
+func testStructSchema() *openapi3.Schema {
+	return openapi3.NewObjectSchema().
+		WithProperty("name", openapi3.NewStringSchema().WithMinLength(2)).
+		WithProperty("age", openapi3.NewIntegerSchema().WithMin(0).WithMax(150)).
+		WithRequired([]string{"name"})
+}
+
+func TestOpenAPISchemaValidator(t *testing.T) {
+	validator := OpenAPISchemaValidator{Schema: testStructSchema()}
+
+	t.Run("valid document", func(t *testing.T) {
+		require.Empty(t, validator.ValidateJSON([]byte(`{"name":"John","age":30}`)))
+	})
+
+	t.Run("reports every violation with its pointer", func(t *testing.T) {
+		violations := validator.ValidateJSON([]byte(`{"name":"J","age":200}`))
+		require.Len(t, violations, 2)
+
+		pointers := []string{violations[0].Pointer, violations[1].Pointer}
+		require.ElementsMatch(t, []string{"/name", "/age"}, pointers)
+		for _, violation := range violations {
+			require.NotEmpty(t, violation.Message)
+		}
+	})
+
+	t.Run("missing required property", func(t *testing.T) {
+		violations := validator.ValidateJSON([]byte(`{"age":30}`))
+		require.Len(t, violations, 1)
+		require.Contains(t, violations[0].Message, "name")
+	})
+
+	t.Run("invalid JSON is left to the decoder", func(t *testing.T) {
+		require.Empty(t, validator.ValidateJSON([]byte(`{"name":`)))
+	})
+}
+
+func TestOptionJSONSchema(t *testing.T) {
+	s := NewServer()
+	Post(s, "/people", func(c ContextWithBody[testStruct]) (string, error) {
+		body, err := c.Body()
+		return body.Name, err
+	}, OptionJSONSchema(OpenAPISchemaValidator{Schema: testStructSchema()}))
+
+	t.Run("valid body is deserialized", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/people", strings.NewReader(`{"name":"John","age":30}`))
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "John", strings.TrimSpace(w.Body.String()))
+	})
+
+	t.Run("invalid body is rejected with a 422", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/people", strings.NewReader(`{"name":"John","age":200}`))
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+		var httpErr HTTPError
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &httpErr))
+		require.Equal(t, "Schema Validation Error", httpErr.Title)
+		require.Len(t, httpErr.Errors, 1)
+		require.Equal(t, "/age", httpErr.Errors[0].Name)
+		require.Equal(t, "/age", httpErr.Errors[0].More["pointer"])
+	})
+
+	t.Run("too deep JSON is rejected before validation", func(t *testing.T) {
+		s := NewServer(WithMaxJSONDepth(3))
+		Post(s, "/people", func(c ContextWithBody[testStruct]) (string, error) {
+			body, err := c.Body()
+			return body.Name, err
+		}, OptionJSONSchema(OpenAPISchemaValidator{Schema: testStructSchema()}))
+
+		r := httptest.NewRequest(http.MethodPost, "/people", strings.NewReader(`{"name":"John","age":`+nestedJSON(10)+`}`))
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Contains(t, w.Body.String(), "JSON Too Deep")
+	})
+
+	t.Run("malformed JSON is still a 400", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/people", strings.NewReader(`{"name":`))
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("non JSON bodies are not validated", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/people", strings.NewReader("<TestStruct><Name>J</Name><Age>200</Age></TestStruct>"))
+		r.Header.Set("Content-Type", "application/xml")
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+}
@@ -0,0 +1,67 @@
+package fuego
+
+import (
+	"maps"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// FormatLinkHeader formats the given links, mapping relation types to URLs,
+// as the value of a Link header (RFC 8288). Relations are sorted for a stable output.
+//
+//	FormatLinkHeader(map[string]string{"next": "/pets?page=3", "prev": "/pets?page=1"})
+//	-> </pets?page=3>; rel="next", </pets?page=1>; rel="prev"
+func FormatLinkHeader(links map[string]string) string {
+	var formatted []string
+	for _, rel := range slices.Sorted(maps.Keys(links)) {
+		formatted = append(formatted, "<"+links[rel]+`>; rel="`+rel+`"`)
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// PaginationLinks computes the "first", "prev", "next" and "last" links of a paginated
+// collection, to be used with [Context.SetLinkHeader].
+// Pages start at 1 and are set in the "page" and "per_page" query parameters of the given URL,
+// other query parameters are kept. "prev" and "next" are omitted on the first and last pages.
+//
+//	links := fuego.PaginationLinks(c.Request().URL, page, perPage, totalPets)
+//	c.SetLinkHeader(links)
+func PaginationLinks(u *url.URL, page, perPage, total int) map[string]string {
+	if perPage < 1 {
+		return map[string]string{}
+	}
+
+	lastPage := max((total+perPage-1)/perPage, 1)
+	page = min(max(page, 1), lastPage)
+
+	pageURL := func(p int) string {
+		pageURL := *u
+		query := pageURL.Query()
+		query.Set("page", strconv.Itoa(p))
+		query.Set("per_page", strconv.Itoa(perPage))
+		pageURL.RawQuery = query.Encode()
+		return pageURL.String()
+	}
+
+	links := map[string]string{
+		"first": pageURL(1),
+		"last":  pageURL(lastPage),
+	}
+	if page > 1 {
+		links["prev"] = pageURL(page - 1)
+	}
+	if page < lastPage {
+		links["next"] = pageURL(page + 1)
+	}
+	return links
+}
+
+// SetLinkHeader sets the Link response header (RFC 8288) from the given relation types to URLs.
+func (c netHttpContext[B, P]) SetLinkHeader(links map[string]string) {
+	if len(links) == 0 {
+		return
+	}
+	c.Res.Header().Set("Link", FormatLinkHeader(links))
+}
@@ -0,0 +1,88 @@
+package fuego
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatLinkHeader(t *testing.T) {
+	t.Run("formats and sorts relations", func(t *testing.T) {
+		header := FormatLinkHeader(map[string]string{
+			"prev": "https://api.example.com/pets?page=1",
+			"next": "https://api.example.com/pets?page=3",
+		})
+		require.Equal(t, `<https://api.example.com/pets?page=3>; rel="next", <https://api.example.com/pets?page=1>; rel="prev"`, header)
+	})
+
+	t.Run("no links", func(t *testing.T) {
+		require.Empty(t, FormatLinkHeader(nil))
+	})
+}
+
+func TestPaginationLinks(t *testing.T) {
+	u, err := url.Parse("/pets?name=kitty&page=2&per_page=10")
+	require.NoError(t, err)
+
+	t.Run("middle page", func(t *testing.T) {
+		require.Equal(t, map[string]string{
+			"first": "/pets?name=kitty&page=1&per_page=10",
+			"prev":  "/pets?name=kitty&page=1&per_page=10",
+			"next":  "/pets?name=kitty&page=3&per_page=10",
+			"last":  "/pets?name=kitty&page=5&per_page=10",
+		}, PaginationLinks(u, 2, 10, 42))
+	})
+
+	t.Run("first page has no prev", func(t *testing.T) {
+		links := PaginationLinks(u, 1, 10, 42)
+		require.NotContains(t, links, "prev")
+		require.Equal(t, "/pets?name=kitty&page=2&per_page=10", links["next"])
+	})
+
+	t.Run("last page has no next", func(t *testing.T) {
+		links := PaginationLinks(u, 5, 10, 42)
+		require.NotContains(t, links, "next")
+		require.Equal(t, "/pets?name=kitty&page=4&per_page=10", links["prev"])
+	})
+
+	t.Run("empty collection", func(t *testing.T) {
+		require.Equal(t, map[string]string{
+			"first": "/pets?name=kitty&page=1&per_page=10",
+			"last":  "/pets?name=kitty&page=1&per_page=10",
+		}, PaginationLinks(u, 1, 10, 0))
+	})
+
+	t.Run("does not modify the given URL", func(t *testing.T) {
+		PaginationLinks(u, 3, 20, 42)
+		require.Equal(t, "/pets?name=kitty&page=2&per_page=10", u.String())
+	})
+
+	t.Run("invalid page size", func(t *testing.T) {
+		require.Empty(t, PaginationLinks(u, 1, 0, 42))
+	})
+}
+
+func TestContext_SetLinkHeader(t *testing.T) {
+	s := NewServer()
+	Get(s, "/pets", func(c ContextNoBody) ([]string, error) {
+		page := c.QueryParamInt("page")
+		c.SetLinkHeader(PaginationLinks(c.Request().URL, page, 10, 25))
+		return []string{}, nil
+	})
+
+	r := httptest.NewRequest("GET", "/pets?page=2", nil)
+	w := httptest.NewRecorder()
+	s.Mux.ServeHTTP(w, r)
+
+	require.Equal(t, `</pets?page=1&per_page=10>; rel="first", </pets?page=3&per_page=10>; rel="last", </pets?page=3&per_page=10>; rel="next", </pets?page=1&per_page=10>; rel="prev"`, w.Header().Get("Link"))
+
+	t.Run("no links", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c := NewNetHTTPContext[any, any](BaseRoute{}, w, httptest.NewRequest("GET", "/", nil), readOptions{})
+		c.SetLinkHeader(nil)
+
+		require.Empty(t, w.Header().Values("Link"))
+	})
+}
@@ -0,0 +1,42 @@
+package fuego
+
+import (
+	"cmp"
+	"log/slog"
+	"net/http"
+)
+
+// WithLogger sets the base logger of [Context.Logger]. Defaults to [slog.Default], see [WithLogHandler].
+func WithLogger(logger *slog.Logger) func(*Server) {
+	return func(s *Server) { s.logger = logger }
+}
+
+// RequestLogger returns the base logger with the attributes of the request: "request_id" (if not empty),
+// "route" (the matched pattern), "method" and "remote_ip", to correlate the logs of a request.
+// If base is nil, [slog.Default] is used.
+func RequestLogger(base *slog.Logger, r *http.Request, route, requestID string) *slog.Logger {
+	if base == nil {
+		base = slog.Default()
+	}
+
+	attrs := make([]any, 0, 4)
+	if requestID != "" {
+		attrs = append(attrs, slog.String("request_id", requestID))
+	}
+	attrs = append(attrs,
+		slog.String("route", route),
+		slog.String("method", r.Method),
+		slog.String("remote_ip", RemoteIP(r)),
+	)
+	return base.With(attrs...)
+}
+
+// requestID returns the request ID set by the logging middleware, or sent by the client.
+func requestID(w http.ResponseWriter, r *http.Request) string {
+	return cmp.Or(w.Header().Get("X-Request-ID"), r.Header.Get("X-Request-ID"))
+}
+
+// Logger returns the logger of the server with the attributes of the request, see [RequestLogger].
+func (c netHttpContext[B, P]) Logger() *slog.Logger {
+	return RequestLogger(c.logger, c.Req, c.Req.Pattern, requestID(c.Res, c.Req))
+}
@@ -0,0 +1,55 @@
+package fuego
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContextLogger(t *testing.T) {
+	var logs bytes.Buffer
+	s := NewServer(
+		WithLogger(slog.New(slog.NewJSONHandler(&logs, nil))),
+	)
+	Post(s, "/recipes/{id}", func(c ContextNoBody) (any, error) {
+		c.Logger().Info("recipe created", "id", c.PathParam("id"))
+		return nil, nil
+	})
+
+	r := httptest.NewRequest(http.MethodPost, "/recipes/42", nil)
+	r.RemoteAddr = "203.0.113.7:1234"
+	r.Header.Set("X-Request-ID", "req-123")
+	w := httptest.NewRecorder()
+	s.Mux.ServeHTTP(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var entry map[string]any
+	for line := range bytes.Lines(logs.Bytes()) {
+		var candidate map[string]any
+		require.NoError(t, json.Unmarshal(line, &candidate))
+		if candidate["msg"] == "recipe created" {
+			entry = candidate
+		}
+	}
+	require.NotNil(t, entry, logs.String())
+	require.Equal(t, "req-123", entry["request_id"])
+	require.Equal(t, "POST /recipes/{id}", entry["route"])
+	require.Equal(t, "POST", entry["method"])
+	require.Equal(t, "203.0.113.7", entry["remote_ip"])
+	require.Equal(t, "42", entry["id"])
+}
+
+func TestRequestLogger(t *testing.T) {
+	var logs bytes.Buffer
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	RequestLogger(slog.New(slog.NewTextHandler(&logs, nil)), r, "GET /", "").Info("hello")
+
+	require.Contains(t, logs.String(), `msg=hello route="GET /" method=GET remote_ip=192.0.2.1`)
+	require.NotContains(t, logs.String(), "request_id")
+}
@@ -3,6 +3,7 @@ package cache
 import (
 	"bytes"
 	"net/http"
+	"strconv"
 	"time"
 )
 
@@ -14,6 +15,8 @@ type Storage interface {
 type Config struct {
 	Storage Storage
 	Key     func(r *http.Request) string // Key returns the cache key for the request
+
+	now func() time.Time // now returns the current time, used to compute the Age header
 }
 
 // Cache the response of GET requests for a given duration.
@@ -23,6 +26,8 @@ type Config struct {
 // Headers can be used to invalidate the cache:
 //   - Cache-Control: no-cache will bypass the cache
 //   - Cache-Control: no-store might use the cache but will not store the response in the cache
+//
+// Responses served from the cache have an Age header (RFC 9111): the number of seconds since they were stored.
 func New(config ...Config) func(http.Handler) http.Handler {
 	if len(config) > 1 {
 		panic("Only one config is allowed")
@@ -33,6 +38,7 @@ func New(config ...Config) func(http.Handler) http.Handler {
 		Key: func(r *http.Request) string {
 			return "httpcache_" + r.URL.Path + "_" + r.Header.Get("Content-Type")
 		},
+		now: time.Now,
 	}
 
 	if len(config) == 1 {
@@ -43,6 +49,10 @@ func New(config ...Config) func(http.Handler) http.Handler {
 		if config[0].Key != nil {
 			c.Key = config[0].Key
 		}
+
+		if config[0].now != nil {
+			c.now = config[0].now
+		}
 	}
 
 	return func(h http.Handler) http.Handler {
@@ -65,6 +75,12 @@ func New(config ...Config) func(http.Handler) http.Handler {
 					w.Header().Set("Content-Type", respContentType)
 				}
 
+				if storedAt, ok := c.Storage.Get(key + "_stored-at"); ok {
+					if age, ok := responseAge(storedAt, c.now()); ok {
+						w.Header().Set("Age", age)
+					}
+				}
+
 				w.WriteHeader(http.StatusOK)
 				w.Header().Set("Cache", "hit")
 				_, _ = w.Write([]byte(val))
@@ -86,6 +102,21 @@ func New(config ...Config) func(http.Handler) http.Handler {
 
 			c.Storage.Set(key, multiWriter.cacheWriter.(*bytes.Buffer).String())
 			c.Storage.Set(key+"_response-content-type", multiWriter.Header().Get("Content-Type"))
+			c.Storage.Set(key+"_stored-at", strconv.FormatInt(c.now().UnixNano(), 10))
 		})
 	}
 }
+
+// responseAge returns the value of the Age header of a response stored at the given time (unix nanoseconds):
+// the number of seconds since then, rounded down.
+func responseAge(storedAt string, now time.Time) (string, bool) {
+	nanos, err := strconv.ParseInt(storedAt, 10, 64)
+	if err != nil {
+		return "", false
+	}
+	age := now.Sub(time.Unix(0, nanos))
+	if age < 0 {
+		age = 0
+	}
+	return strconv.FormatInt(int64(age/time.Second), 10), true
+}
@@ -3,6 +3,7 @@ package cache
 import (
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 	"time"
 
@@ -201,6 +202,53 @@ func TestCache(t *testing.T) {
 	})
 }
 
+func TestCacheAge(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := fuego.NewServer()
+	fuego.Get(s, "/with-cache", baseController, option.Middleware(New(Config{
+		Storage: NewInMemoryCache(time.Hour, 10),
+		now:     func() time.Time { return now },
+	})))
+
+	t.Run("no Age header when the response is not served from the cache", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/with-cache", nil)
+		w := httptest.NewRecorder()
+
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Empty(t, w.Header().Get("Age"))
+	})
+
+	t.Run("Age header is the number of seconds since the response was stored", func(t *testing.T) {
+		now = now.Add(90*time.Second + 900*time.Millisecond)
+		r := httptest.NewRequest("GET", "/with-cache", nil)
+		w := httptest.NewRecorder()
+
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "90", w.Header().Get("Age"))
+		require.Equal(t, `{"Name":"test","Age":10}`+"\n", w.Body.String())
+	})
+}
+
+func TestResponseAge(t *testing.T) {
+	storedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	stored := strconv.FormatInt(storedAt.UnixNano(), 10)
+
+	age, ok := responseAge(stored, storedAt.Add(1500*time.Millisecond))
+	require.True(t, ok)
+	require.Equal(t, "1", age)
+
+	age, ok = responseAge(stored, storedAt.Add(-time.Second))
+	require.True(t, ok)
+	require.Equal(t, "0", age, "clock skew never gives a negative age")
+
+	_, ok = responseAge("invalid", storedAt)
+	require.False(t, ok)
+}
+
 func BenchmarkCache(b *testing.B) {
 	s := fuego.NewServer()
 
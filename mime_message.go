@@ -0,0 +1,127 @@
+package fuego
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/http"
+	"net/textproto"
+	"path"
+	texttemplate "text/template"
+)
+
+// BuildMIMEMessage assembles a multipart/alternative MIME message (RFC 2046) with a plain text and an HTML part,
+// as sent by email clients. The parts are encoded as quoted-printable, and the subject as an RFC 2047 encoded-word if needed.
+// The HTML part is last, as clients display the last part they support.
+func BuildMIMEMessage(subject, html, text string) (string, error) {
+	var message bytes.Buffer
+	writer := multipart.NewWriter(&message)
+
+	message.WriteString("MIME-Version: 1.0\r\n")
+	message.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", subject) + "\r\n")
+	message.WriteString("Content-Type: " + mime.FormatMediaType("multipart/alternative", map[string]string{"boundary": writer.Boundary()}) + "\r\n")
+	message.WriteString("\r\n")
+
+	for _, part := range []struct {
+		contentType string
+		body        string
+	}{
+		{"text/plain; charset=utf-8", text},
+		{"text/html; charset=utf-8", html},
+	} {
+		partWriter, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return "", err
+		}
+		encoder := quotedprintable.NewWriter(partWriter)
+		if _, err := encoder.Write([]byte(part.body)); err != nil {
+			return "", err
+		}
+		if err := encoder.Close(); err != nil {
+			return "", err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+	return message.String(), nil
+}
+
+// renderMIME renders the HTML template with the given templates, like [Context.Render],
+// and the text template from the template filesystem with text/template, so it is not HTML-escaped.
+// Both are assembled with [BuildMIMEMessage].
+func renderMIME(ctx context.Context, templates *template.Template, fsys fs.FS, subject, htmlTemplate, textTemplate string, data any) (string, error) {
+	if templates == nil {
+		templates = template.New("")
+	}
+
+	var html bytes.Buffer
+	err := StdRenderer{
+		templateToExecute: htmlTemplate,
+		templates:         templates,
+		fs:                fsys,
+		data:              data,
+	}.Render(ctx, &html)
+	if err != nil {
+		return "", err
+	}
+
+	text, err := renderTextTemplate(fsys, textTemplate, data)
+	if err != nil {
+		return "", err
+	}
+
+	return BuildMIMEMessage(subject, html.String(), text)
+}
+
+func renderTextTemplate(fsys fs.FS, textTemplate string, data any) (string, error) {
+	if fsys == nil {
+		return "", HTTPError{
+			Err:    fs.ErrNotExist,
+			Status: http.StatusInternalServerError,
+			Title:  "Error parsing template",
+			Detail: "no template filesystem to read '" + textTemplate + "' from, see WithTemplateFS",
+		}
+	}
+
+	tmpl, err := texttemplate.ParseFS(fsys, textTemplate)
+	if err != nil {
+		return "", HTTPError{
+			Err:    err,
+			Status: http.StatusInternalServerError,
+			Title:  "Error parsing template",
+			Detail: fmt.Errorf("error parsing template '%s': %w", textTemplate, err).Error(),
+		}
+	}
+
+	var text bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&text, path.Base(textTemplate), data); err != nil {
+		return "", HTTPError{
+			Err:    err,
+			Status: http.StatusInternalServerError,
+			Title:  "Error rendering template",
+			Detail: fmt.Errorf("error executing template '%s': %w", textTemplate, err).Error(),
+		}
+	}
+	return text.String(), nil
+}
+
+// RenderMIME renders an email-style multipart/alternative MIME message, with a plain text and an HTML part,
+// see [BuildMIMEMessage]. [htmlTemplate] is rendered with the server templates like [Context.Render],
+// [textTemplate] is read from the template filesystem and rendered with text/template, so it is not HTML-escaped.
+// Useful for notification preview endpoints.
+// Example:
+//
+//	message, err := s.RenderMIME(c.Request(), "Welcome!", "emails/welcome.html", "emails/welcome.txt", user)
+func (s *Server) RenderMIME(r *http.Request, subject, htmlTemplate, textTemplate string, data any) (string, error) {
+	return renderMIME(r.Context(), s.requestTemplates(r), s.fs, subject, htmlTemplate, textTemplate, data)
+}
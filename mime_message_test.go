@@ -0,0 +1,98 @@
+package fuego
+
+import (
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+// readMIMEMessage parses a multipart/alternative message, and returns its subject and its parts by Content-Type.
+func readMIMEMessage(t *testing.T, message string) (string, map[string]string) {
+	t.Helper()
+
+	msg, err := mail.ReadMessage(strings.NewReader(message))
+	require.NoError(t, err)
+	require.Equal(t, "1.0", msg.Header.Get("MIME-Version"))
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/alternative", mediaType)
+
+	parts := map[string]string{}
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := reader.NextPart() // Decodes quoted-printable
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		body, err := io.ReadAll(part)
+		require.NoError(t, err)
+		parts[part.Header.Get("Content-Type")] = string(body)
+	}
+	return subject, parts
+}
+
+func TestBuildMIMEMessage(t *testing.T) {
+	message, err := BuildMIMEMessage("Bienvenue à bord", "<p>Hello</p>", "Hello = "+strings.Repeat("long ", 30))
+	require.NoError(t, err)
+	require.Contains(t, message, "Subject: =?utf-8?q?Bienvenue_=C3=A0_bord?=\r\n")
+	require.Contains(t, message, "Content-Transfer-Encoding: quoted-printable")
+	require.Less(t, strings.Index(message, "text/plain"), strings.Index(message, "text/html"), "the HTML part is the preferred one, so it is last")
+
+	subject, parts := readMIMEMessage(t, message)
+	require.Equal(t, "Bienvenue à bord", subject)
+	require.Equal(t, map[string]string{
+		"text/plain; charset=utf-8": "Hello = " + strings.Repeat("long ", 30),
+		"text/html; charset=utf-8":  "<p>Hello</p>",
+	}, parts)
+}
+
+func TestServer_RenderMIME(t *testing.T) {
+	s := NewServer(
+		WithTemplateFS(fstest.MapFS{
+			"emails/welcome.html": {Data: []byte(`<p>Welcome {{ .Name }}!</p>`)},
+			"emails/welcome.txt":  {Data: []byte(`Welcome {{ .Name }}!`)},
+		}),
+		WithTemplateGlobs("emails/*.html"),
+	)
+
+	Get(s, "/preview", func(c ContextNoBody) (string, error) {
+		return s.RenderMIME(c.Request(), "Welcome", "emails/welcome.html", "emails/welcome.txt", H{"Name": "Ewen & <Dylan>"})
+	})
+	Get(s, "/preview-missing", func(c ContextNoBody) (string, error) {
+		return s.RenderMIME(c.Request(), "Welcome", "emails/welcome.html", "emails/missing.txt", H{"Name": "Ewen"})
+	})
+
+	t.Run("renders both parts", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/preview", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		subject, parts := readMIMEMessage(t, w.Body.String())
+		require.Equal(t, "Welcome", subject)
+		require.Equal(t, map[string]string{
+			"text/plain; charset=utf-8": "Welcome Ewen & <Dylan>!",
+			"text/html; charset=utf-8":  "<p>Welcome Ewen &amp; &lt;Dylan&gt;!</p>",
+		}, parts)
+	})
+
+	t.Run("fails if a template is missing", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/preview-missing", nil))
+
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+		require.Contains(t, w.Body.String(), "Error parsing template")
+	})
+}
@@ -1,12 +1,21 @@
 package fuego
 
 import (
+	"archive/zip"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
 	"fmt"
+	"html/template"
+	"io"
+	"iter"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-fuego/fuego/internal"
 )
@@ -24,13 +33,21 @@ type MockContext[B, P any] struct {
 	response      http.ResponseWriter
 	request       *http.Request
 	Cookies       map[string]*http.Cookie
+	RateLimiter   RateLimiter
+	APIVersioning APIVersionConfig
+	// TLS is the connection state of the mock request, nil for non-TLS requests.
+	TLS *tls.ConnectionState
+	// BandwidthLimit is the last limit set with [MockContext.SetBandwidthLimit].
+	BandwidthLimit int64
+
+	finishCallbacks FinishCallbacks
 }
 
 // NewMockContext creates a new MockContext instance with the provided body
 func NewMockContext[B, P any](body B, params P) *MockContext[B, P] {
 	return &MockContext[B, P]{
 		CommonContext: internal.CommonContext[B]{
-			CommonCtx:         context.Background(),
+			CommonCtx:         withContextValues(context.Background()),
 			UrlValues:         make(url.Values),
 			OpenAPIParams:     make(map[string]internal.OpenAPIParam),
 			DefaultStatusCode: http.StatusOK,
@@ -69,6 +86,60 @@ func (m *MockContext[B, P]) MustParams() P {
 	return m.RequestParams
 }
 
+// BodyAny reads the mock request body, see [ReadBodyAny].
+// Without request, it returns the mock body.
+func (m *MockContext[B, P]) BodyAny() (any, error) {
+	if m.request == nil {
+		return m.RequestBody, nil
+	}
+	return ReadBodyAny(m.request)
+}
+
+// BodyLines returns an iterator over the lines of the mock request body, see [ReadLines].
+// Without request, it iterates over nothing.
+func (m *MockContext[B, P]) BodyLines() iter.Seq2[string, error] {
+	if m.request == nil {
+		return func(func(string, error) bool) {}
+	}
+	return ReadLines(m.request)
+}
+
+// VerifyContentMD5 checks the mock request body against its Content-MD5 header.
+// Without request, it always succeeds.
+func (m *MockContext[B, P]) VerifyContentMD5() error {
+	if m.request == nil {
+		return nil
+	}
+	return VerifyContentMD5(m.request)
+}
+
+// MultipartRelated reads the mock multipart/related request body.
+// Without request, it returns no parts.
+func (m *MockContext[B, P]) MultipartRelated() ([]byte, []RelatedPart, error) {
+	if m.request == nil {
+		return nil, nil, nil
+	}
+	return ReadMultipartRelated(m.request)
+}
+
+// VerifyDigestSHA256 checks the mock request body against its Digest header.
+// Without request, it always succeeds.
+func (m *MockContext[B, P]) VerifyDigestSHA256() error {
+	if m.request == nil {
+		return nil
+	}
+	return VerifyDigestSHA256(m.request)
+}
+
+// VerifySignature checks the HMAC of the mock request body against the signature of the given header.
+// Without request, it always succeeds.
+func (m *MockContext[B, P]) VerifySignature(header string, secret []byte, algo string) error {
+	if m.request == nil {
+		return nil
+	}
+	return VerifySignature(m.request, header, secret, algo)
+}
+
 // HasHeader checks if a header exists
 func (m *MockContext[B, P]) HasHeader(key string) bool {
 	_, exists := m.Headers[key]
@@ -91,15 +162,236 @@ func (m *MockContext[B, P]) SetHeader(key, value string) {
 	m.Headers.Set(key, value)
 }
 
+// SetContentLength sets the Content-Length header in the mock context
+func (m *MockContext[B, P]) SetContentLength(n int64) {
+	m.Headers.Set("Content-Length", strconv.FormatInt(n, 10))
+}
+
+// ResetHeaders clears the headers of the mock context
+func (m *MockContext[B, P]) ResetHeaders() error {
+	clear(m.Headers)
+	return nil
+}
+
+// Prefer returns the given preference of the mock Prefer header
+func (m *MockContext[B, P]) Prefer(name string) (string, bool) {
+	value, ok := ParsePreferHeader(m.Headers)[strings.ToLower(name)]
+	return value, ok
+}
+
+// StartTiming adds the duration of the sub-operation to the mock Server-Timing header
+func (m *MockContext[B, P]) StartTiming(name string) func() {
+	return StartTiming(m.Headers, name)
+}
+
+// Vary adds the given headers to the mock Vary header
+func (m *MockContext[B, P]) Vary(headers ...string) {
+	AddVary(m.Headers, headers...)
+}
+
+// ClientHints parses the User-Agent Client Hints of the mock headers
+func (m *MockContext[B, P]) ClientHints() ClientHints {
+	return ParseClientHints(m.Headers)
+}
+
+// RequireContentType checks the mock Content-Type header against the allowed media types
+func (m *MockContext[B, P]) RequireContentType(allowed ...string) error {
+	return RequireContentType(m.Headers, allowed...)
+}
+
+// IfMatch checks the mock If-Match header against the given ETag
+func (m *MockContext[B, P]) IfMatch(etag string) bool {
+	return IfMatch(m.Headers, etag)
+}
+
+// RequireIfMatch checks the mock If-Match header against the current ETag
+func (m *MockContext[B, P]) RequireIfMatch(current string) error {
+	return RequireIfMatch(m.Headers, current)
+}
+
+// SetPreferenceApplied adds a Preference-Applied header in the mock context
+func (m *MockContext[B, P]) SetPreferenceApplied(name, value string) {
+	m.Headers.Add("Preference-Applied", formatPreference(name, value))
+}
+
+// SetLinkHeader sets a Link header in the mock context
+func (m *MockContext[B, P]) SetLinkHeader(links map[string]string) {
+	if len(links) == 0 {
+		return
+	}
+	m.Headers.Set("Link", FormatLinkHeader(links))
+}
+
+// Deprecate sets the deprecation headers in the mock context
+func (m *MockContext[B, P]) Deprecate(date time.Time, link string) {
+	SetDeprecationHeaders(m.Headers, date, link)
+}
+
+// SetExpires sets the Expires header in the mock context
+func (m *MockContext[B, P]) SetExpires(t time.Time) {
+	SetExpiresHeader(m.Headers, t)
+}
+
+// RemoteIP returns the client IP address from the mock request or headers
+func (m *MockContext[B, P]) RemoteIP() string {
+	return RemoteIP(m.forwardedRequest())
+}
+
+// FullURL returns the URL requested by the client from the mock request or headers
+func (m *MockContext[B, P]) FullURL() string {
+	return FullURL(m.forwardedRequest())
+}
+
+// ForwardedFor returns the forwarded addresses from the mock request or headers
+func (m *MockContext[B, P]) ForwardedFor() []string {
+	return ForwardedFor(m.forwardedRequest())
+}
+
+// APIVersion returns the API version from the mock request or headers
+func (m *MockContext[B, P]) APIVersion() (string, error) {
+	return APIVersionFromRequest(m.forwardedRequest(), m.APIVersioning)
+}
+
+// forwardedRequest returns the mock request, or a request with the mock headers if none is set.
+func (m *MockContext[B, P]) forwardedRequest() *http.Request {
+	if m.request != nil {
+		return m.request
+	}
+	return &http.Request{Header: m.Headers, URL: &url.URL{Path: "/"}}
+}
+
+// AcceptOffers returns the media ranges of the mock Accept header
+func (m *MockContext[B, P]) AcceptOffers() []MediaOffer {
+	return ParseAccept(m.Headers)
+}
+
+// FormValues returns the values of the given field of the mock request form, if any
+func (m *MockContext[B, P]) FormValues(name string) []string {
+	if m.request == nil {
+		return nil
+	}
+	return FormValues(m.request, name)
+}
+
+// FormValuesInt returns the values of the given field of the mock request form as ints
+func (m *MockContext[B, P]) FormValuesInt(name string) ([]int, error) {
+	if m.request == nil {
+		return nil, nil
+	}
+	return FormValuesInt(m.request, name)
+}
+
+// Logger returns the default logger with the attributes of the mock request
+func (m *MockContext[B, P]) Logger() *slog.Logger {
+	r := m.forwardedRequest()
+	return RequestLogger(nil, r, r.Pattern, r.Header.Get("X-Request-ID"))
+}
+
+// traceContext returns the trace context stored in the mock context with [SetContextValue],
+// or a new one from the mock headers
+func (m *MockContext[B, P]) traceContext() TraceContext {
+	if trace, ok := ContextValue[TraceContext](m); ok {
+		return trace
+	}
+	trace := NewTraceContext(m.Headers)
+	SetContextValue(m, trace)
+	return trace
+}
+
+// TraceID returns the trace ID of the mock request
+func (m *MockContext[B, P]) TraceID() string {
+	return m.traceContext().TraceID
+}
+
+// SpanID returns the span ID of the mock request
+func (m *MockContext[B, P]) SpanID() string {
+	return m.traceContext().SpanID
+}
+
+// PropagateTrace sets the trace headers of the outgoing request from the mock trace context
+func (m *MockContext[B, P]) PropagateTrace(req *http.Request) {
+	m.traceContext().Inject(req.Header)
+}
+
+// ClientCertificate returns the verified client certificate of the mock TLS connection state
+func (m *MockContext[B, P]) ClientCertificate() (*x509.Certificate, bool) {
+	return ClientCertificate(&http.Request{TLS: m.TLS})
+}
+
+// TLSVersion returns the TLS version of the mock TLS connection state
+func (m *MockContext[B, P]) TLSVersion() uint16 {
+	return TLSVersion(&http.Request{TLS: m.TLS})
+}
+
+// Tx returns the transaction stored in the mock context with [SetContextValue], as a [Tx]
+func (m *MockContext[B, P]) Tx() (any, bool) {
+	return ContextValue[Tx](m)
+}
+
+// Cursor decodes the "cursor" query parameter of the mock request
+func (m *MockContext[B, P]) Cursor() (Cursor, error) {
+	return ParseCursor(m.QueryParam("cursor"))
+}
+
+// TimeRange parses the time range given by two query parameters of the mock request
+func (m *MockContext[B, P]) TimeRange(fromParam, toParam, layout string, options ...TimeRangeOption) (from, to time.Time, err error) {
+	return ParseTimeRange(m.QueryParam(fromParam), m.QueryParam(toParam), layout, options...)
+}
+
+// OnFinish registers a callback, run by [MockContext.Finish]
+func (m *MockContext[B, P]) OnFinish(fn func(err error)) {
+	m.finishCallbacks.Add(fn)
+}
+
+// Finish runs the callbacks registered with OnFinish, as done once the response is written
+func (m *MockContext[B, P]) Finish(err error) {
+	m.finishCallbacks.Run(err)
+}
+
+// DebugInfo returns the metadata of the mock request
+func (m *MockContext[B, P]) DebugInfo() map[string]any {
+	r := m.forwardedRequest()
+	return RequestDebugInfo(r, r.Pattern, DefaultDebugRedactedHeaders)
+}
+
+// ResponseCharset returns the charset negotiated from the mock Accept-Charset header
+func (m *MockContext[B, P]) ResponseCharset() string {
+	return NegotiateCharset(m.Headers)
+}
+
+// BytesWritten returns 0, as the mock context does not write a response body
+func (m *MockContext[B, P]) BytesWritten() int64 {
+	return 0
+}
+
+// SetBandwidthLimit records the limit in BandwidthLimit
+func (m *MockContext[B, P]) SetBandwidthLimit(bytesPerSec int64) {
+	m.BandwidthLimit = bytesPerSec
+}
+
+// CheckRateLimit checks the key against the mock rate limiter, if any
+func (m *MockContext[B, P]) CheckRateLimit(key string) error {
+	return checkRateLimit(m.RateLimiter, m.Headers, key)
+}
+
 // PathParam returns a mock path parameter
 func (m *MockContext[B, P]) PathParam(name string) string {
 	return m.PathParams[name]
 }
 
+// PathParamRaw returns the mock path parameter, URL-escaped
+func (m *MockContext[B, P]) PathParamRaw(name string) string {
+	return url.PathEscape(m.PathParams[name])
+}
+
 func (m *MockContext[B, P]) PathParamIntErr(name string) (int, error) {
 	return strconv.Atoi(m.PathParams[name])
 }
 
+func (m *MockContext[B, P]) PathParamIntArr(name, sep string) ([]int, error) {
+	return PathParamIntArr(m, name, sep)
+}
+
 func (m *MockContext[B, P]) PathParamInt(name string) int {
 	if i, err := m.PathParamIntErr(name); err == nil {
 		return i
@@ -107,11 +399,24 @@ func (m *MockContext[B, P]) PathParamInt(name string) int {
 	return 0
 }
 
+// QueryString returns the encoded query parameters set on the mock context
+func (m *MockContext[B, P]) QueryString() string {
+	return m.UrlValues.Encode()
+}
+
 // Request returns the mock request
 func (m *MockContext[B, P]) Request() *http.Request {
 	return m.request
 }
 
+// Method returns the method of the mock request, GET if no request is set
+func (m *MockContext[B, P]) Method() string {
+	if m.request == nil {
+		return http.MethodGet
+	}
+	return m.request.Method
+}
+
 // Response returns the mock response writer
 func (m *MockContext[B, P]) Response() http.ResponseWriter {
 	return m.response
@@ -152,6 +457,78 @@ func (m *MockContext[B, P]) MainLocale() string {
 	return m.Headers.Get("Accept-Language")
 }
 
+// SendRaw writes the given payload to the mock response, if any
+func (m *MockContext[B, P]) SendRaw(contentType string, data []byte) (any, error) {
+	if m.response == nil {
+		return nil, nil
+	}
+	m.response.Header().Set("Content-Type", contentType)
+	m.response.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	m.response.WriteHeader(m.DefaultStatusCode)
+	_, err := m.response.Write(data)
+	return nil, err
+}
+
+// MultipartResponse starts a multipart/mixed response on the mock response, if any.
+// Without response, the parts are discarded.
+func (m *MockContext[B, P]) MultipartResponse() (*MultipartWriter, error) {
+	if m.response == nil {
+		return newMultipartWriter(io.Discard, nil), nil
+	}
+	writer := NewMultipartResponse(m.response)
+	m.response.WriteHeader(m.DefaultStatusCode)
+	return writer, nil
+}
+
+// StreamNDJSON writes the items as NDJSON to the mock response, if any.
+// Without response, the items are serialized and discarded.
+func (m *MockContext[B, P]) StreamNDJSON(items iter.Seq[any]) error {
+	if m.response == nil {
+		for item := range items {
+			if _, err := json.Marshal(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return WriteNDJSON(m.Context(), m.response, m.DefaultStatusCode, items)
+}
+
+// ZipStream writes the ZIP archive to the mock response, if any.
+// Without response, the archive is discarded.
+func (m *MockContext[B, P]) ZipStream(fn func(zw *zip.Writer) error) (any, error) {
+	if m.response == nil {
+		return nil, fn(zip.NewWriter(io.Discard))
+	}
+	return nil, WriteZip(m.Context(), m.response, m.DefaultStatusCode, fn)
+}
+
+// SerializeFields writes the data with only the given fields as JSON to the mock response, if any
+func (m *MockContext[B, P]) SerializeFields(data any, fields []string) error {
+	if m.response == nil {
+		return nil
+	}
+	if len(fields) > 0 {
+		filtered, err := FilterFields(data, fields)
+		if err != nil {
+			return err
+		}
+		data = filtered
+	}
+	m.response.WriteHeader(m.DefaultStatusCode)
+	return SendJSON(m.response, m.request, data)
+}
+
+// TailStream writes the lines to the mock response, if any, until the channel is closed
+func (m *MockContext[B, P]) TailStream(ch <-chan string) error {
+	if m.response == nil {
+		for range ch {
+		}
+		return nil
+	}
+	return WriteLines(m.CommonCtx, m.response, m.DefaultStatusCode, ch)
+}
+
 // Redirect returns a redirect response
 func (m *MockContext[B, P]) Redirect(code int, location string) (any, error) {
 	if m.response != nil {
@@ -160,11 +537,64 @@ func (m *MockContext[B, P]) Redirect(code int, location string) (any, error) {
 	return nil, nil
 }
 
+// RedirectPreserveQuery returns a redirect response keeping the mock query parameters
+func (m *MockContext[B, P]) RedirectPreserveQuery(code int, path string) (any, error) {
+	location, err := MergeQuery(path, m.UrlValues)
+	if err != nil {
+		return nil, err
+	}
+	return m.Redirect(code, location)
+}
+
+// ExpectContinue reports whether the mock request expects a "100 Continue" response
+func (m *MockContext[B, P]) ExpectContinue() bool {
+	return ExpectContinue(m.forwardedRequest())
+}
+
+// EarlyHints sends a "103 Early Hints" response to the mock response, if any
+func (m *MockContext[B, P]) EarlyHints(links ...string) {
+	if m.response != nil {
+		SendEarlyHints(m.response, m.forwardedRequest(), links...)
+	}
+}
+
+// SendContinue sends a "100 Continue" response to the mock response, if any
+func (m *MockContext[B, P]) SendContinue() {
+	if m.response != nil {
+		SendContinue(m.response, m.forwardedRequest())
+	}
+}
+
+// Trailers returns the trailers of the mock request, if any
+func (m *MockContext[B, P]) Trailers() http.Header {
+	if m.request == nil {
+		return nil
+	}
+	return RequestTrailers(m.request)
+}
+
+// Trailer returns a trailer of the mock request
+func (m *MockContext[B, P]) Trailer(key string) string {
+	return m.Trailers().Get(key)
+}
+
+// Health runs the health checks, and sets the status code of the mock response if any
+func (m *MockContext[B, P]) Health(checks ...HealthCheck) (any, error) {
+	response := CheckHealth(m.Context(), checks...)
+	m.SetStatus(response.StatusCode())
+	return response, nil
+}
+
 // Render is a mock implementation that does nothing
 func (m *MockContext[B, P]) Render(templateToExecute string, data any, templateGlobsToOverride ...string) (CtxRenderer, error) {
 	panic("not implemented")
 }
 
+// RenderMarkdown returns the HTML-escaped content, as no renderer is available in the mock context
+func (m *MockContext[B, P]) RenderMarkdown(md string) template.HTML {
+	return template.HTML(template.HTMLEscapeString(md)) // #nosec G203 (escaped)
+}
+
 // SetQueryParam adds a query parameter to the mock context with OpenAPI validation
 func (m *MockContext[B, P]) SetQueryParam(name, value string) *MockContext[B, P] {
 	param := OpenAPIParam{
@@ -0,0 +1,124 @@
+package fuego
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strings"
+)
+
+// RelatedPart is a part of a multipart/related request body (RFC 2387), see [ReadMultipartRelated].
+// The body is read in memory, as parts cannot be read once the next one is.
+type RelatedPart struct {
+	Header textproto.MIMEHeader
+	// ContentID is the Content-ID of the part, without the angle brackets.
+	ContentID string
+	Body      []byte
+}
+
+// ReadMultipartRelated reads a multipart/related request body, as sent by FHIR or document upload APIs
+// with JSON metadata and binary attachments in a single request.
+// It returns the body of the root part, identified by the start parameter of the Content-Type
+// or the first part without it, and the other parts in order.
+// Customizable by modifying ReadOptions.
+func ReadMultipartRelated(r *http.Request) ([]byte, []RelatedPart, error) {
+	return readMultipartRelated(r, ReadOptions)
+}
+
+func readMultipartRelated(r *http.Request, options readOptions) ([]byte, []RelatedPart, error) {
+	mediaType, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/related" {
+		return nil, nil, BadRequestError{
+			Title:  "Invalid Content-Type",
+			Err:    err,
+			Detail: "expected a multipart/related body, got Content-Type: " + r.Header.Get("Content-Type"),
+		}
+	}
+	if params["boundary"] == "" {
+		return nil, nil, BadRequestError{
+			Title:  "Invalid Content-Type",
+			Err:    errors.New("missing boundary"),
+			Detail: "the multipart/related Content-Type must have a boundary parameter",
+		}
+	}
+	start := trimContentID(params["start"])
+
+	body := r.Body
+	if options.MaxBodySize != 0 {
+		body = http.MaxBytesReader(nil, body, options.MaxBodySize)
+	}
+
+	parts, err := readRelatedParts(multipart.NewReader(body, params["boundary"]))
+	if err != nil {
+		return nil, nil, BadRequestError{
+			Title:  "Decoding Failed",
+			Err:    err,
+			Detail: "cannot read multipart/related request body: " + err.Error(),
+		}
+	}
+	if len(parts) == 0 {
+		return nil, nil, BadRequestError{
+			Title:  "Decoding Failed",
+			Err:    errors.New("no parts"),
+			Detail: "the multipart/related request body must have a root part",
+		}
+	}
+
+	root := 0
+	if start != "" {
+		root = -1
+		for i, part := range parts {
+			if part.ContentID == start {
+				root = i
+				break
+			}
+		}
+		if root < 0 {
+			return nil, nil, BadRequestError{
+				Title:  "Decoding Failed",
+				Err:    fmt.Errorf("root part %q not found", start),
+				Detail: "no part has the Content-ID given by the start parameter: " + start,
+			}
+		}
+	}
+
+	attachments := append(parts[:root:root], parts[root+1:]...)
+	return parts[root].Body, attachments, nil
+}
+
+func readRelatedParts(reader *multipart.Reader) ([]RelatedPart, error) {
+	var parts []RelatedPart
+	for {
+		part, err := reader.NextRawPart()
+		if errors.Is(err, io.EOF) {
+			return parts, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := io.ReadAll(part)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, RelatedPart{
+			Header:    part.Header,
+			ContentID: trimContentID(part.Header.Get("Content-ID")),
+			Body:      body,
+		})
+	}
+}
+
+// trimContentID removes the angle brackets of a Content-ID: <root@example.com> becomes root@example.com.
+func trimContentID(id string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(id), "<"), ">")
+}
+
+// MultipartRelated reads the multipart/related request body, see [ReadMultipartRelated].
+func (c netHttpContext[B, P]) MultipartRelated() ([]byte, []RelatedPart, error) {
+	return readMultipartRelated(c.Req, c.readOptions)
+}
@@ -0,0 +1,150 @@
+package fuego
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newMultipartRelatedRequest builds a multipart/related request with the given parts and start parameter.
+func newMultipartRelatedRequest(t *testing.T, start string, parts ...RelatedPart) *http.Request {
+	t.Helper()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for _, part := range parts {
+		header := textproto.MIMEHeader{}
+		for key, values := range part.Header {
+			header[key] = values
+		}
+		if part.ContentID != "" {
+			header.Set("Content-ID", "<"+part.ContentID+">")
+		}
+		partWriter, err := writer.CreatePart(header)
+		require.NoError(t, err)
+		_, err = partWriter.Write(part.Body)
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+
+	contentType := `multipart/related; type="application/json"; boundary=` + writer.Boundary()
+	if start != "" {
+		contentType += `; start="<` + start + `>"`
+	}
+	r := httptest.NewRequest(http.MethodPost, "/documents", &body)
+	r.Header.Set("Content-Type", contentType)
+	return r
+}
+
+func TestReadMultipartRelated(t *testing.T) {
+	metadata := RelatedPart{
+		Header:    textproto.MIMEHeader{"Content-Type": {"application/json"}},
+		ContentID: "metadata@example.com",
+		Body:      []byte(`{"title":"Report","attachments":["cid:scan@example.com","cid:photo@example.com"]}`),
+	}
+	scan := RelatedPart{
+		Header:    textproto.MIMEHeader{"Content-Type": {"application/pdf"}},
+		ContentID: "scan@example.com",
+		Body:      []byte("%PDF-1.7 binary\x00\x01"),
+	}
+	photo := RelatedPart{
+		Header:    textproto.MIMEHeader{"Content-Type": {"image/png"}, "Content-Transfer-Encoding": {"binary"}},
+		ContentID: "photo@example.com",
+		Body:      []byte("\x89PNG\r\n\x1a\n"),
+	}
+
+	t.Run("reads the root part identified by start and the attachments", func(t *testing.T) {
+		r := newMultipartRelatedRequest(t, "metadata@example.com", scan, metadata, photo)
+
+		root, attachments, err := ReadMultipartRelated(r)
+		require.NoError(t, err)
+		require.JSONEq(t, string(metadata.Body), string(root))
+		require.Len(t, attachments, 2)
+		require.Equal(t, "scan@example.com", attachments[0].ContentID)
+		require.Equal(t, "application/pdf", attachments[0].Header.Get("Content-Type"))
+		require.Equal(t, scan.Body, attachments[0].Body)
+		require.Equal(t, "photo@example.com", attachments[1].ContentID)
+		require.Equal(t, photo.Body, attachments[1].Body)
+	})
+
+	t.Run("root part is the first one without start", func(t *testing.T) {
+		r := newMultipartRelatedRequest(t, "", metadata, scan, photo)
+
+		root, attachments, err := ReadMultipartRelated(r)
+		require.NoError(t, err)
+		require.Equal(t, metadata.Body, root)
+		require.Len(t, attachments, 2)
+		require.Equal(t, "scan@example.com", attachments[0].ContentID)
+	})
+
+	t.Run("root part not found", func(t *testing.T) {
+		r := newMultipartRelatedRequest(t, "missing@example.com", metadata, scan)
+
+		_, _, err := ReadMultipartRelated(r)
+		require.ErrorAs(t, err, &BadRequestError{})
+		require.Contains(t, err.Error(), "missing@example.com")
+	})
+
+	t.Run("other Content-Type", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/documents", bytes.NewReader([]byte("{}")))
+		r.Header.Set("Content-Type", "application/json")
+
+		_, _, err := ReadMultipartRelated(r)
+		require.ErrorAs(t, err, &BadRequestError{})
+	})
+
+	t.Run("missing boundary", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/documents", bytes.NewReader([]byte("{}")))
+		r.Header.Set("Content-Type", "multipart/related")
+
+		_, _, err := ReadMultipartRelated(r)
+		require.ErrorAs(t, err, &BadRequestError{})
+	})
+
+	t.Run("body too large", func(t *testing.T) {
+		r := newMultipartRelatedRequest(t, "", metadata, scan, photo)
+
+		_, _, err := readMultipartRelated(r, readOptions{MaxBodySize: 10})
+		require.ErrorAs(t, err, &BadRequestError{})
+	})
+}
+
+func TestContextMultipartRelated(t *testing.T) {
+	type document struct {
+		Title string `json:"title"`
+	}
+
+	s := NewServer()
+	Post(s, "/documents", func(c ContextNoBody) ([]string, error) {
+		metadata, attachments, err := c.MultipartRelated()
+		if err != nil {
+			return nil, err
+		}
+		var doc document
+		if err := json.Unmarshal(metadata, &doc); err != nil {
+			return nil, err
+		}
+		names := []string{doc.Title}
+		for _, attachment := range attachments {
+			names = append(names, attachment.ContentID)
+		}
+		return names, nil
+	})
+
+	r := newMultipartRelatedRequest(t, "root",
+		RelatedPart{ContentID: "first", Body: []byte("1")},
+		RelatedPart{ContentID: "root", Header: textproto.MIMEHeader{"Content-Type": {"application/json"}}, Body: []byte(`{"title":"Report"}`)},
+		RelatedPart{ContentID: "second", Body: []byte("2")},
+	)
+	w := httptest.NewRecorder()
+	s.Mux.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `["Report","first","second"]`, w.Body.String())
+}
@@ -0,0 +1,92 @@
+package fuego
+
+import (
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+)
+
+// MultipartWriter writes the parts of a multipart/mixed response, see [Context.MultipartResponse].
+// Each part is flushed to the client once written, so clients can process parts as they arrive.
+// [MultipartWriter.Close] must be called to write the closing boundary.
+type MultipartWriter struct {
+	writer *multipart.Writer
+	flush  func() error
+}
+
+// NewMultipartResponse sets the Content-Type of the response to multipart/mixed with a random boundary,
+// and returns a writer for the parts of the response.
+// The status code must be written by the caller, if any, before writing the first part.
+func NewMultipartResponse(w http.ResponseWriter) *MultipartWriter {
+	writer := newMultipartWriter(w, http.NewResponseController(w).Flush)
+	w.Header().Set("Content-Type", "multipart/mixed; boundary="+writer.Boundary())
+	w.Header().Del("Content-Length")
+	return writer
+}
+
+func newMultipartWriter(w io.Writer, flush func() error) *MultipartWriter {
+	return &MultipartWriter{
+		writer: multipart.NewWriter(w),
+		flush:  flush,
+	}
+}
+
+// Boundary returns the boundary separating the parts.
+func (w *MultipartWriter) Boundary() string {
+	return w.writer.Boundary()
+}
+
+// CreatePart starts a new part with the given headers, and returns a writer for its body.
+// The previous part is flushed to the client.
+func (w *MultipartWriter) CreatePart(header textproto.MIMEHeader) (io.Writer, error) {
+	if err := w.Flush(); err != nil {
+		return nil, err
+	}
+	return w.writer.CreatePart(header)
+}
+
+// WritePart writes a part with the given headers and body, and flushes it to the client.
+//
+//	mw.WritePart(textproto.MIMEHeader{"Content-Type": {"application/json"}}, []byte(`{"id":1}`))
+func (w *MultipartWriter) WritePart(header textproto.MIMEHeader, body []byte) error {
+	part, err := w.writer.CreatePart(header)
+	if err != nil {
+		return err
+	}
+	if _, err := part.Write(body); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// Flush sends the parts written so far to the client.
+// It does nothing if the underlying response writer does not support flushing.
+func (w *MultipartWriter) Flush() error {
+	if w.flush == nil {
+		return nil
+	}
+	if err := w.flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
+
+// Close writes the closing boundary and flushes the response.
+func (w *MultipartWriter) Close() error {
+	if err := w.writer.Close(); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// MultipartResponse starts a multipart/mixed response with the default status code of the route.
+func (c netHttpContext[B, P]) MultipartResponse() (*MultipartWriter, error) {
+	if w, ok := c.Res.(*responseSizeWriter); ok && w.wroteHeader {
+		return nil, ErrHeadersAlreadySent
+	}
+	writer := NewMultipartResponse(c.Res)
+	c.SetDefaultStatusCode()
+	return writer, nil
+}
@@ -0,0 +1,84 @@
+package fuego
+
+import (
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultipartResponse(t *testing.T) {
+	s := NewServer()
+	Post(s, "/batch", func(c ContextNoBody) (any, error) {
+		mw, err := c.MultipartResponse()
+		if err != nil {
+			return nil, err
+		}
+
+		err = mw.WritePart(textproto.MIMEHeader{
+			"Content-Type": {"application/json"},
+			"Content-Id":   {"1"},
+		}, []byte(`{"id":1}`))
+		if err != nil {
+			return nil, err
+		}
+
+		part, err := mw.CreatePart(textproto.MIMEHeader{"Content-Type": {"text/plain"}})
+		if err != nil {
+			return nil, err
+		}
+		_, err = io.WriteString(part, "second part")
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, mw.Close()
+	}, OptionDefaultStatusCode(http.StatusMultiStatus))
+
+	r := httptest.NewRequest(http.MethodPost, "/batch", nil)
+	w := httptest.NewRecorder()
+	s.Mux.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusMultiStatus, w.Code)
+	require.True(t, w.Flushed)
+
+	mediaType, params, err := mime.ParseMediaType(w.Header().Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/mixed", mediaType)
+	require.NotEmpty(t, params["boundary"])
+
+	reader := multipart.NewReader(w.Body, params["boundary"])
+
+	part, err := reader.NextPart()
+	require.NoError(t, err)
+	require.Equal(t, "application/json", part.Header.Get("Content-Type"))
+	require.Equal(t, "1", part.Header.Get("Content-Id"))
+	body, err := io.ReadAll(part)
+	require.NoError(t, err)
+	require.Equal(t, `{"id":1}`, string(body))
+
+	part, err = reader.NextPart()
+	require.NoError(t, err)
+	require.Equal(t, "text/plain", part.Header.Get("Content-Type"))
+	body, err = io.ReadAll(part)
+	require.NoError(t, err)
+	require.Equal(t, "second part", string(body))
+
+	_, err = reader.NextPart()
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestMultipartResponse_HeadersAlreadySent(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	c := NewNetHTTPContext[any, any](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+	_, err := c.SendRaw("text/plain", []byte("hello"))
+	require.NoError(t, err)
+
+	_, err = c.MultipartResponse()
+	require.ErrorIs(t, err, ErrHeadersAlreadySent)
+}
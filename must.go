@@ -0,0 +1,42 @@
+package fuego
+
+import (
+	"errors"
+	"net/http"
+	"runtime"
+)
+
+// Must returns the value, or panics with the error if it is not nil.
+// In a controller, the panic is recovered and the error is handled like a returned error:
+// it goes through the error handler and the error serializer, so typed errors keep their status code.
+// It complements [Context.MustBody] and [Context.MustParams] for other calls, like database lookups.
+//
+//	fuego.Get(s, "/recipes/{id}", func(c fuego.ContextNoBody) (Recipe, error) {
+//		recipe := fuego.Must(store.GetRecipe(c, c.PathParam("id")))
+//		return recipe, nil
+//	})
+func Must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// callController runs the controller, recovering the errors panicked by [Must], [Context.MustBody] and [Context.MustParams]
+// to return them. Runtime errors, aborted handlers and panics with other values are not recovered.
+func callController[B, T, P any](ctx Context[B, P], controller func(c Context[B, P]) (T, error)) (ans T, err error) {
+	defer func() {
+		recovered := recover()
+		if recovered == nil {
+			return
+		}
+		panicErr, ok := recovered.(error)
+		var runtimeErr runtime.Error
+		if !ok || errors.As(panicErr, &runtimeErr) || errors.Is(panicErr, http.ErrAbortHandler) {
+			panic(recovered)
+		}
+		err = panicErr
+	}()
+
+	return controller(ctx)
+}
@@ -0,0 +1,89 @@
+package fuego
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMust(t *testing.T) {
+	t.Run("returns the value", func(t *testing.T) {
+		require.Equal(t, 42, Must(strconv.Atoi("42")))
+	})
+
+	t.Run("panics with the error", func(t *testing.T) {
+		require.PanicsWithError(t, `strconv.Atoi: parsing "abc": invalid syntax`, func() {
+			Must(strconv.Atoi("abc"))
+		})
+	})
+}
+
+func TestMust_InController(t *testing.T) {
+	getRecipe := func(id string) (string, error) {
+		if id != "1" {
+			return "", NotFoundError{Detail: "recipe " + id + " not found"}
+		}
+		return "Pizza", nil
+	}
+
+	s := NewServer()
+	Get(s, "/recipes/{id}", func(c ContextNoBody) (string, error) {
+		return Must(getRecipe(c.PathParam("id"))), nil
+	})
+	Get(s, "/internal", func(c ContextNoBody) (string, error) {
+		return Must("", errors.New("connection refused")), nil
+	})
+	Get(s, "/nil-pointer", func(c ContextNoBody) (string, error) {
+		var recipe *struct{ Name string }
+		return recipe.Name, nil
+	})
+	Post(s, "/recipes", func(c ContextWithBody[struct {
+		Name string `json:"name" validate:"required"`
+	}]) (string, error) {
+		return c.MustBody().Name, nil
+	})
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, path, nil)
+		if body != "" {
+			r = httptest.NewRequest(method, path, strings.NewReader(body))
+			r.Header.Set("Content-Type", "application/json")
+		}
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("happy path", func(t *testing.T) {
+		w := serve(http.MethodGet, "/recipes/1", "")
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, w.Body.String(), "Pizza")
+	})
+
+	t.Run("typed errors keep their status", func(t *testing.T) {
+		w := serve(http.MethodGet, "/recipes/2", "")
+		require.Equal(t, http.StatusNotFound, w.Code)
+		require.Contains(t, w.Body.String(), "recipe 2 not found")
+	})
+
+	t.Run("other errors are 500", func(t *testing.T) {
+		w := serve(http.MethodGet, "/internal", "")
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("MustBody", func(t *testing.T) {
+		w := serve(http.MethodPost, "/recipes", `{}`)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("runtime errors are not recovered", func(t *testing.T) {
+		require.Panics(t, func() {
+			serve(http.MethodGet, "/nil-pointer", "")
+		})
+	})
+}
@@ -0,0 +1,87 @@
+package fuego
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"iter"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// ndjsonFlushInterval is the minimum duration between two flushes of an NDJSON stream, see [WriteNDJSON].
+const ndjsonFlushInterval = 50 * time.Millisecond
+
+// WriteNDJSON writes each item of the iterator as a line of JSON (newline-delimited JSON, "application/x-ndjson"),
+// easier for clients to consume incrementally than a JSON array. Items are read from the iterator
+// only once the previous one has been written, and the lines are flushed to the client periodically.
+// The status code, if not 0, is written with the first line: if an item cannot be serialized before,
+// the error is returned to be sent instead. Afterwards, the stream stops and the error is returned,
+// to be sent as a last line by the error handler.
+// If the context is done (client disconnected), the iteration stops and nil is returned.
+func WriteNDJSON(ctx context.Context, w http.ResponseWriter, status int, items iter.Seq[any]) error {
+	header := w.Header()
+	header.Set("Content-Type", "application/x-ndjson")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("X-Content-Type-Options", "nosniff")
+	header.Del("Content-Length")
+
+	controller := http.NewResponseController(w)
+	flush := func() error {
+		if err := controller.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return err
+		}
+		return nil
+	}
+
+	var line bytes.Buffer
+	encoder := json.NewEncoder(&line)
+	wroteHeader := false
+	var lastFlush time.Time
+	for item := range items {
+		if ctx.Err() != nil {
+			// The client is gone: nothing more can be sent.
+			return nil
+		}
+
+		line.Reset()
+		if err := encoder.Encode(item); err != nil {
+			slog.ErrorContext(ctx, "Cannot serialize NDJSON item", "error", err)
+			if !wroteHeader {
+				header.Del("Content-Type")
+			}
+			return err
+		}
+
+		if !wroteHeader {
+			wroteHeader = true
+			if status != 0 {
+				w.WriteHeader(status)
+			}
+		}
+		if _, err := w.Write(line.Bytes()); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		if time.Since(lastFlush) >= ndjsonFlushInterval {
+			if err := flush(); err != nil {
+				return err
+			}
+			lastFlush = time.Now()
+		}
+	}
+
+	if !wroteHeader && status != 0 {
+		w.WriteHeader(status)
+	}
+	return flush()
+}
+
+// StreamNDJSON writes each item of the iterator as a line of JSON to the response, see [WriteNDJSON].
+func (c netHttpContext[B, P]) StreamNDJSON(items iter.Seq[any]) error {
+	return WriteNDJSON(c.Req.Context(), c.Res, c.DefaultStatusCode, items)
+}
@@ -0,0 +1,114 @@
+package fuego
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func itemsOf(items ...any) iter.Seq[any] {
+	return func(yield func(any) bool) {
+		for _, item := range items {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+func TestStreamNDJSON(t *testing.T) {
+	type recipe struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	s := NewServer()
+	Get(s, "/recipes", func(c ContextNoBody) (any, error) {
+		return nil, c.StreamNDJSON(itemsOf(recipe{1, "pasta"}, recipe{2, "pizza\nmargherita"}, map[string]int{"total": 2}))
+	})
+	Get(s, "/broken-first", func(c ContextNoBody) (any, error) {
+		return nil, c.StreamNDJSON(itemsOf(make(chan int)))
+	})
+	Get(s, "/broken-later", func(c ContextNoBody) (any, error) {
+		return nil, c.StreamNDJSON(itemsOf(recipe{1, "pasta"}, make(chan int), recipe{3, "never sent"}))
+	})
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	t.Run("each line is valid JSON", func(t *testing.T) {
+		w := serve("/recipes")
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+		require.True(t, w.Flushed)
+
+		var lines []string
+		scanner := bufio.NewScanner(w.Body)
+		for scanner.Scan() {
+			require.True(t, json.Valid(scanner.Bytes()), scanner.Text())
+			lines = append(lines, scanner.Text())
+		}
+		require.Equal(t, []string{
+			`{"id":1,"name":"pasta"}`,
+			`{"id":2,"name":"pizza\nmargherita"}`,
+			`{"total":2}`,
+		}, lines)
+	})
+
+	t.Run("serialization error before the first line is sent instead", func(t *testing.T) {
+		w := serve("/broken-first")
+
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+		require.NotEqual(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	})
+
+	t.Run("serialization error mid-stream stops the stream", func(t *testing.T) {
+		w := serve("/broken-later")
+
+		require.Equal(t, http.StatusOK, w.Code)
+		lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+		require.Equal(t, `{"id":1,"name":"pasta"}`, lines[0])
+		require.NotContains(t, w.Body.String(), "never sent")
+		for _, line := range lines {
+			require.True(t, json.Valid([]byte(line)), line)
+		}
+	})
+
+	t.Run("client disconnected", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		produced := 0
+		items := func(yield func(any) bool) {
+			for i := range 100 {
+				produced++
+				if i == 2 {
+					cancel()
+				}
+				if !yield(i) {
+					return
+				}
+			}
+		}
+
+		w := httptest.NewRecorder()
+		require.NoError(t, WriteNDJSON(ctx, w, http.StatusOK, items))
+		require.Equal(t, "0\n1\n", w.Body.String())
+		require.Equal(t, 3, produced, "the producer stops")
+	})
+
+	t.Run("mock context without response", func(t *testing.T) {
+		c := NewMockContextNoBody()
+		require.NoError(t, c.StreamNDJSON(itemsOf(1, "two")))
+		require.Error(t, c.StreamNDJSON(itemsOf(make(chan int))))
+	})
+}
@@ -87,6 +87,10 @@ func Register[T, B, P any](s *Server, route Route[T, B, P], controller http.Hand
 	}
 	slog.Debug("registering controller " + fullPath)
 
+	if route.Name != "" {
+		s.registerRouteName(route.Name, route.Path)
+	}
+
 	route.Middlewares = append(s.middlewares, route.Middlewares...)
 	s.Mux.Handle(fullPath, withMiddlewares(controller, route.Middlewares...))
 
@@ -0,0 +1,60 @@
+package fuego
+
+import (
+	"fmt"
+	"sync"
+)
+
+// FinishCallbacks are the callbacks registered with [Context.OnFinish].
+// They are run by the router adaptors once the response is written, see [Flow].
+// The zero value is ready to use.
+type FinishCallbacks struct {
+	mu        sync.Mutex
+	callbacks []func(err error)
+}
+
+// Add registers a callback.
+func (f *FinishCallbacks) Add(fn func(err error)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.callbacks = append(f.callbacks, fn)
+}
+
+// Run runs the registered callbacks in reverse order of registration (LIFO), like deferred calls,
+// with the error of the request. Callbacks are only run once: the list is emptied.
+func (f *FinishCallbacks) Run(err error) {
+	f.mu.Lock()
+	callbacks := f.callbacks
+	f.callbacks = nil
+	f.mu.Unlock()
+
+	for i := len(callbacks) - 1; i >= 0; i-- {
+		callbacks[i](err)
+	}
+}
+
+// Finish runs the callbacks with the error of the request, and is meant to be deferred by the router adaptors.
+// If the handler panics, the callbacks are run with an error describing the panic, then the panic goes on.
+//
+//	var err error
+//	defer callbacks.Finish(&err)
+//	err = fuego.FlowWithError(engine, ctx, controller)
+func (f *FinishCallbacks) Finish(err *error) {
+	recovered := recover()
+	if recovered == nil {
+		f.Run(*err)
+		return
+	}
+
+	if panicErr, ok := recovered.(error); ok {
+		f.Run(fmt.Errorf("panic: %w", panicErr))
+	} else {
+		f.Run(fmt.Errorf("panic: %v", recovered))
+	}
+	panic(recovered)
+}
+
+// OnFinish registers a callback run after the response is written, see [FinishCallbacks].
+func (c netHttpContext[B, P]) OnFinish(fn func(err error)) {
+	c.finishCallbacks.Add(fn)
+}
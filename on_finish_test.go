@@ -0,0 +1,97 @@
+package fuego
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOnFinish(t *testing.T) {
+	t.Run("runs callbacks in LIFO order after the response is written", func(t *testing.T) {
+		var calls []string
+		var w *httptest.ResponseRecorder
+		s := NewServer()
+		Get(s, "/", func(c ContextNoBody) (string, error) {
+			c.OnFinish(func(err error) {
+				require.NoError(t, err)
+				require.Equal(t, "hello", w.Body.String())
+				calls = append(calls, "first")
+			})
+			c.OnFinish(func(err error) {
+				calls = append(calls, "second")
+			})
+			return "hello", nil
+		})
+
+		w = httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, []string{"second", "first"}, calls)
+	})
+
+	t.Run("receives the controller error", func(t *testing.T) {
+		var finishErr error
+		s := NewServer()
+		Get(s, "/", func(c ContextNoBody) (string, error) {
+			c.OnFinish(func(err error) { finishErr = err })
+			return "", BadRequestError{Title: "Nope"}
+		})
+
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		var badRequest BadRequestError
+		require.ErrorAs(t, finishErr, &badRequest)
+		require.Equal(t, "Nope", badRequest.Title)
+	})
+
+	t.Run("receives the error of a panicking controller", func(t *testing.T) {
+		var finishErr error
+		s := NewServer()
+		Get(s, "/", func(c ContextNoBody) (string, error) {
+			c.OnFinish(func(err error) { finishErr = err })
+			panic(errors.New("boom"))
+		})
+
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+		require.ErrorContains(t, finishErr, "boom")
+	})
+
+	t.Run("runs callbacks when the controller panics", func(t *testing.T) {
+		var finishErr error
+		s := NewServer()
+		Get(s, "/", func(c ContextNoBody) (string, error) {
+			c.OnFinish(func(err error) { finishErr = err })
+			var counts map[string]int
+			counts["recipes"]++ // assignment to entry in nil map
+			return "", nil
+		})
+
+		require.Panics(t, func() {
+			s.Mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		})
+		require.ErrorContains(t, finishErr, "panic: assignment to entry in nil map")
+		var runtimeErr runtime.Error
+		require.ErrorAs(t, finishErr, &runtimeErr)
+	})
+
+	t.Run("mock context", func(t *testing.T) {
+		var calls []int
+		c := NewMockContextNoBody()
+		c.OnFinish(func(error) { calls = append(calls, 1) })
+		c.OnFinish(func(error) { calls = append(calls, 2) })
+
+		c.Finish(nil)
+		c.Finish(nil)
+		require.Equal(t, []int{2, 1}, calls)
+	})
+}
@@ -166,6 +166,14 @@ func RegisterOpenAPIOperation[T, B, P any](openapi *OpenAPI, route Route[T, B, P
 		bodyTag := SchemaTagFromType(openapi, *new(B))
 
 		if bodyTag.Name != "unknown-interface" {
+			if route.BodyUnwrapKey != "" {
+				inner := bodyTag.SchemaRef
+				bodyTag.SchemaRef = openapi3.SchemaRef{
+					Value: openapi3.NewObjectSchema().
+						WithPropertyRef(route.BodyUnwrapKey, &inner).
+						WithRequired([]string{route.BodyUnwrapKey}),
+				}
+			}
 			requestBody := newRequestBody[B](bodyTag, route.RequestContentTypes)
 
 			// add request body to operation
@@ -261,11 +269,20 @@ func (route *Route[ResponseBody, RequestBody, Params]) RegisterParams() error {
 				params = append(params, ParamExample("example", example))
 			}
 
+			if defaultValue, ok := field.Tag.Lookup("default"); ok {
+				params = append(params, ParamDefault(typedParamDefault(defaultValue, field.Type.Kind())))
+			}
+			if field.Tag.Get("required") == "true" {
+				params = append(params, ParamRequired())
+			}
+
 			description, _ := field.Tag.Lookup("description")
 			if headerKey, ok := field.Tag.Lookup("header"); ok {
 				OptionHeader(headerKey, description, params...)(&route.BaseRoute)
 			}
 			if queryKey, ok := field.Tag.Lookup("query"); ok {
+				// Only the first name is documented, the other ones are aliases.
+				queryKey = queryParamAliases(queryKey)[0]
 				switch field.Type.Kind() {
 				case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
 					reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
@@ -278,6 +295,10 @@ func (route *Route[ResponseBody, RequestBody, Params]) RegisterParams() error {
 					OptionQuery(queryKey, description, params...)(&route.BaseRoute)
 				case reflect.Slice, reflect.Array:
 					OptionQueryArray(queryKey, description, field.Type.Elem().Kind(), params...)(&route.BaseRoute)
+				case reflect.Struct:
+					if isQueryParamsObject(field.Type) {
+						OptionQueryObject(queryKey, description, field.Type, params...)(&route.BaseRoute)
+					}
 				}
 			}
 			if cookieKey, ok := field.Tag.Lookup("cookie"); ok {
@@ -289,6 +310,26 @@ func (route *Route[ResponseBody, RequestBody, Params]) RegisterParams() error {
 	return nil
 }
 
+// typedParamDefault converts the default tag of a parameter to the type of its field, as expected by the OpenAPI spec.
+func typedParamDefault(defaultValue string, kind reflect.Kind) any {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if i, err := strconv.Atoi(defaultValue); err == nil {
+			return i
+		}
+	case reflect.Float32, reflect.Float64:
+		if f, err := strconv.ParseFloat(defaultValue, 64); err == nil {
+			return f
+		}
+	case reflect.Bool:
+		if b, err := strconv.ParseBool(defaultValue); err == nil {
+			return b
+		}
+	}
+	return defaultValue
+}
+
 func newRequestBody[RequestBody any](tag SchemaTag, consumes []string) *openapi3.RequestBody {
 	content := openapi3.NewContentWithSchemaRef(&tag.SchemaRef, consumes)
 	return openapi3.NewRequestBody().
@@ -118,6 +118,61 @@ func OptionQueryArray(name, description string, elemKind reflect.Kind, options .
 	}
 }
 
+// OptionQueryObject declares an object query parameter for the route, sent with the OpenAPI deepObject style.
+// Its properties are the exported fields of the given struct type, named like in [BindParams].
+// This will be added to the OpenAPI spec.
+// Example:
+//
+//	OptionQueryObject("range", "Price range", reflect.TypeFor[PriceRange]()) // ?range[min]=1&range[max]=10
+//
+// The list of options is in the param package.
+func OptionQueryObject(name, description string, objectType reflect.Type, options ...func(*OpenAPIParam)) func(*BaseRoute) {
+	return func(r *BaseRoute) {
+		param, openapiParam := buildParam(name, append(options, ParamDescription(description), paramType(QueryParamType))...)
+
+		objectSchema := openapi3.NewObjectSchema()
+		for i := range objectType.NumField() {
+			field := objectType.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			propertySchema := paramKindSchema(field.Type.Kind())
+			if field.Type.Kind() == reflect.Slice {
+				propertySchema = openapi3.NewArraySchema().WithItems(paramKindSchema(field.Type.Elem().Kind()))
+			}
+			objectSchema.WithProperty(queryObjectPropertyName(field), propertySchema)
+		}
+
+		explode := true
+		openapiParam.Schema = objectSchema.NewRef()
+		openapiParam.Style = openapi3.SerializationDeepObject
+		openapiParam.Explode = &explode
+
+		r.Operation.AddParameter(openapiParam)
+		if r.Params == nil {
+			r.Params = make(map[string]OpenAPIParam)
+		}
+		// The object is not sent as a single query param: its presence is checked when binding.
+		param.Required = false
+		r.Params[name] = param
+	}
+}
+
+// paramKindSchema returns the schema of a parameter of the given kind.
+func paramKindSchema(kind reflect.Kind) *openapi3.Schema {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return openapi3.NewIntegerSchema()
+	case reflect.Float32, reflect.Float64:
+		return openapi3.NewFloat64Schema()
+	case reflect.Bool:
+		return openapi3.NewBoolSchema()
+	default:
+		return openapi3.NewStringSchema()
+	}
+}
+
 // OptionHeader declares a header parameter for the route.
 // This will be added to the OpenAPI spec.
 // Example:
@@ -160,6 +160,9 @@ var Security = fuego.OptionSecurity
 // OperationID adds an operation ID to the route.
 var OperationID = fuego.OptionOperationID
 
+// Name names the route, to build its URL with [fuego.Server.NamedRouteURL] instead of hardcoding it.
+var Name = fuego.OptionName
+
 // Deprecated marks the route as deprecated.
 var Deprecated = fuego.OptionDeprecated
 
@@ -189,6 +192,10 @@ var DefaultResponse = fuego.OptionDefaultResponse
 // This will override any options set at the server level.
 var RequestContentType = fuego.OptionRequestContentType
 
+// RequestDecoder registers a decoder for the given content type on the route,
+// consulted before the built-in decoders. Useful for custom or versioned media types.
+var RequestDecoder = fuego.OptionRequestDecoder
+
 // Hide hides the route from the OpenAPI spec.
 var Hide = fuego.OptionHide
 
@@ -201,3 +208,11 @@ var DefaultStatusCode = fuego.OptionDefaultStatusCode
 // StripTrailingSlash removes the trailing slash from the route.
 // By default, the trailing slash is kept, so becauseful when registering route like "/" within a group.
 var StripTrailingSlash = fuego.OptionStripTrailingSlash
+
+// JSONSchema validates the raw JSON request body against the given schema before deserialization.
+// Requests that do not match are rejected with a 422 listing every violation.
+var JSONSchema = fuego.OptionJSONSchema
+
+// BodyUnwrap decodes the JSON request body from the object under the given key of an envelope, like {"data": {...}}.
+// Requests without the key are rejected with a 400.
+var BodyUnwrap = fuego.OptionBodyUnwrap
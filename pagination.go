@@ -0,0 +1,114 @@
+package fuego
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// defaultPerPage is the number of items per page when the per_page query parameter is missing and has no default.
+const defaultPerPage = 20
+
+// Pagination is the pagination metadata of a list response, see [Paginated].
+type Pagination struct {
+	Page       int `json:"page" xml:"page"`
+	PerPage    int `json:"per_page" xml:"per_page"`
+	Total      int `json:"total" xml:"total"`
+	TotalPages int `json:"total_pages" xml:"total_pages"`
+}
+
+// PaginatedResponse is the default shape of the responses of [Paginated].
+type PaginatedResponse struct {
+	Data       any        `json:"data" xml:"data"`
+	Pagination Pagination `json:"pagination" xml:"pagination"`
+}
+
+// PaginationEnvelope builds the response of [Paginated] from the items of the page and the pagination metadata.
+// Declared as a variable to be able to override it for APIs with another shape.
+//
+//	fuego.PaginationEnvelope = func(data any, p fuego.Pagination) any {
+//		return map[string]any{"items": data, "page": p.Page, "count": p.Total}
+//	}
+var PaginationEnvelope = func(data any, pagination Pagination) any {
+	return PaginatedResponse{Data: data, Pagination: pagination}
+}
+
+// PaginationCtx is the subset of [Context] needed to paginate a response, see [Paginated].
+type PaginationCtx interface {
+	QueryParam(name string) string
+	GetOpenAPIParams() map[string]OpenAPIParam
+	Request() *http.Request
+	SetHeader(key, value string)
+	SetLinkHeader(links map[string]string)
+}
+
+// PageParams returns the page, starting at 1, and the number of items per page requested
+// with the "page" and "per_page" query parameters. Missing parameters fall back to the defaults
+// declared for the route, then to page 1 and 20 items per page.
+// It returns a [BadRequestError] if a parameter is not a positive integer.
+// Use them to query the items, then send them with [Paginated].
+func PageParams(c PaginationCtx) (page, perPage int, err error) {
+	page, err = pageParam(c, "page", 1)
+	if err != nil {
+		return 0, 0, err
+	}
+	perPage, err = pageParam(c, "per_page", defaultPerPage)
+	return page, perPage, err
+}
+
+func pageParam(c PaginationCtx, name string, defaultValue int) (int, error) {
+	value := c.QueryParam(name)
+	if value == "" {
+		if routeDefault, ok := c.GetOpenAPIParams()[name].Default.(int); ok {
+			return routeDefault, nil
+		}
+		return defaultValue, nil
+	}
+
+	i, err := strconv.Atoi(value)
+	if err != nil || i < 1 {
+		return 0, BadRequestError{
+			Title:  "Invalid Parameter",
+			Err:    err,
+			Detail: "query param " + name + "=" + value + " must be a positive integer",
+		}
+	}
+	return i, nil
+}
+
+// Paginated sends the items of the requested page, see [PageParams], in the [PaginationEnvelope]
+// with the pagination metadata: {"data": [...], "pagination": {"page": 2, "per_page": 10, "total": 42, "total_pages": 5}}.
+// total is the number of items of the whole collection. It also sets the X-Total-Count header and the Link header
+// with the first, previous, next and last pages, see [PaginationLinks].
+//
+//	fuego.Get(s, "/pets", func(c fuego.ContextNoBody) (any, error) {
+//		page, perPage, err := fuego.PageParams(c)
+//		if err != nil {
+//			return nil, err
+//		}
+//		pets, total, err := store.ListPets(c, (page-1)*perPage, perPage)
+//		if err != nil {
+//			return nil, err
+//		}
+//		return fuego.Paginated(c, pets, total)
+//	})
+func Paginated[T any](c PaginationCtx, items []T, total int) (any, error) {
+	page, perPage, err := PageParams(c)
+	if err != nil {
+		return nil, err
+	}
+	if items == nil {
+		items = []T{}
+	}
+
+	c.SetHeader("X-Total-Count", strconv.Itoa(total))
+	if r := c.Request(); r != nil {
+		c.SetLinkHeader(PaginationLinks(r.URL, page, perPage, total))
+	}
+
+	return PaginationEnvelope(items, Pagination{
+		Page:       page,
+		PerPage:    perPage,
+		Total:      total,
+		TotalPages: (total + perPage - 1) / perPage,
+	}), nil
+}
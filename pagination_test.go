@@ -0,0 +1,85 @@
+package fuego
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaginated(t *testing.T) {
+	pets := make([]string, 42)
+	for i := range pets {
+		pets[i] = "pet" + string(rune('A'+i%26))
+	}
+
+	s := NewServer()
+	Get(s, "/pets", func(c ContextNoBody) (any, error) {
+		page, perPage, err := PageParams(c)
+		if err != nil {
+			return nil, err
+		}
+		start := min((page-1)*perPage, len(pets))
+		end := min(start+perPage, len(pets))
+		return Paginated(c, pets[start:end], len(pets))
+	})
+
+	get := func(t *testing.T, path string) *httptest.ResponseRecorder {
+		t.Helper()
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("metadata and headers", func(t *testing.T) {
+		w := get(t, "/pets?page=2&per_page=10&sort=name")
+
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.JSONEq(t, `{
+			"data": ["petK","petL","petM","petN","petO","petP","petQ","petR","petS","petT"],
+			"pagination": {"page": 2, "per_page": 10, "total": 42, "total_pages": 5}
+		}`, w.Body.String())
+		require.Equal(t, "42", w.Header().Get("X-Total-Count"))
+		require.Equal(t, `</pets?page=1&per_page=10&sort=name>; rel="first", </pets?page=5&per_page=10&sort=name>; rel="last", `+
+			`</pets?page=3&per_page=10&sort=name>; rel="next", </pets?page=1&per_page=10&sort=name>; rel="prev"`, w.Header().Get("Link"))
+	})
+
+	t.Run("last partial page", func(t *testing.T) {
+		w := get(t, "/pets?page=5&per_page=10")
+
+		require.JSONEq(t, `{"data":["petO","petP"],"pagination":{"page":5,"per_page":10,"total":42,"total_pages":5}}`, w.Body.String())
+		require.NotContains(t, w.Header().Get("Link"), `rel="next"`)
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		w := get(t, "/pets")
+
+		require.Contains(t, w.Body.String(), `"pagination":{"page":1,"per_page":20,"total":42,"total_pages":3}`)
+	})
+
+	t.Run("invalid page", func(t *testing.T) {
+		require.Equal(t, http.StatusBadRequest, get(t, "/pets?page=0").Code)
+		require.Equal(t, http.StatusBadRequest, get(t, "/pets?per_page=abc").Code)
+	})
+
+	t.Run("empty collection", func(t *testing.T) {
+		c := NewMockContextNoBody()
+		response, err := Paginated[string](c, nil, 0)
+		require.NoError(t, err)
+		require.Equal(t, PaginatedResponse{Data: []string{}, Pagination: Pagination{Page: 1, PerPage: 20}}, response)
+		require.Equal(t, "0", c.Headers.Get("X-Total-Count"))
+	})
+
+	t.Run("custom envelope", func(t *testing.T) {
+		defaultEnvelope := PaginationEnvelope
+		defer func() { PaginationEnvelope = defaultEnvelope }()
+		PaginationEnvelope = func(data any, p Pagination) any {
+			return map[string]any{"items": data, "count": p.Total}
+		}
+
+		w := get(t, "/pets?page=9&per_page=10")
+		require.JSONEq(t, `{"items":[],"count":42}`, w.Body.String())
+	})
+}
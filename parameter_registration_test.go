@@ -50,6 +50,21 @@ func Test_RegisterOpenAPIOperation(t *testing.T) {
 		assert.Equal(t, "headerParam", headerParam.Name)
 	})
 
+	t.Run("Register only the first name of aliased params", func(t *testing.T) {
+		route := NewRoute[struct{}, struct{}, struct {
+			PageSize int `query:"page_size,perPage,limit"`
+		}](
+			http.MethodGet,
+			"/aliases",
+			handler,
+			s.Engine,
+		)
+		err := route.RegisterParams()
+		require.NoError(t, err)
+		assert.Len(t, route.Operation.Parameters, 1)
+		assert.NotNil(t, route.Operation.Parameters.GetByInAndName("query", "page_size"))
+	})
+
 	t.Run("RegisterParams do not raise error with interface types", func(t *testing.T) {
 		route := NewRoute[struct{}, struct{}, any](
 			http.MethodGet,
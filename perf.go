@@ -1,7 +1,9 @@
 package fuego
 
 import (
+	"net/http"
 	"strconv"
+	"sync"
 	"time"
 )
 
@@ -21,3 +23,26 @@ func (t Timing) String() string {
 	}
 	return s
 }
+
+// StartTiming starts measuring a sub-operation, and returns a function that adds its duration
+// to the Server-Timing header when called. Calling the returned function more than once has no effect.
+// The name must be a valid token: no spaces, commas or semicolons.
+//
+//	stop := StartTiming(w.Header(), "db")
+//	recipes, err := db.ListRecipes(ctx)
+//	stop()
+//	// Server-Timing: db;dur=12
+func StartTiming(header http.Header, name string) func() {
+	start := time.Now()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			header.Add("Server-Timing", Timing{Name: name, Dur: time.Since(start)}.String())
+		})
+	}
+}
+
+// StartTiming starts measuring a sub-operation of the controller, see [StartTiming].
+func (c netHttpContext[B, P]) StartTiming(name string) func() {
+	return StartTiming(c.Res.Header(), name)
+}
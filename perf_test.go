@@ -1,6 +1,9 @@
 package fuego
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -27,3 +30,29 @@ func TestTiming_String(t *testing.T) {
 		require.Equal(t, "test;dur=300;desc=\"test desc\"", timing.String())
 	})
 }
+
+func TestStartTiming(t *testing.T) {
+	s := NewServer()
+	Get(s, "/recipes", func(c ContextNoBody) (string, error) {
+		stopDB := c.StartTiming("db")
+		time.Sleep(2 * time.Millisecond)
+		stopDB()
+		stopDB() // no effect
+
+		stopRender := c.StartTiming("render")
+		stopRender()
+		return "ok", nil
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/recipes", nil)
+	w := httptest.NewRecorder()
+	s.Mux.ServeHTTP(w, r)
+
+	var names []string
+	for _, timing := range w.Header().Values("Server-Timing") {
+		name, _, _ := strings.Cut(timing, ";")
+		names = append(names, name)
+	}
+	require.Equal(t, []string{"fuegoReqInit", "db", "render", "controller", "transformOut", "serialize"}, names)
+	require.NotEqual(t, "db;dur=0", w.Header().Values("Server-Timing")[1])
+}
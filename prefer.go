@@ -0,0 +1,50 @@
+package fuego
+
+import (
+	"net/http"
+	"strings"
+)
+
+// ParsePreferHeader parses the Prefer headers of a request (RFC 7240)
+// into a map of preference names (lowercased) to their values.
+// Preferences without value, like "respond-async", are mapped to an empty string.
+// Preference parameters (after ";") are ignored. If a preference is given twice, the first one wins.
+//
+//	Prefer: return=minimal, wait=10, respond-async
+//	-> map[return:minimal wait:10 respond-async:]
+func ParsePreferHeader(header http.Header) map[string]string {
+	preferences := make(map[string]string)
+	for _, line := range header.Values("Prefer") {
+		for preference := range strings.SplitSeq(line, ",") {
+			preference, _, _ = strings.Cut(preference, ";")
+			name, value, _ := strings.Cut(preference, "=")
+			name = strings.ToLower(strings.TrimSpace(name))
+			if name == "" {
+				continue
+			}
+			if _, exists := preferences[name]; exists {
+				continue
+			}
+			preferences[name] = strings.Trim(strings.TrimSpace(value), `"`)
+		}
+	}
+	return preferences
+}
+
+// Prefer returns the value of the given preference from the Prefer header of the request, and whether it was found.
+func (c netHttpContext[B, P]) Prefer(name string) (string, bool) {
+	value, ok := ParsePreferHeader(c.Req.Header)[strings.ToLower(name)]
+	return value, ok
+}
+
+// SetPreferenceApplied adds the given preference to the Preference-Applied response header.
+func (c netHttpContext[B, P]) SetPreferenceApplied(name, value string) {
+	c.Res.Header().Add("Preference-Applied", formatPreference(name, value))
+}
+
+func formatPreference(name, value string) string {
+	if value == "" {
+		return name
+	}
+	return name + "=" + value
+}
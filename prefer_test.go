@@ -0,0 +1,106 @@
+package fuego
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePreferHeader(t *testing.T) {
+	t.Run("parses multiple preferences", func(t *testing.T) {
+		header := http.Header{}
+		header.Add("Prefer", `return=minimal, wait=10, respond-async`)
+		header.Add("Prefer", `Handling=lenient; param=ignored, odata.track-changes`)
+
+		require.Equal(t, map[string]string{
+			"return":              "minimal",
+			"wait":                "10",
+			"respond-async":       "",
+			"handling":            "lenient",
+			"odata.track-changes": "",
+		}, ParsePreferHeader(header))
+	})
+
+	t.Run("unquotes values", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("Prefer", `return="representation"`)
+
+		require.Equal(t, map[string]string{"return": "representation"}, ParsePreferHeader(header))
+	})
+
+	t.Run("first preference wins", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("Prefer", `return=minimal, return=representation`)
+
+		require.Equal(t, map[string]string{"return": "minimal"}, ParsePreferHeader(header))
+	})
+
+	t.Run("no preference", func(t *testing.T) {
+		require.Empty(t, ParsePreferHeader(http.Header{}))
+	})
+}
+
+func TestContext_Prefer(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Prefer", "return=minimal, respond-async")
+	c := NewNetHTTPContext[any, any](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+
+	value, ok := c.Prefer("return")
+	require.True(t, ok)
+	require.Equal(t, "minimal", value)
+
+	value, ok = c.Prefer("Respond-Async")
+	require.True(t, ok)
+	require.Empty(t, value)
+
+	_, ok = c.Prefer("wait")
+	require.False(t, ok)
+}
+
+func TestContext_SetPreferenceApplied(t *testing.T) {
+	s := NewServer()
+	Post(s, "/recipes", func(c ContextWithBody[ans]) (any, error) {
+		body, err := c.Body()
+		if err != nil {
+			return nil, err
+		}
+		if ret, _ := c.Prefer("return"); ret == "minimal" {
+			c.SetPreferenceApplied("return", "minimal")
+			c.SetStatus(http.StatusNoContent)
+			return nil, nil
+		}
+		return body, nil
+	})
+
+	t.Run("returns a minimal response when preferred", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/recipes", strings.NewReader(`{"ans":"cake"}`))
+		r.Header.Set("Prefer", "return=minimal")
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusNoContent, w.Code)
+		require.Equal(t, "return=minimal", w.Header().Get("Preference-Applied"))
+		require.Empty(t, w.Body.String())
+	})
+
+	t.Run("returns the representation otherwise", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/recipes", strings.NewReader(`{"ans":"cake"}`))
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Empty(t, w.Header().Get("Preference-Applied"))
+		require.JSONEq(t, `{"ans":"cake"}`, w.Body.String())
+	})
+
+	t.Run("preference without value", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c := NewNetHTTPContext[any, any](BaseRoute{}, w, httptest.NewRequest("GET", "/", nil), readOptions{})
+		c.SetPreferenceApplied("respond-async", "")
+
+		require.Equal(t, "respond-async", w.Header().Get("Preference-Applied"))
+	})
+}
@@ -0,0 +1,187 @@
+package fuego
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"reflect"
+)
+
+// ProtoMessage is implemented by protobuf messages generated with protoc-gen-go.
+// Fuego does not depend on the protobuf module: it only checks that the body
+// or the response is a protobuf message and delegates the encoding to the [ProtoCodec].
+type ProtoMessage interface {
+	ProtoMessage()
+}
+
+// ProtoCodec marshals and unmarshals protobuf messages.
+// Use [WithProtoCodec] to plug an implementation, for example
+// [github.com/go-fuego/fuego/extra/fuegoproto], which is based on google.golang.org/protobuf.
+type ProtoCodec interface {
+	Marshal(ProtoMessage) ([]byte, error)
+	Unmarshal([]byte, ProtoMessage) error
+}
+
+// WithProtoCodec sets the codec used to read "application/x-protobuf" request bodies
+// and to send "application/x-protobuf" responses.
+// Without a codec, only []byte bodies and responses are supported.
+//
+//	app := fuego.NewServer(
+//		fuego.WithProtoCodec(fuegoproto.Codec{}),
+//	)
+func WithProtoCodec(codec ProtoCodec) func(*Server) {
+	return func(s *Server) { s.protoCodec = codec }
+}
+
+// WithProtoJSONCodec sets the codec used to read and send the protobuf messages as JSON,
+// following the canonical proto3 JSON mapping: "application/json" request bodies are decoded with it
+// when the body type is a protobuf message, and protobuf message responses are sent with it as JSON.
+// encoding/json does not follow this mapping, for example for the well-known types like Timestamp,
+// sent as "2024-01-02T03:04:05Z" instead of {"seconds":1704164645}.
+// Other types are still read and sent with encoding/json.
+//
+//	app := fuego.NewServer(
+//		fuego.WithProtoJSONCodec(fuegoproto.JSONCodec{}),
+//	)
+func WithProtoJSONCodec(codec ProtoCodec) func(*Server) {
+	return func(s *Server) { s.protoJSONCodec = codec }
+}
+
+// newProtoMessage initializes the body and returns the protobuf message to decode into,
+// which is the body itself or a pointer to it. ok is false if B is not a protobuf message.
+func newProtoMessage[B any](body *B) (message ProtoMessage, ok bool) {
+	// Generated messages are pointers: allocate the underlying struct.
+	if t := reflect.TypeFor[B](); t.Kind() == reflect.Ptr {
+		*body = reflect.New(t.Elem()).Interface().(B)
+	}
+
+	message, ok = any(*body).(ProtoMessage)
+	if !ok {
+		message, ok = any(body).(ProtoMessage)
+	}
+	return message, ok
+}
+
+// isProtoMessageType reports whether B is a protobuf message, or a pointer to one.
+func isProtoMessageType[B any]() bool {
+	t := reflect.TypeFor[B]()
+	protoMessageType := reflect.TypeFor[ProtoMessage]()
+	return t.Implements(protoMessageType) || reflect.PointerTo(t).Implements(protoMessageType)
+}
+
+// readProtoJSON reads the JSON request body into a protobuf message with the [WithProtoJSONCodec] codec.
+func readProtoJSON[B any](ctx context.Context, input io.Reader, options readOptions) (B, error) {
+	var body B
+	message, _ := newProtoMessage(&body)
+
+	readBody, err := io.ReadAll(input)
+	if err != nil {
+		return body, BadRequestError{
+			Err:    err,
+			Detail: "cannot read request body: " + err.Error(),
+		}
+	}
+
+	err = options.ProtoJSONCodec.Unmarshal(readBody, message)
+	if err != nil {
+		return body, BadRequestError{
+			Title:  "Decoding Failed",
+			Err:    err,
+			Detail: "cannot decode request body: " + err.Error(),
+		}
+	}
+	slog.DebugContext(ctx, "Decoded body", "body", body)
+
+	return TransformAndValidate(ctx, body)
+}
+
+// ReadProto reads the request body as protobuf.
+// Customizable by modifying ReadOptions, protobuf messages need its ProtoCodec.
+func ReadProto[B any](ctx context.Context, input io.Reader) (B, error) {
+	return readProto[B](ctx, input, ReadOptions)
+}
+
+// readProto reads the request body as protobuf.
+// B must be a []byte (raw passthrough) or a protobuf message (pointer or not).
+func readProto[B any](ctx context.Context, input io.Reader, options readOptions) (B, error) {
+	var body B
+
+	readBody, err := io.ReadAll(input)
+	if err != nil {
+		return body, BadRequestError{
+			Err:    err,
+			Detail: "cannot read request body: " + err.Error(),
+		}
+	}
+
+	if raw, ok := any(readBody).(B); ok {
+		return raw, nil
+	}
+
+	message, ok := newProtoMessage(&body)
+	if !ok {
+		return body, BadRequestError{
+			Title:  "Decoding Failed",
+			Err:    fmt.Errorf("type %T is not a protobuf message", body),
+			Detail: "cannot decode protobuf request body into this endpoint body type",
+		}
+	}
+
+	if options.ProtoCodec == nil {
+		return body, InternalServerError{
+			Err:    errors.New("no protobuf codec configured, please use fuego.WithProtoCodec"),
+			Detail: "cannot decode protobuf request body",
+		}
+	}
+
+	err = options.ProtoCodec.Unmarshal(readBody, message)
+	if err != nil {
+		return body, BadRequestError{
+			Title:  "Decoding Failed",
+			Err:    err,
+			Detail: "cannot decode request body: " + err.Error(),
+		}
+	}
+	slog.DebugContext(ctx, "Decoded body", "body", body)
+
+	return TransformAndValidate(ctx, body)
+}
+
+// SendProto sends a protobuf response.
+// The response must be a []byte (sent as is) or a protobuf message,
+// encoded with the codec of the server, see [WithProtoCodec].
+// Declared as a variable to be able to override it for clients that need to customize serialization.
+// If serialization fails, it does NOT write to the response writer.
+var SendProto = func(w http.ResponseWriter, r *http.Request, ans any) error {
+	var data []byte
+	switch v := ans.(type) {
+	case []byte:
+		data = v
+	case ProtoMessage:
+		protoCodec := serializeOptionsFrom(r).ProtoCodec
+		if protoCodec == nil {
+			return NotAcceptableError{
+				Err:    errors.New("no protobuf codec configured, please use fuego.WithProtoCodec"),
+				Detail: "Cannot serialize returned response to protobuf",
+			}
+		}
+		var err error
+		data, err = protoCodec.Marshal(v)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Cannot serialize returned response to protobuf", "error", err)
+			return err
+		}
+	default:
+		return NotAcceptableError{
+			Err:    fmt.Errorf("type %T is not a protobuf message", ans),
+			Detail: fmt.Sprintf("Cannot serialize type %T to protobuf", ans),
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	_, err := w.Write(data)
+	return err
+}
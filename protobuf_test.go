@@ -0,0 +1,150 @@
+package fuego
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// mockProtoMessage mimics a message generated by protoc-gen-go.
+type mockProtoMessage struct {
+	Name string
+}
+
+func (*mockProtoMessage) ProtoMessage() {}
+
+// mockProtoCodec encodes a [mockProtoMessage] as "name:<Name>".
+type mockProtoCodec struct{}
+
+func (mockProtoCodec) Marshal(m ProtoMessage) ([]byte, error) {
+	return []byte("name:" + m.(*mockProtoMessage).Name), nil
+}
+
+func (mockProtoCodec) Unmarshal(data []byte, m ProtoMessage) error {
+	name, ok := strings.CutPrefix(string(data), "name:")
+	if !ok {
+		return errors.New("invalid wire format")
+	}
+	m.(*mockProtoMessage).Name = name
+	return nil
+}
+
+// mockProtoOptions are the read options of a server using the mock codec.
+var mockProtoOptions = readOptions{ProtoCodec: mockProtoCodec{}}
+
+// newMockProtoRequest returns a request served by a server using the mock codec.
+func newMockProtoRequest() *http.Request {
+	return withSerializeOptions(httptest.NewRequest("GET", "/", nil), serializeOptions{ProtoCodec: mockProtoCodec{}})
+}
+
+func TestReadProto(t *testing.T) {
+	t.Run("can read a pointer message", func(t *testing.T) {
+		body, err := readProto[*mockProtoMessage](context.Background(), strings.NewReader("name:fuego"), mockProtoOptions)
+		require.NoError(t, err)
+		require.Equal(t, "fuego", body.Name)
+	})
+
+	t.Run("can read a non-pointer message", func(t *testing.T) {
+		body, err := readProto[mockProtoMessage](context.Background(), strings.NewReader("name:fuego"), mockProtoOptions)
+		require.NoError(t, err)
+		require.Equal(t, "fuego", body.Name)
+	})
+
+	t.Run("passes raw bytes through", func(t *testing.T) {
+		body, err := ReadProto[[]byte](context.Background(), strings.NewReader("\x0a\x05fuego"))
+		require.NoError(t, err)
+		require.Equal(t, []byte("\x0a\x05fuego"), body)
+	})
+
+	t.Run("cannot read invalid wire format", func(t *testing.T) {
+		_, err := readProto[*mockProtoMessage](context.Background(), strings.NewReader("garbage"), mockProtoOptions)
+		require.ErrorAs(t, err, &BadRequestError{})
+	})
+
+	t.Run("cannot read into a non-protobuf type", func(t *testing.T) {
+		_, err := readProto[testStruct](context.Background(), strings.NewReader("name:fuego"), mockProtoOptions)
+		require.ErrorAs(t, err, &BadRequestError{})
+	})
+
+	t.Run("cannot read a message without codec", func(t *testing.T) {
+		_, err := ReadProto[*mockProtoMessage](context.Background(), strings.NewReader("name:fuego"))
+		require.ErrorAs(t, err, &InternalServerError{})
+	})
+}
+
+func TestSendProto(t *testing.T) {
+	t.Run("can send a message", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		err := SendProto(w, newMockProtoRequest(), &mockProtoMessage{Name: "fuego"})
+		require.NoError(t, err)
+		require.Equal(t, "application/x-protobuf", w.Header().Get("Content-Type"))
+		require.Equal(t, "name:fuego", w.Body.String())
+	})
+
+	t.Run("passes raw bytes through", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		err := SendProto(w, httptest.NewRequest("GET", "/", nil), []byte("\x0a\x05fuego"))
+		require.NoError(t, err)
+		require.Equal(t, "\x0a\x05fuego", w.Body.String())
+	})
+
+	t.Run("cannot send a message without codec", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		err := SendProto(w, httptest.NewRequest("GET", "/", nil), &mockProtoMessage{Name: "fuego"})
+		require.ErrorAs(t, err, &NotAcceptableError{})
+		require.Empty(t, w.Body.String())
+	})
+
+	t.Run("cannot send a non-protobuf type", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		err := SendProto(w, httptest.NewRequest("GET", "/", nil), testStruct{Name: "fuego"})
+		require.ErrorAs(t, err, &NotAcceptableError{})
+		require.Empty(t, w.Body.String())
+	})
+}
+
+func TestProtoRoundTrip(t *testing.T) {
+	s := NewServer(WithProtoCodec(mockProtoCodec{}))
+
+	Post(s, "/proto", func(c ContextWithBody[*mockProtoMessage]) (*mockProtoMessage, error) {
+		body, err := c.Body()
+		if err != nil {
+			return nil, err
+		}
+		return &mockProtoMessage{Name: "hello " + body.Name}, nil
+	})
+
+	t.Run("decodes and encodes protobuf", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/proto", bytes.NewBufferString("name:fuego"))
+		r.Header.Set("Content-Type", "application/x-protobuf")
+		r.Header.Set("Accept", "application/x-protobuf")
+		w := httptest.NewRecorder()
+
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "application/x-protobuf", w.Header().Get("Content-Type"))
+		require.Equal(t, "name:hello fuego", w.Body.String())
+	})
+
+	t.Run("falls back to JSON when protobuf is not accepted", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/proto", bytes.NewBufferString("name:fuego"))
+		r.Header.Set("Content-Type", "application/x-protobuf")
+		w := httptest.NewRecorder()
+
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.JSONEq(t, `{"Name":"hello fuego"}`, w.Body.String())
+	})
+}
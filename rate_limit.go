@@ -0,0 +1,69 @@
+package fuego
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RateLimiter decides if a request identified by the given key is allowed, see [WithRateLimiter].
+// When denied, it returns the delay after which the client can retry.
+// The key is typically the IP address of the connection, or an API key.
+// Do not use [Context.RemoteIP] unless a trusted proxy overwrites the forwarding headers, as clients can set them.
+type RateLimiter interface {
+	Allow(key string) (bool, time.Duration)
+}
+
+// RateLimiterFunc is an adapter to use a function as a [RateLimiter].
+type RateLimiterFunc func(key string) (bool, time.Duration)
+
+var _ RateLimiter = RateLimiterFunc(nil)
+
+// Allow calls f(key).
+func (f RateLimiterFunc) Allow(key string) (bool, time.Duration) {
+	return f(key)
+}
+
+// WithRateLimiter sets the rate limiter used by [Context.CheckRateLimit],
+// for handler-level rate limiting without external middleware:
+//
+//	fuego.Get(s, "/search", func(c fuego.ContextNoBody) ([]Result, error) {
+//		ip, _, _ := net.SplitHostPort(c.Request().RemoteAddr)
+//		if err := c.CheckRateLimit(ip); err != nil {
+//			return nil, err
+//		}
+//		...
+//	})
+func WithRateLimiter(limiter RateLimiter) func(*Server) {
+	return func(s *Server) { s.rateLimiter = limiter }
+}
+
+// checkRateLimit checks the key against the limiter, and sets the Retry-After header when denied.
+// All requests are allowed without limiter.
+func checkRateLimit(limiter RateLimiter, header http.Header, key string) error {
+	if limiter == nil {
+		return nil
+	}
+
+	allowed, retryAfter := limiter.Allow(key)
+	if allowed {
+		return nil
+	}
+
+	detail := "rate limit exceeded"
+	if retryAfter > 0 {
+		seconds := strconv.Itoa(int(math.Ceil(retryAfter.Seconds())))
+		header.Set("Retry-After", seconds)
+		detail += ", retry after " + seconds + " seconds"
+	}
+	return RetryableError{
+		Title:  "Too Many Requests",
+		Detail: detail,
+	}
+}
+
+// CheckRateLimit returns a [RetryableError] if the rate limiter denies the given key.
+func (c netHttpContext[B, P]) CheckRateLimit(key string) error {
+	return checkRateLimit(c.rateLimiter, c.Res.Header(), key)
+}
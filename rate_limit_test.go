@@ -0,0 +1,75 @@
+package fuego
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// countingLimiter allows max calls per key.
+type countingLimiter struct {
+	mu    sync.Mutex
+	max   int
+	calls map[string]int
+}
+
+func (l *countingLimiter) Allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.calls[key]++
+	return l.calls[key] <= l.max, 1500 * time.Millisecond
+}
+
+func TestCheckRateLimit(t *testing.T) {
+	s := NewServer(WithRateLimiter(&countingLimiter{max: 2, calls: map[string]int{}}))
+	Get(s, "/search", func(c ContextNoBody) (string, error) {
+		if err := c.CheckRateLimit(c.Header("X-API-Key")); err != nil {
+			return "", err
+		}
+		return "results", nil
+	})
+
+	search := func(apiKey string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/search", nil)
+		r.Header.Set("X-API-Key", apiKey)
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+		return w
+	}
+
+	for range 2 {
+		w := search("key-1")
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "results", w.Body.String())
+		require.Empty(t, w.Header().Get("Retry-After"))
+	}
+
+	w := search("key-1")
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	require.Equal(t, "2", w.Header().Get("Retry-After"))
+	require.Contains(t, w.Body.String(), "Too Many Requests")
+
+	t.Run("keys are limited independently", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, search("key-2").Code)
+	})
+
+	t.Run("allowed without limiter", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		c := NewNetHTTPContext[any, any](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+
+		require.NoError(t, c.CheckRateLimit("key"))
+	})
+
+	t.Run("without retry delay", func(t *testing.T) {
+		c := NewMockContextNoBody()
+		c.RateLimiter = RateLimiterFunc(func(key string) (bool, time.Duration) { return false, 0 })
+
+		err := c.CheckRateLimit("key")
+		require.ErrorAs(t, err, &RetryableError{})
+		require.Empty(t, c.Headers.Get("Retry-After"))
+	})
+}
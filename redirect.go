@@ -0,0 +1,40 @@
+package fuego
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// MergeQuery adds the query parameters to the target URL. Parameters already set in the target are kept as is,
+// the others are added with all their values. The fragment of the target is preserved.
+//
+//	MergeQuery("/login?lang=fr#form", url.Values{"next": {"/cart"}, "lang": {"en"}})
+//	// "/login?lang=fr&next=%2Fcart#form"
+func MergeQuery(target string, query url.Values) (string, error) {
+	u, err := url.Parse(target)
+	if err != nil {
+		return "", err
+	}
+	if len(query) == 0 {
+		return target, nil
+	}
+
+	merged := u.Query()
+	for name, values := range query {
+		if _, exists := merged[name]; !exists {
+			merged[name] = values
+		}
+	}
+	u.RawQuery = merged.Encode()
+	return u.String(), nil
+}
+
+// RedirectPreserveQuery redirects to the given path, keeping the query parameters of the request, see [MergeQuery].
+func (c netHttpContext[B, P]) RedirectPreserveQuery(code int, path string) (any, error) {
+	location, err := MergeQuery(path, c.Req.URL.Query())
+	if err != nil {
+		return nil, BadRequestError{Title: "Invalid Redirect", Err: err, Detail: "cannot redirect to " + path}
+	}
+	http.Redirect(c.Res, c.Req, location, code)
+	return nil, nil
+}
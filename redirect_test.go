@@ -0,0 +1,48 @@
+package fuego
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeQuery(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		target   string
+		query    url.Values
+		expected string
+	}{
+		{"adds the query", "/login", url.Values{"next": {"/cart"}}, "/login?next=%2Fcart"},
+		{"keeps the target values", "/login?lang=fr", url.Values{"lang": {"en"}, "next": {"/cart"}}, "/login?lang=fr&next=%2Fcart"},
+		{"multiple values", "/search", url.Values{"tag": {"a", "b"}}, "/search?tag=a&tag=b"},
+		{"keeps the fragment", "https://example.com/login#form", url.Values{"next": {"/"}}, "https://example.com/login?next=%2F#form"},
+		{"no query", "/login?lang=fr", nil, "/login?lang=fr"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			location, err := MergeQuery(tc.target, tc.query)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, location)
+		})
+	}
+
+	_, err := MergeQuery("http://[::1", url.Values{"next": {"/"}})
+	require.Error(t, err)
+}
+
+func TestRedirectPreserveQuery(t *testing.T) {
+	s := NewServer()
+	Get(s, "/account", func(c ContextNoBody) (any, error) {
+		return c.RedirectPreserveQuery(http.StatusSeeOther, "/login?next=/account&lang=fr")
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/account?next=/cart&utm_source=mail", nil)
+	w := httptest.NewRecorder()
+	s.Mux.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusSeeOther, w.Code)
+	require.Equal(t, "/login?lang=fr&next=%2Faccount&utm_source=mail", w.Header().Get("Location"))
+}
@@ -0,0 +1,23 @@
+package fuego
+
+// OK returns the given data with a nil error.
+// It makes the intent of a controller explicit and helps type inference
+// in return statements, without repeating the response type:
+//
+//	fuego.Get(s, "/recipes/{id}", func(c fuego.ContextNoBody) (Recipe, error) {
+//		recipe, err := store.GetRecipe(c.PathParam("id"))
+//		if err != nil {
+//			return fuego.Fail[Recipe](err)
+//		}
+//		return fuego.OK(recipe)
+//	})
+func OK[T any](data T) (T, error) {
+	return data, nil
+}
+
+// Fail returns the zero value of T with the given error.
+// See [OK].
+func Fail[T any](err error) (T, error) {
+	var zero T
+	return zero, err
+}
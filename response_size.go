@@ -0,0 +1,134 @@
+package fuego
+
+import (
+	"bufio"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+)
+
+// ResponseSizeLimit configures the maximum size of response bodies, see [WithResponseSizeLimit].
+type ResponseSizeLimit struct {
+	// Maximum number of bytes written in a response body. 0 means no limit.
+	MaxBytes int64
+	// If true, responses exceeding MaxBytes are only logged, not truncated.
+	LogOnly bool
+}
+
+// WithResponseSizeLimit limits the number of bytes written in each response body,
+// to catch runaway serializations.
+// If nothing has been sent yet, the response is replaced by a 500 error.
+// Otherwise, as the headers are already sent, the response is truncated.
+// In both cases, an error is logged. Set [ResponseSizeLimit.LogOnly] to only log.
+//
+//	s := fuego.NewServer(
+//		fuego.WithResponseSizeLimit(fuego.ResponseSizeLimit{MaxBytes: 10 << 20}), // 10 MB
+//	)
+func WithResponseSizeLimit(limit ResponseSizeLimit) func(*Server) {
+	return func(c *Server) { c.responseSizeLimit = limit }
+}
+
+// responseSizeWriter wraps [http.ResponseWriter] to count the bytes written
+// in the response body, and enforce the [ResponseSizeLimit].
+type responseSizeWriter struct {
+	http.ResponseWriter
+	r           *http.Request
+	limit       ResponseSizeLimit
+	written     int64
+	wroteHeader bool
+	exceeded    bool
+}
+
+func newResponseSizeWriter(w http.ResponseWriter, r *http.Request, limit ResponseSizeLimit) *responseSizeWriter {
+	return &responseSizeWriter{ResponseWriter: w, r: r, limit: limit}
+}
+
+func (w *responseSizeWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+	// Informational responses are sent before the final response.
+	if code < http.StatusOK {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *responseSizeWriter) Write(b []byte) (int, error) {
+	if w.limit.MaxBytes == 0 || w.written+int64(len(b)) <= w.limit.MaxBytes {
+		w.wroteHeader = true
+		n, err := w.ResponseWriter.Write(b)
+		w.written += int64(n)
+		return n, err
+	}
+
+	if !w.exceeded {
+		w.exceeded = true
+		slog.ErrorContext(w.r.Context(), "Response exceeds the maximum size",
+			"max_bytes", w.limit.MaxBytes,
+			"method", w.r.Method,
+			"path", w.r.URL.Path,
+			"truncated", !w.limit.LogOnly,
+		)
+	}
+
+	if w.limit.LogOnly {
+		w.wroteHeader = true
+		n, err := w.ResponseWriter.Write(b)
+		w.written += int64(n)
+		return n, err
+	}
+
+	err := HTTPError{
+		Err:    fmt.Errorf("response exceeds the maximum size of %d bytes", w.limit.MaxBytes),
+		Status: http.StatusInternalServerError,
+		Title:  "Response Too Large",
+	}
+
+	// Nothing sent yet: the error can still be sent instead.
+	if !w.wroteHeader && w.written == 0 {
+		return 0, err
+	}
+
+	// Headers already sent: truncate.
+	n, errWrite := w.ResponseWriter.Write(b[:w.limit.MaxBytes-w.written])
+	w.written += int64(n)
+	if errWrite != nil {
+		return n, errWrite
+	}
+	return n, err
+}
+
+func (w *responseSizeWriter) Flush() {
+	flusher, ok := w.ResponseWriter.(http.Flusher)
+	if !ok {
+		return
+	}
+	// Flushing sends the headers.
+	w.wroteHeader = true
+	flusher.Flush()
+}
+
+func (w *responseSizeWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+	return hijacker.Hijack()
+}
+
+// Unwrap returns the underlying [http.ResponseWriter], for [http.ResponseController].
+func (w *responseSizeWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// BytesWritten returns the number of bytes written in the response body so far.
+func (c netHttpContext[B, P]) BytesWritten() int64 {
+	if w, ok := c.Res.(*responseSizeWriter); ok {
+		return w.written
+	}
+	return 0
+}
@@ -0,0 +1,87 @@
+package fuego
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContext_BytesWritten(t *testing.T) {
+	s := NewServer()
+	var written int64
+	Get(s, "/stream", func(c ContextNoBody) (any, error) {
+		_, err := c.Response().Write([]byte("hello"))
+		written = c.BytesWritten()
+		return nil, err
+	})
+
+	w := httptest.NewRecorder()
+	s.Mux.ServeHTTP(w, httptest.NewRequest("GET", "/stream", nil))
+
+	require.Equal(t, int64(5), written)
+	require.Equal(t, "hello", w.Body.String())
+}
+
+func TestWithResponseSizeLimit(t *testing.T) {
+	bigResponse := strings.Repeat("a", 100)
+
+	t.Run("response under the limit", func(t *testing.T) {
+		s := NewServer(WithResponseSizeLimit(ResponseSizeLimit{MaxBytes: 200}))
+		Get(s, "/big", func(c ContextNoBody) (ans, error) {
+			return ans{Ans: bigResponse}, nil
+		})
+
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest("GET", "/big", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, w.Body.String(), bigResponse)
+	})
+
+	t.Run("response exceeding the limit is replaced by an error", func(t *testing.T) {
+		s := NewServer(WithResponseSizeLimit(ResponseSizeLimit{MaxBytes: 50}))
+		Get(s, "/big", func(c ContextNoBody) (ans, error) {
+			return ans{Ans: bigResponse}, nil
+		})
+
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest("GET", "/big", nil))
+
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+		require.NotContains(t, w.Body.String(), bigResponse)
+	})
+
+	t.Run("response exceeding the limit after headers are sent is truncated", func(t *testing.T) {
+		s := NewServer(WithResponseSizeLimit(ResponseSizeLimit{MaxBytes: 50}))
+		var errWrite error
+		Get(s, "/stream", func(c ContextNoBody) (any, error) {
+			c.SetStatus(http.StatusOK)
+			_, errWrite = c.Response().Write([]byte(bigResponse))
+			require.Equal(t, int64(50), c.BytesWritten())
+			return nil, nil
+		})
+
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest("GET", "/stream", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, bigResponse[:50], w.Body.String())
+		require.ErrorAs(t, errWrite, &HTTPError{})
+	})
+
+	t.Run("log only", func(t *testing.T) {
+		s := NewServer(WithResponseSizeLimit(ResponseSizeLimit{MaxBytes: 50, LogOnly: true}))
+		Get(s, "/big", func(c ContextNoBody) (ans, error) {
+			return ans{Ans: bigResponse}, nil
+		})
+
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest("GET", "/big", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, w.Body.String(), bigResponse)
+	})
+}
@@ -0,0 +1,87 @@
+package fuego
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOK(t *testing.T) {
+	t.Run("returns the data without error", func(t *testing.T) {
+		data, err := OK(ans{Ans: "hello"})
+		require.NoError(t, err)
+		require.Equal(t, ans{Ans: "hello"}, data)
+	})
+
+	t.Run("infers pointer types", func(t *testing.T) {
+		data, err := OK(&ans{Ans: "hello"})
+		require.NoError(t, err)
+		require.Equal(t, "hello", data.Ans)
+	})
+}
+
+func TestFail(t *testing.T) {
+	t.Run("returns the zero value with the error", func(t *testing.T) {
+		data, err := Fail[ans](NotFoundError{Title: "not found"})
+		require.ErrorAs(t, err, &NotFoundError{})
+		require.Equal(t, ans{}, data)
+	})
+
+	t.Run("returns nil for pointer types", func(t *testing.T) {
+		data, err := Fail[*ans](errors.New("error"))
+		require.Error(t, err)
+		require.Nil(t, data)
+	})
+}
+
+func TestOKAndFailInController(t *testing.T) {
+	s := NewServer()
+	route := Get(s, "/test/{id}", func(c ContextNoBody) (ans, error) {
+		if c.PathParam("id") != "1" {
+			return Fail[ans](NotFoundError{Title: "not found"})
+		}
+		return OK(ans{Ans: "found"})
+	})
+
+	// The response type is still inferred for the OpenAPI spec
+	require.NotNil(t, route.Operation.Responses.Value("200").Value.Content["application/json"].Schema)
+
+	t.Run("ok", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/test/1", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.JSONEq(t, `{"ans":"found"}`, w.Body.String())
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/test/2", nil))
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func ExampleOK() {
+	s := NewServer()
+	Get(s, "/recipes/{id}", func(c ContextNoBody) (ans, error) {
+		if c.PathParam("id") == "" {
+			return Fail[ans](BadRequestError{Title: "missing id"})
+		}
+		return OK(ans{Ans: "recipe " + c.PathParam("id")})
+	})
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/recipes/42", nil)
+
+	s.Mux.ServeHTTP(w, r)
+
+	fmt.Print(w.Body.String())
+
+	// Output:
+	// {"ans":"recipe 42"}
+}
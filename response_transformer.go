@@ -0,0 +1,51 @@
+package fuego
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+)
+
+// ResponseTransformerContext is the part of the [Context] available to response transformers,
+// whatever the body and params types of the route, see [RegisterResponseTransformer].
+type ResponseTransformerContext interface {
+	context.Context
+
+	Request() *http.Request        // Request returns the underlying HTTP request.
+	Response() http.ResponseWriter // Response returns the underlying HTTP response writer.
+	PathParam(name string) string
+	QueryParam(name string) string
+	Header(key string) string    // Get request header
+	SetHeader(key, value string) // Sets response header
+}
+
+// responseTransformer transforms the value returned by a controller, before serialization.
+type responseTransformer func(ans any, c ResponseTransformerContext) (any, error)
+
+// RegisterResponseTransformer registers a function applied to every value of type T returned by a controller,
+// before serialization. The returned value is serialized instead, useful for cross-cutting concerns
+// such as adding computed fields to all the responses of a type.
+// The type must match exactly: a transformer for User is not applied to *User nor []User.
+// It runs after the [OutTransformer] of the value, if any. Registering a transformer for the same type replaces it.
+// Must be called before the server starts.
+//
+//	fuego.RegisterResponseTransformer(s.Engine, func(user User, c fuego.ResponseTransformerContext) (any, error) {
+//		return UserWithAvatar{User: user, Avatar: avatarURL(user.ID)}, nil
+//	})
+func RegisterResponseTransformer[T any](e *Engine, fn func(T, ResponseTransformerContext) (any, error)) {
+	if e.responseTransformers == nil {
+		e.responseTransformers = make(map[reflect.Type]responseTransformer)
+	}
+	e.responseTransformers[reflect.TypeFor[T]()] = func(ans any, c ResponseTransformerContext) (any, error) {
+		return fn(ans.(T), c)
+	}
+}
+
+// transformResponse applies the response transformer registered for the type of ans, if any.
+func (e *Engine) transformResponse(ans any, c ResponseTransformerContext) (any, error) {
+	transformer, ok := e.responseTransformers[reflect.TypeOf(ans)]
+	if !ok {
+		return ans, nil
+	}
+	return transformer(ans, c)
+}
@@ -0,0 +1,99 @@
+package fuego
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type transformedUser struct {
+	Name string `json:"name"`
+}
+
+type transformedUserWithGreeting struct {
+	transformedUser
+	Greeting string `json:"greeting"`
+}
+
+func TestRegisterResponseTransformer(t *testing.T) {
+	s := NewServer()
+	RegisterResponseTransformer(s.Engine, func(user transformedUser, c ResponseTransformerContext) (any, error) {
+		if user.Name == "" {
+			return nil, BadRequestError{Title: "Missing name"}
+		}
+		c.SetHeader("X-Transformed", "true")
+		return transformedUserWithGreeting{transformedUser: user, Greeting: "Hello " + user.Name + " from " + c.Request().URL.Path}, nil
+	})
+
+	Get(s, "/user/{name}", func(c ContextNoBody) (transformedUser, error) {
+		return transformedUser{Name: c.PathParam("name")}, nil
+	})
+	Get(s, "/user-pointer", func(c ContextNoBody) (*transformedUser, error) {
+		return &transformedUser{Name: "Ewen"}, nil
+	})
+	Get(s, "/other", func(c ContextNoBody) (ans, error) {
+		return ans{Ans: "untouched"}, nil
+	})
+	Get(s, "/anonymous", func(c ContextNoBody) (any, error) {
+		return transformedUser{}, nil
+	})
+	Get(s, "/error", func(c ContextNoBody) (transformedUser, error) {
+		return transformedUser{}, errors.New("controller error")
+	})
+
+	t.Run("applies the transformer to the registered type", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/user/Ewen", nil)
+
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "true", w.Header().Get("X-Transformed"))
+		require.JSONEq(t, `{"name":"Ewen","greeting":"Hello Ewen from /user/Ewen"}`, w.Body.String())
+	})
+
+	t.Run("does not apply the transformer to a pointer of the registered type", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/user-pointer", nil)
+
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Empty(t, w.Header().Get("X-Transformed"))
+		require.JSONEq(t, `{"name":"Ewen"}`, w.Body.String())
+	})
+
+	t.Run("does not apply the transformer to other types", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/other", nil)
+
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Empty(t, w.Header().Get("X-Transformed"))
+		require.JSONEq(t, `{"ans":"untouched"}`, w.Body.String())
+	})
+
+	t.Run("applies the transformer to the dynamic type of the returned value", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/anonymous", nil)
+
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Contains(t, w.Body.String(), "Missing name")
+	})
+
+	t.Run("does not apply the transformer when the controller fails", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/error", nil)
+
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+		require.Empty(t, w.Header().Get("X-Transformed"))
+	})
+}
@@ -64,6 +64,9 @@ type BaseRoute struct {
 	// Content types accepted for the request body. If nil, all content types (*/*) are accepted.
 	RequestContentTypes []string
 
+	// Decoders of the request body by media type, consulted before the built-in ones. See [OptionRequestDecoder].
+	RequestDecoders map[string]BodyDecoder
+
 	Middlewares []func(http.Handler) http.Handler
 
 	// Default status code for the response
@@ -77,6 +80,15 @@ type BaseRoute struct {
 
 	// Middleware configuration for the route
 	MiddlewareConfig *MiddlewareConfig
+
+	// Validates the raw JSON request body before deserialization. See [OptionJSONSchema].
+	JSONSchema JSONSchemaValidator
+
+	// Decodes the JSON request body from the object under this key of an envelope. See [OptionBodyUnwrap].
+	BodyUnwrapKey string
+
+	// Name of the route, to build its URL. See [OptionName].
+	Name string
 }
 
 func (r *BaseRoute) GenerateDefaultDescription() {
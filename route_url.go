@@ -0,0 +1,102 @@
+package fuego
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// OptionName names the route, to build its URL with [Server.NamedRouteURL] instead of hardcoding it.
+// Names must be unique in the server.
+//
+//	fuego.Get(s, "/users/{userID}/recipes/{recipeID}", getRecipe,
+//		option.Name("recipe"),
+//	)
+func OptionName(name string) func(*BaseRoute) {
+	return func(r *BaseRoute) {
+		r.Name = name
+	}
+}
+
+// registerRouteName records the path of a named route, see [OptionName].
+func (s *Server) registerRouteName(name, path string) {
+	if existing, ok := s.routeNames[name]; ok && existing != path {
+		panic(fmt.Sprintf("fuego: route name %q is already used by %s", name, existing))
+	}
+	s.routeNames[name] = path
+}
+
+// NamedRouteURL builds the URL of the route named with [OptionName], filling the placeholders of its path,
+// like "{id}", with the escaped params, so redirects do not break when routes change. See [RouteURL].
+// It returns an error if there is no such route, or if the params do not match the placeholders.
+// Example:
+//
+//	fuego.Get(s, "/recipes/{id}", getRecipe, option.Name("recipe"))
+//	fuego.Post(s, "/recipes", func(c fuego.ContextWithBody[Recipe]) (any, error) {
+//		...
+//		location, err := s.NamedRouteURL("recipe", map[string]string{"id": recipe.ID})
+//		if err != nil {
+//			return nil, err
+//		}
+//		return c.Redirect(http.StatusSeeOther, location)
+//	})
+func (s *Server) NamedRouteURL(routeName string, params map[string]string) (string, error) {
+	pattern, ok := s.routeNames[routeName]
+	if !ok {
+		return "", fmt.Errorf("no route named %q, see fuego.OptionName", routeName)
+	}
+	location, err := RouteURL(pattern, params)
+	if err != nil {
+		return "", fmt.Errorf("cannot build the URL of route %q: %w", routeName, err)
+	}
+	return location, nil
+}
+
+// RouteURL builds the URL of a route pattern, like "/users/{userID}/recipes/{recipeID}",
+// by replacing each placeholder with the escaped value of the param of the same name.
+// Wildcards, like "{path...}", keep the slashes of their value, and "{$}" is removed.
+// It returns an error if a param of the pattern is missing, or if a param is not in the pattern.
+func RouteURL(pattern string, params map[string]string) (string, error) {
+	var b strings.Builder
+	used := make(map[string]bool, len(params))
+	for {
+		start := strings.Index(pattern, "{")
+		if start < 0 {
+			b.WriteString(pattern)
+			break
+		}
+		end := strings.Index(pattern[start:], "}")
+		if end < 0 {
+			return "", fmt.Errorf("invalid route pattern %q: unclosed placeholder", pattern)
+		}
+		b.WriteString(pattern[:start])
+		name := pattern[start+1 : start+end]
+		pattern = pattern[start+end+1:]
+
+		if name == "$" {
+			continue
+		}
+		wildcard := strings.HasSuffix(name, "...")
+		name = strings.TrimSuffix(name, "...")
+
+		value, ok := params[name]
+		if !ok {
+			return "", fmt.Errorf("missing route param %q", name)
+		}
+		used[name] = true
+		if wildcard {
+			b.WriteString((&url.URL{Path: value}).EscapedPath())
+		} else {
+			b.WriteString(url.PathEscape(value))
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(params)) {
+		if !used[name] {
+			return "", fmt.Errorf("unknown route param %q", name)
+		}
+	}
+	return b.String(), nil
+}
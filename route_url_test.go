@@ -0,0 +1,92 @@
+package fuego
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouteURL(t *testing.T) {
+	t.Run("multiple params", func(t *testing.T) {
+		url, err := RouteURL("/users/{userID}/recipes/{recipeID}", map[string]string{"userID": "42", "recipeID": "pasta carbonara"})
+		require.NoError(t, err)
+		require.Equal(t, "/users/42/recipes/pasta%20carbonara", url)
+	})
+
+	t.Run("params are escaped", func(t *testing.T) {
+		url, err := RouteURL("/recipes/{name}", map[string]string{"name": "a/b?c"})
+		require.NoError(t, err)
+		require.Equal(t, "/recipes/a%2Fb%3Fc", url)
+	})
+
+	t.Run("wildcard and end of path", func(t *testing.T) {
+		url, err := RouteURL("/files/{path...}", map[string]string{"path": "docs/read me.md"})
+		require.NoError(t, err)
+		require.Equal(t, "/files/docs/read%20me.md", url)
+
+		url, err = RouteURL("/{$}", nil)
+		require.NoError(t, err)
+		require.Equal(t, "/", url)
+	})
+
+	t.Run("missing param", func(t *testing.T) {
+		_, err := RouteURL("/users/{userID}/recipes/{recipeID}", map[string]string{"userID": "42"})
+		require.EqualError(t, err, `missing route param "recipeID"`)
+	})
+
+	t.Run("unknown param", func(t *testing.T) {
+		_, err := RouteURL("/users/{userID}", map[string]string{"userID": "42", "userId": "43"})
+		require.EqualError(t, err, `unknown route param "userId"`)
+	})
+
+	t.Run("unclosed placeholder", func(t *testing.T) {
+		_, err := RouteURL("/users/{userID", map[string]string{"userID": "42"})
+		require.Error(t, err)
+	})
+}
+
+func TestServer_NamedRouteURL(t *testing.T) {
+	s := NewServer()
+	api := Group(s, "/api")
+	Get(api, "/users/{userID}/recipes/{recipeID}", func(c ContextNoBody) (string, error) {
+		return c.PathParam("recipeID"), nil
+	}, OptionName("recipe"))
+	Post(s, "/recipes", func(c ContextNoBody) (any, error) {
+		location, err := s.NamedRouteURL("recipe", map[string]string{"userID": "42", "recipeID": "7"})
+		if err != nil {
+			return nil, err
+		}
+		return c.Redirect(http.StatusSeeOther, location)
+	})
+
+	t.Run("redirects to the route of the group", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/recipes", nil))
+		require.Equal(t, http.StatusSeeOther, w.Code)
+		require.Equal(t, "/api/users/42/recipes/7", w.Header().Get("Location"))
+	})
+
+	t.Run("named in a group", func(t *testing.T) {
+		location, err := api.NamedRouteURL("recipe", map[string]string{"userID": "42", "recipeID": "7"})
+		require.NoError(t, err)
+		require.Equal(t, "/api/users/42/recipes/7", location)
+	})
+
+	t.Run("missing param", func(t *testing.T) {
+		_, err := s.NamedRouteURL("recipe", map[string]string{"userID": "42"})
+		require.EqualError(t, err, `cannot build the URL of route "recipe": missing route param "recipeID"`)
+	})
+
+	t.Run("unknown route", func(t *testing.T) {
+		_, err := s.NamedRouteURL("nope", nil)
+		require.EqualError(t, err, `no route named "nope", see fuego.OptionName`)
+	})
+
+	t.Run("duplicate name", func(t *testing.T) {
+		require.Panics(t, func() {
+			Get(s, "/other", func(c ContextNoBody) (string, error) { return "", nil }, OptionName("recipe"))
+		})
+	})
+}
@@ -1,15 +1,18 @@
 package fuego
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"encoding/xml"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 	"reflect"
 	"strings"
+	"sync"
 
 	"gopkg.in/yaml.v3"
 )
@@ -79,13 +82,111 @@ func transformOut[T any](ctx context.Context, ans T) (T, error) {
 	return ans, nil
 }
 
+// maxPooledBufferSize is the maximum capacity of the buffers kept in the pool,
+// so a single large response does not retain memory forever.
+const maxPooledBufferSize = 64 << 10
+
+// serializationBuffer is a buffer responses are serialized into before being written,
+// with an encoder bound to it. Both are reused across requests to reduce allocations:
+// [xml.NewEncoder] allocates a 4KB buffer each time.
+type serializationBuffer struct {
+	bytes.Buffer
+	jsonEncoder *json.Encoder
+	xmlEncoder  *xml.Encoder
+}
+
+var serializationBufferPool = sync.Pool{
+	New: func() any { return new(serializationBuffer) },
+}
+
+// getSerializationBuffer returns an empty buffer from the pool.
+func getSerializationBuffer() *serializationBuffer {
+	return serializationBufferPool.Get().(*serializationBuffer)
+}
+
+// putSerializationBuffer resets the buffer and returns it to the pool.
+func putSerializationBuffer(buf *serializationBuffer) {
+	if buf.Cap() > maxPooledBufferSize {
+		return
+	}
+	buf.Reset()
+	serializationBufferPool.Put(buf)
+}
+
+// JSONEncoder returns a JSON encoder writing to the buffer.
+func (buf *serializationBuffer) JSONEncoder() *json.Encoder {
+	if buf.jsonEncoder == nil {
+		buf.jsonEncoder = json.NewEncoder(&buf.Buffer)
+	}
+	return buf.jsonEncoder
+}
+
+// writeJSON serializes the value into a pooled buffer, then writes it.
+// Nothing is written if the serialization fails.
+func writeJSON(w io.Writer, ans any, options serializeOptions) error {
+	buf := getSerializationBuffer()
+	defer putSerializationBuffer(buf)
+
+	if err := buf.JSONEncoder().Encode(ans); err != nil {
+		return err
+	}
+
+	data := buf.Bytes()
+	if options.DecimalJSONFloats {
+		data = decimalJSONNumbers(data)
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+// XMLEncoder returns a XML encoder writing to the buffer.
+func (buf *serializationBuffer) XMLEncoder() *xml.Encoder {
+	if buf.xmlEncoder == nil {
+		buf.xmlEncoder = xml.NewEncoder(&buf.Buffer)
+	}
+	return buf.xmlEncoder
+}
+
 type Sender func(http.ResponseWriter, *http.Request, any) error
 
+// serializeOptions are the options of the server used by the senders.
+// They are given through the request context, as the senders only get the request.
+type serializeOptions struct {
+	// Codec of the protobuf responses, see [WithProtoCodec].
+	ProtoCodec ProtoCodec
+	// Codec of the protobuf responses sent as JSON, see [WithProtoJSONCodec].
+	ProtoJSONCodec ProtoCodec
+	// Send the numbers of the JSON responses in decimal notation, see [WithDecimalJSONFloats].
+	DecimalJSONFloats bool
+}
+
+type serializeOptionsKey struct{}
+
+// withSerializeOptions returns the request with the options in its context.
+// The request is returned as is if no option is set.
+func withSerializeOptions(r *http.Request, options serializeOptions) *http.Request {
+	if options.ProtoCodec == nil && options.ProtoJSONCodec == nil && !options.DecimalJSONFloats {
+		return r
+	}
+	return r.WithContext(context.WithValue(r.Context(), serializeOptionsKey{}, options))
+}
+
+// serializeOptionsFrom returns the options of the server the request is served by.
+func serializeOptionsFrom(r *http.Request) serializeOptions {
+	if r == nil {
+		return serializeOptions{}
+	}
+	options, _ := r.Context().Value(serializeOptionsKey{}).(serializeOptions)
+	return options
+}
+
 // Send sends a response.
 // The format is determined by the Accept header.
 // If Accept header `*/*` is found Send will Attempt to send
 // HTML, and then JSON.
 func Send(w http.ResponseWriter, r *http.Request, ans any) (err error) {
+	// The format is negotiated from the Accept header.
+	AddVary(w.Header(), "Accept")
 	for _, header := range parseAcceptHeader(r.Header) {
 		switch inferAcceptHeader(header, ans) {
 		case "application/xml":
@@ -98,6 +199,8 @@ func Send(w http.ResponseWriter, r *http.Request, ans any) (err error) {
 			err = SendJSON(w, r, ans)
 		case "application/x-yaml", "text/yaml; charset=utf-8", "application/yaml": // https://www.rfc-editor.org/rfc/rfc9512.html
 			err = SendYAML(w, r, ans)
+		case "application/x-protobuf", "application/protobuf":
+			err = SendProto(w, r, ans)
 		default:
 			// if we don't support the header, try the next one
 			continue
@@ -156,7 +259,17 @@ func SendYAMLError(w http.ResponseWriter, _ *http.Request, err error) {
 // If serialization fails, it does NOT write to the response writer. It has to be passed to SendJSONError.
 var SendJSON = func(w http.ResponseWriter, r *http.Request, ans any) error {
 	w.Header().Set("Content-Type", "application/json")
-	err := json.NewEncoder(w).Encode(ans)
+	options := serializeOptionsFrom(r)
+	if message, ok := ans.(ProtoMessage); ok && options.ProtoJSONCodec != nil {
+		data, err := options.ProtoJSONCodec.Marshal(message)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Cannot serialize returned response to protobuf JSON", "error", err)
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	}
+	err := writeJSON(w, ans, options)
 	if err != nil {
 		slog.ErrorContext(r.Context(), "Cannot serialize returned response to JSON", "error", err, "errtype", fmt.Sprintf("%T", err))
 		var unsupportedType *json.UnsupportedTypeError
@@ -184,7 +297,7 @@ var SendError = func(w http.ResponseWriter, r *http.Request, err error) {
 		case "text/plain":
 			SendTextError(w, r, err)
 		case "application/json":
-			SendJSONError(w, nil, err)
+			SendJSONError(w, r, err)
 		case "application/x-yaml", "text/yaml; charset=utf-8", "application/yaml": // https://www.rfc-editor.org/rfc/rfc9512.html
 			SendYAMLError(w, nil, err)
 		default:
@@ -197,7 +310,7 @@ var SendError = func(w http.ResponseWriter, r *http.Request, err error) {
 
 // SendJSONError sends a JSON error response.
 // If the error implements ErrorWithStatus, the status code will be set.
-func SendJSONError(w http.ResponseWriter, _ *http.Request, err error) {
+func SendJSONError(w http.ResponseWriter, r *http.Request, err error) {
 	status := http.StatusInternalServerError
 	var errorStatus ErrorWithStatus
 	if errors.As(err, &errorStatus) {
@@ -212,7 +325,7 @@ func SendJSONError(w http.ResponseWriter, _ *http.Request, err error) {
 	}
 
 	w.WriteHeader(status)
-	_ = SendJSON(w, nil, err)
+	_ = SendJSON(w, r, err)
 }
 
 // SendXML sends a XML response.
@@ -220,8 +333,14 @@ func SendJSONError(w http.ResponseWriter, _ *http.Request, err error) {
 // If serialization fails, it does NOT write to the response writer. It has to be passed to SendJSONError.
 var SendXML = func(w http.ResponseWriter, r *http.Request, ans any) error {
 	w.Header().Set("Content-Type", "application/xml")
-	err := xml.NewEncoder(w).Encode(ans)
+
+	buf := getSerializationBuffer()
+	defer putSerializationBuffer(buf)
+
+	err := buf.XMLEncoder().Encode(ans)
 	if err != nil {
+		// The encoder may have buffered a partial document
+		buf.xmlEncoder = nil
 		slog.ErrorContext(r.Context(), "Cannot serialize returned response to XML", "error", err, "errtype", fmt.Sprintf("%T", err))
 		var unsupportedType *xml.UnsupportedTypeError
 		if errors.As(err, &unsupportedType) {
@@ -230,8 +349,10 @@ var SendXML = func(w http.ResponseWriter, r *http.Request, ans any) error {
 				Detail: fmt.Sprintf("Cannot serialize type %T to XML", ans),
 			}
 		}
+		return err
 	}
 
+	_, err = w.Write(buf.Bytes())
 	return err
 }
 
@@ -253,35 +374,44 @@ func SendXMLError(w http.ResponseWriter, r *http.Request, err error) {
 }
 
 // SendHTML sends a HTML response.
+// The charset is negotiated from the Accept-Charset header, see [NegotiateCharset].
 // Declared as a variable to be able to override it for clients that need to customize serialization.
-var SendHTML = func(w http.ResponseWriter, r *http.Request, ans any) error {
-	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+var SendHTML = func(w http.ResponseWriter, r *http.Request, ans any) (err error) {
+	charset := responseCharset(r)
+	w.Header().Set("Content-Type", "text/html; charset="+charset)
+
+	cw := charsetWriter(w, charset)
+	defer func() {
+		if errClose := cw.Close(); err == nil {
+			err = errClose
+		}
+	}()
 
 	ctxRenderer, ok := ans.(CtxRenderer)
 	if ok {
-		return ctxRenderer.Render(r.Context(), w)
+		return ctxRenderer.Render(r.Context(), cw)
 	}
 
 	renderer, ok := ans.(Renderer)
 	if ok {
-		return renderer.Render(w)
+		return renderer.Render(cw)
 	}
 
 	html, ok := ans.(HTML)
 	if ok {
-		_, err := w.Write([]byte(html))
+		_, err := cw.Write([]byte(html))
 		return err
 	}
 
 	htmlString, ok := ans.(string)
 	if ok {
-		_, err := w.Write([]byte(htmlString))
+		_, err := cw.Write([]byte(htmlString))
 		return err
 	}
 
 	htmlStringRef, ok := ans.(*string)
 	if ok {
-		_, err := w.Write([]byte(*htmlStringRef))
+		_, err := cw.Write([]byte(*htmlStringRef))
 		return err
 	}
 
@@ -309,10 +439,11 @@ func SendHTMLError(w http.ResponseWriter, _ *http.Request, err error) {
 }
 
 // SendText sends a HTML response.
+// The charset is negotiated from the Accept-Charset header, see [NegotiateCharset].
 // Declared as a variable to be able to override it for clients that need to customize serialization.
-func SendText(w http.ResponseWriter, _ *http.Request, ans any) error {
-	var err error
-	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+func SendText(w http.ResponseWriter, r *http.Request, ans any) error {
+	charset := responseCharset(r)
+	w.Header().Set("Content-Type", "text/plain; charset="+charset)
 	stringToWrite, ok := ans.(string)
 	if !ok {
 		stringToWritePtr, okPtr := ans.(*string)
@@ -322,7 +453,12 @@ func SendText(w http.ResponseWriter, _ *http.Request, ans any) error {
 			stringToWrite = fmt.Sprintf("%v", ans)
 		}
 	}
-	_, err = w.Write([]byte(stringToWrite))
+
+	cw := charsetWriter(w, charset)
+	_, err := cw.Write([]byte(stringToWrite))
+	if errClose := cw.Close(); err == nil {
+		err = errClose
+	}
 
 	return err
 }
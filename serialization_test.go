@@ -2,6 +2,8 @@ package fuego
 
 import (
 	"context"
+	"encoding/json"
+	"encoding/xml"
 	"errors"
 	"html/template"
 	"io"
@@ -86,6 +88,20 @@ func TestJSON(t *testing.T) {
 		body := w.Body.String()
 		require.Empty(t, body)
 	})
+
+	t.Run("pooled buffer is reset after a failure", func(t *testing.T) {
+		type broken struct {
+			Message string `json:"message"`
+			F       func() `json:"f"`
+		}
+		err := SendJSON(httptest.NewRecorder(), httptest.NewRequest("", "/", nil), broken{Message: "partial"})
+		require.Error(t, err)
+
+		w := httptest.NewRecorder()
+		err = SendJSON(w, httptest.NewRequest("", "/", nil), response{Message: "Hello World", Code: 200})
+		require.NoError(t, err)
+		require.Equal(t, crlf(`{"message":"Hello World","code":200}`), w.Body.String())
+	})
 }
 
 func TestXML(t *testing.T) {
@@ -108,6 +124,23 @@ func TestXML(t *testing.T) {
 		require.Empty(t, body)
 	})
 
+	t.Run("failed serialization does not leak in the next response", func(t *testing.T) {
+		type partiallySerializable struct {
+			Message  string
+			Callback func()
+		}
+
+		w := httptest.NewRecorder()
+		err := SendXML(w, httptest.NewRequest("", "/", nil), partiallySerializable{Message: "partial", Callback: func() {}})
+		require.Error(t, err)
+		require.Empty(t, w.Body.String())
+
+		w = httptest.NewRecorder()
+		err = SendXML(w, httptest.NewRequest("", "/", nil), response{Message: "Hello World", Code: 200})
+		require.NoError(t, err)
+		require.Equal(t, `<response><Message>Hello World</Message><Code>200</Code></response>`, w.Body.String())
+	})
+
 	t.Run("can serialize xml error", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		err := HTTPError{Detail: "Hello World"}
@@ -655,3 +688,52 @@ func TestSendError(t *testing.T) {
 		})
 	}
 }
+
+type discardResponseWriter struct {
+	header http.Header
+}
+
+func (w discardResponseWriter) Header() http.Header         { return w.header }
+func (w discardResponseWriter) Write(b []byte) (int, error) { return len(b), nil }
+func (w discardResponseWriter) WriteHeader(int)             {}
+
+// BenchmarkSerialization compares the pooled serialization buffers
+// with an encoder allocated for each response.
+func BenchmarkSerialization(b *testing.B) {
+	r := httptest.NewRequest("GET", "/", nil)
+	data := []response{{Message: "Hello World", Code: 200}, {Message: "Hello Fuego", Code: 201}}
+
+	b.Run("json pooled", func(b *testing.B) {
+		w := discardResponseWriter{header: http.Header{}}
+		b.ReportAllocs()
+		for b.Loop() {
+			_ = SendJSON(w, r, data)
+		}
+	})
+
+	b.Run("json unpooled", func(b *testing.B) {
+		w := discardResponseWriter{header: http.Header{}}
+		b.ReportAllocs()
+		for b.Loop() {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(data)
+		}
+	})
+
+	b.Run("xml pooled", func(b *testing.B) {
+		w := discardResponseWriter{header: http.Header{}}
+		b.ReportAllocs()
+		for b.Loop() {
+			_ = SendXML(w, r, data)
+		}
+	})
+
+	b.Run("xml unpooled", func(b *testing.B) {
+		w := discardResponseWriter{header: http.Header{}}
+		b.ReportAllocs()
+		for b.Loop() {
+			w.Header().Set("Content-Type", "application/xml")
+			_ = xml.NewEncoder(w).Encode(data)
+		}
+	})
+}
@@ -1,7 +1,6 @@
 package fuego
 
 import (
-	"html/template"
 	"log/slog"
 	"net"
 	"net/http"
@@ -90,23 +89,86 @@ func (s *Server) url() string {
 // Uses Server for configuration.
 // Uses Route for route configuration. Optional.
 func HTTPHandler[ReturnType, Body, Params any](s *Server, controller func(c Context[Body, Params]) (ReturnType, error), route BaseRoute) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		var templates *template.Template
-		if s.template != nil {
-			templates = template.Must(s.template.Clone())
-		}
+	var errorSerializer ErrorSender
+	if s.errorSerializers != nil {
+		errorSerializer = negotiateErrorSerializer(s.errorSerializers, func(w http.ResponseWriter, r *http.Request, err error) {
+			s.SerializeError(w, r, err)
+		})
+	}
+	if s.txProvider != nil {
+		controller = withTransaction(s.txProvider, controller)
+	}
+	if s.expectContinueCheck != nil {
+		controller = withExpectContinueCheck(s.expectContinueCheck, controller)
+	}
+
+	serve := func(w http.ResponseWriter, r *http.Request) {
+		templates := s.requestTemplates(r)
 
 		// CONTEXT INITIALIZATION
+		var buffered *bufferedResponseWriter
+		autoETag := s.autoETag && (r.Method == http.MethodGet || r.Method == http.MethodHead)
+		if s.bufferResponses || autoETag {
+			buffered = newBufferedResponseWriter(w, r, s.maxBufferedResponseSize)
+			buffered.autoETag = autoETag
+			w = buffered
+		}
+		w = newResponseSizeWriter(w, r, s.responseSizeLimit)
+		r = withSerializeOptions(r, serializeOptions{
+			ProtoCodec:        s.protoCodec,
+			ProtoJSONCodec:    s.protoJSONCodec,
+			DecimalJSONFloats: s.decimalJSONFloats,
+		})
 		ctx := NewNetHTTPContext[Body, Params](route, w, r, readOptions{
 			DisallowUnknownFields: s.DisallowUnknownFields,
 			MaxBodySize:           s.maxBodySize,
+			MaxJSONDepth:          s.maxJSONDepth,
+			MaxMultipartParts:     s.maxMultipartParts,
+			MaxFormFields:         s.maxFormFields,
+			BodyReadTimeout:       s.bodyReadTimeout,
+			ReportBodySize:        s.reportBodySize,
+			ValidateUTF8:          s.validateUTF8,
+			JSONSchema:            route.JSONSchema,
+			BodyUnwrapKey:         route.BodyUnwrapKey,
+			Decoders:              route.RequestDecoders,
+			TrimParamWhitespace:   s.trimParamWhitespace,
+			ContentTypeNormalizer: s.contentTypeNormalizer,
+			FieldKeys:             s.fieldKeys,
+			ProtoCodec:            s.protoCodec,
+			ProtoJSONCodec:        s.protoJSONCodec,
 		})
 		ctx.serializer = s.Serialize
 		ctx.errorSerializer = s.SerializeError
+		if errorSerializer != nil {
+			ctx.errorSerializer = errorSerializer
+		}
 		ctx.fs = s.fs
 		ctx.templates = templates
+		ctx.markdownRenderer = s.markdownRenderer
+		ctx.rateLimiter = s.rateLimiter
+		ctx.apiVersioning = s.apiVersioning
+		ctx.debugRedactedHeaders = s.debugRedactedHeaders
+		ctx.jsonpParam = s.jsonpParam
+		ctx.logger = s.logger
+
+		var err error
+		defer ctx.finishCallbacks.Finish(&err)
+
+		err = FlowWithError(s.Engine, ctx, controller)
+
+		if buffered != nil {
+			if err := buffered.finish(); err != nil {
+				slog.ErrorContext(r.Context(), "Cannot send buffered response", "error", err)
+			}
+		}
+	}
 
-		Flow(s.Engine, ctx, controller)
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.singleflight != nil && r.Method == http.MethodGet {
+			s.singleflight.serveHTTP(w, r, serve)
+			return
+		}
+		serve(w, r)
 	}
 }
 
@@ -125,6 +187,12 @@ type ContextFlowable[B, P any] interface {
 
 // Flow is generic handler for Fuego controllers.
 func Flow[B, T, P any](s *Engine, ctx ContextFlowable[B, P], controller func(c Context[B, P]) (T, error)) {
+	_ = FlowWithError(s, ctx, controller)
+}
+
+// FlowWithError is [Flow], returning the error of the request, if any, before it is handled by the error handler,
+// to be given to the callbacks of [Context.OnFinish].
+func FlowWithError[B, T, P any](s *Engine, ctx ContextFlowable[B, P], controller func(c Context[B, P]) (T, error)) error {
 	ctx.SetHeader("X-Powered-By", "Fuego")
 	ctx.SetHeader("Trailer", "Server-Timing")
 
@@ -133,45 +201,56 @@ func Flow[B, T, P any](s *Engine, ctx ContextFlowable[B, P], controller func(c C
 	// PARAMS VALIDATION
 	err := ValidateParams(ctx)
 	if err != nil {
-		err = s.ErrorHandler(ctx, err)
-		ctx.SerializeError(err)
-		return
+		serializeError(s, ctx, s.handleError(ctx, err))
+		return err
 	}
 
 	timeController := time.Now()
-	ctx.SetHeader("Server-Timing", Timing{"fuegoReqInit", "", timeController.Sub(timeCtxInit)}.String())
+	ctx.Response().Header().Add("Server-Timing", Timing{"fuegoReqInit", "", timeController.Sub(timeCtxInit)}.String())
 
 	// CONTROLLER
-	ans, err := controller(ctx)
+	var allocs *allocMeter
+	if s.allocationReport {
+		allocs = startAllocMeter()
+	}
+	ans, err := callController(ctx, controller)
+	if allocs != nil {
+		allocs.report(ctx.Response().Header())
+	}
 	if err != nil {
-		err = s.ErrorHandler(ctx, err)
-		ctx.SerializeError(err)
-		return
+		serializeError(s, ctx, s.handleError(ctx, err))
+		return err
 	}
-	ctx.SetHeader("Server-Timing", Timing{"controller", "", time.Since(timeController)}.String())
+	ctx.Response().Header().Add("Server-Timing", Timing{"controller", "", time.Since(timeController)}.String())
 
 	ctx.SetDefaultStatusCode()
 
 	if reflect.TypeOf(ans) == nil {
-		return
+		return nil
 	}
 
 	// TRANSFORM OUT
 	timeTransformOut := time.Now()
 	ans, err = transformOut(ctx.Context(), ans)
 	if err != nil {
-		err = s.ErrorHandler(ctx, err)
-		ctx.SerializeError(err)
-		return
+		serializeError(s, ctx, s.handleError(ctx, err))
+		return err
+	}
+	response, err := s.transformResponse(ans, ctx)
+	if err != nil {
+		serializeError(s, ctx, s.handleError(ctx, err))
+		return err
 	}
 	timeAfterTransformOut := time.Now()
-	ctx.SetHeader("Server-Timing", Timing{"transformOut", "transformOut", timeAfterTransformOut.Sub(timeTransformOut)}.String())
+	ctx.Response().Header().Add("Server-Timing", Timing{"transformOut", "transformOut", timeAfterTransformOut.Sub(timeTransformOut)}.String())
+
+	response = envelopeResponse(s, ctx, response)
 
 	// SERIALIZATION
-	err = ctx.Serialize(ans)
+	err = ctx.Serialize(response)
 	if err != nil {
-		err = s.ErrorHandler(ctx, err)
-		ctx.SerializeError(err)
+		serializeError(s, ctx, s.handleError(ctx, err))
 	}
-	ctx.SetHeader("Server-Timing", Timing{"serialize", "", time.Since(timeAfterTransformOut)}.String())
+	ctx.Response().Header().Add("Server-Timing", Timing{"serialize", "", time.Since(timeAfterTransformOut)}.String())
+	return err
 }
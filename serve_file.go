@@ -0,0 +1,227 @@
+package fuego
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// ServeFileCompressed serves the file with the given name from the filesystem,
+// gzip-compressing it on the fly when the client accepts it (Accept-Encoding: gzip)
+// and the file is not already compressed (archives, images, audio, video, fonts).
+// Pre-compressed sidecar files are preferred when they exist and the client accepts their encoding:
+// "style.css.br" (Accept-Encoding: br), then "style.css.gz" (Accept-Encoding: gzip) are served as is for "style.css".
+// Compressed responses are sent chunked, without Content-Length.
+// Uncompressed files are served with [http.ServeContent] when possible, supporting range and conditional requests.
+// It returns a [NotFoundError] if the file does not exist or is a directory.
+// Example:
+//
+//	fuego.Get(s, "/assets/{name}", func(c fuego.ContextNoBody) (any, error) {
+//		return nil, fuego.ServeFileCompressed(c.Response(), c.Request(), assets, "assets/"+c.PathParam("name"))
+//	})
+func ServeFileCompressed(w http.ResponseWriter, r *http.Request, fsys fs.FS, name string) error {
+	name = strings.TrimPrefix(path.Clean("/"+name), "/")
+	file, err := fsys.Open(name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return NotFoundError{Title: "File Not Found", Err: err, Detail: "file " + name + " not found"}
+		}
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return NotFoundError{Title: "File Not Found", Detail: "file " + name + " not found"}
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(name))
+	if contentType == "" {
+		// Sniffs the content type from the first bytes, then reads the file from its beginning.
+		sniffed := make([]byte, 512)
+		n, err := io.ReadFull(file, sniffed)
+		if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+			return err
+		}
+		contentType = http.DetectContentType(sniffed[:n])
+		if seeker, ok := file.(io.Seeker); ok {
+			if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+				return err
+			}
+		} else {
+			file = &sniffedFile{File: file, reader: io.MultiReader(bytes.NewReader(sniffed[:n]), file)}
+		}
+	}
+
+	header := w.Header()
+	header.Set("Content-Type", contentType)
+	AddVary(header, "Accept-Encoding")
+
+	if !isCompressedFile(name, contentType) {
+		served, err := serveSidecarFile(w, r, fsys, name)
+		if served || err != nil {
+			return err
+		}
+	}
+
+	if !acceptsGzip(r.Header) || isCompressedFile(name, contentType) {
+		if seeker, ok := file.(io.ReadSeeker); ok {
+			http.ServeContent(w, r, name, info.ModTime(), seeker)
+			return nil
+		}
+		header.Set("Content-Length", strconv.FormatInt(info.Size(), 10))
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodHead {
+			return nil
+		}
+		_, err = io.Copy(w, file)
+		return err
+	}
+
+	// The compressed size is unknown until the file is sent.
+	header.Del("Content-Length")
+	header.Set("Content-Encoding", "gzip")
+	if !info.ModTime().IsZero() {
+		header.Set("Last-Modified", info.ModTime().UTC().Format(http.TimeFormat))
+	}
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodHead {
+		return nil
+	}
+
+	gz := gzip.NewWriter(w)
+	if _, err := io.Copy(gz, file); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// sidecarEncodings are the extensions of the pre-compressed files, by order of preference, and their encoding.
+var sidecarEncodings = []struct{ extension, encoding string }{
+	{".br", "br"},
+	{".gz", "gzip"},
+}
+
+// serveSidecarFile serves the pre-compressed version of the file, if one is accepted by the client and exists.
+// The Content-Type must already be set from the uncompressed file.
+func serveSidecarFile(w http.ResponseWriter, r *http.Request, fsys fs.FS, name string) (bool, error) {
+	for _, sidecar := range sidecarEncodings {
+		if !acceptsEncoding(r.Header, sidecar.encoding) {
+			continue
+		}
+
+		file, err := fsys.Open(name + sidecar.extension)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return false, err
+		}
+		defer file.Close()
+
+		info, err := file.Stat()
+		if err != nil {
+			return false, err
+		}
+		if info.IsDir() {
+			continue
+		}
+
+		header := w.Header()
+		header.Set("Content-Encoding", sidecar.encoding)
+		if seeker, ok := file.(io.ReadSeeker); ok {
+			http.ServeContent(w, r, name, info.ModTime(), seeker)
+			return true, nil
+		}
+		header.Set("Content-Length", strconv.FormatInt(info.Size(), 10))
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodHead {
+			return true, nil
+		}
+		_, err = io.Copy(w, file)
+		return true, err
+	}
+	return false, nil
+}
+
+// sniffedFile is a file whose first bytes have already been read to detect its content type.
+type sniffedFile struct {
+	fs.File
+	reader io.Reader
+}
+
+func (f *sniffedFile) Read(p []byte) (int, error) {
+	return f.reader.Read(p)
+}
+
+// acceptsGzip reports whether the Accept-Encoding header accepts gzip with a non-zero quality.
+func acceptsGzip(header http.Header) bool {
+	return acceptsEncoding(header, "gzip")
+}
+
+// acceptsEncoding reports whether the Accept-Encoding header accepts the content coding with a non-zero quality.
+// An explicit coding takes precedence over the "*" wildcard. "x-gzip" is an alias of "gzip".
+func acceptsEncoding(header http.Header, encoding string) bool {
+	codingQuality, wildcardQuality := -1.0, -1.0
+	for _, line := range header.Values("Accept-Encoding") {
+		for part := range strings.SplitSeq(line, ",") {
+			coding, params, _ := strings.Cut(part, ";")
+			coding = strings.ToLower(strings.TrimSpace(coding))
+
+			quality := 1.0
+			if q, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+				if parsed, err := strconv.ParseFloat(q, 64); err == nil {
+					quality = parsed
+				}
+			}
+
+			if coding == "x-gzip" {
+				coding = "gzip"
+			}
+			switch coding {
+			case encoding:
+				codingQuality = max(codingQuality, quality)
+			case "*":
+				wildcardQuality = max(wildcardQuality, quality)
+			}
+		}
+	}
+
+	if codingQuality >= 0 {
+		return codingQuality > 0
+	}
+	return wildcardQuality > 0
+}
+
+// isCompressedFile reports whether the file is already compressed, so compressing it again would waste CPU.
+func isCompressedFile(name, contentType string) bool {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".gz", ".tgz", ".zip", ".br", ".zst", ".bz2", ".xz", ".7z", ".rar", ".woff", ".woff2":
+		return true
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mediaType == "image/svg+xml":
+		return false
+	case strings.HasPrefix(mediaType, "image/"), strings.HasPrefix(mediaType, "audio/"), strings.HasPrefix(mediaType, "video/"):
+		return true
+	}
+	switch mediaType {
+	case "application/zip", "application/gzip", "application/x-gzip", "application/zstd",
+		"application/x-bzip2", "application/x-xz", "application/x-7z-compressed", "application/vnd.rar",
+		"font/woff", "font/woff2":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,195 @@
+package fuego
+
+import (
+	"compress/gzip"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestServeFileCompressed(t *testing.T) {
+	css := strings.Repeat("body { color: red; }\n", 100)
+	assets := fstest.MapFS{
+		"assets/style.css":   {Data: []byte(css)},
+		"assets/logo.png":    {Data: []byte("\x89PNG\r\n\x1a\nnot really a png")},
+		"assets/archive.gz":  {Data: []byte("already compressed")},
+		"assets/README":      {Data: []byte("plain text without extension")},
+		"assets/folder/file": {Data: []byte("in a folder")},
+	}
+	s := NewServer()
+	Get(s, "/assets/{name...}", func(c ContextNoBody) (any, error) {
+		return nil, ServeFileCompressed(c.Response(), c.Request(), assets, "assets/"+c.PathParam("name"))
+	})
+
+	get := func(t *testing.T, path, acceptEncoding string) *httptest.ResponseRecorder {
+		t.Helper()
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		if acceptEncoding != "" {
+			r.Header.Set("Accept-Encoding", acceptEncoding)
+		}
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("compresses for a client accepting gzip", func(t *testing.T) {
+		w := get(t, "/assets/style.css", "br, gzip;q=0.8")
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+		require.Empty(t, w.Header().Get("Content-Length"))
+		require.Equal(t, "text/css; charset=utf-8", w.Header().Get("Content-Type"))
+		require.Contains(t, w.Header().Values("Vary"), "Accept-Encoding")
+		require.Less(t, w.Body.Len(), len(css))
+
+		gz, err := gzip.NewReader(w.Body)
+		require.NoError(t, err)
+		body, err := io.ReadAll(gz)
+		require.NoError(t, err)
+		require.Equal(t, css, string(body))
+	})
+
+	t.Run("sends the raw file otherwise", func(t *testing.T) {
+		w := get(t, "/assets/style.css", "")
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Empty(t, w.Header().Get("Content-Encoding"))
+		require.Equal(t, "2100", w.Header().Get("Content-Length"))
+		require.Equal(t, css, w.Body.String())
+	})
+
+	t.Run("gzip refused with q=0", func(t *testing.T) {
+		w := get(t, "/assets/style.css", "gzip;q=0, identity")
+
+		require.Empty(t, w.Header().Get("Content-Encoding"))
+		require.Equal(t, css, w.Body.String())
+	})
+
+	t.Run("already compressed files are sent raw", func(t *testing.T) {
+		for _, path := range []string{"/assets/logo.png", "/assets/archive.gz"} {
+			w := get(t, path, "gzip")
+
+			require.Equal(t, http.StatusOK, w.Code, path)
+			require.Empty(t, w.Header().Get("Content-Encoding"), path)
+		}
+	})
+
+	t.Run("sniffs the content type without extension", func(t *testing.T) {
+		w := get(t, "/assets/README", "gzip")
+
+		require.Equal(t, "text/plain; charset=utf-8", w.Header().Get("Content-Type"))
+		gz, err := gzip.NewReader(w.Body)
+		require.NoError(t, err)
+		body, err := io.ReadAll(gz)
+		require.NoError(t, err)
+		require.Equal(t, "plain text without extension", string(body))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		require.Equal(t, http.StatusNotFound, get(t, "/assets/missing.css", "gzip").Code)
+		require.Equal(t, http.StatusNotFound, get(t, "/assets/folder", "gzip").Code)
+	})
+}
+
+func TestServeFileCompressed_Sidecars(t *testing.T) {
+	js := strings.Repeat("console.log('hello');\n", 100)
+	assets := fstest.MapFS{
+		"assets/app.js":        {Data: []byte(js)},
+		"assets/app.js.br":     {Data: []byte("brotli bytes")},
+		"assets/app.js.gz":     {Data: []byte("gzip bytes")},
+		"assets/only-gz.js":    {Data: []byte(js)},
+		"assets/only-gz.js.gz": {Data: []byte("gzip bytes")},
+		"assets/folder.js":     {Data: []byte(js)},
+		"assets/folder.js.br":  {Mode: fs.ModeDir},
+	}
+	s := NewServer()
+	Get(s, "/assets/{name...}", func(c ContextNoBody) (any, error) {
+		return nil, ServeFileCompressed(c.Response(), c.Request(), assets, "assets/"+c.PathParam("name"))
+	})
+
+	get := func(t *testing.T, path, acceptEncoding string) *httptest.ResponseRecorder {
+		t.Helper()
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		if acceptEncoding != "" {
+			r.Header.Set("Accept-Encoding", acceptEncoding)
+		}
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("prefers brotli", func(t *testing.T) {
+		w := get(t, "/assets/app.js", "gzip, br")
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "br", w.Header().Get("Content-Encoding"))
+		require.Equal(t, "text/javascript; charset=utf-8", w.Header().Get("Content-Type"))
+		require.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+		require.Equal(t, "brotli bytes", w.Body.String())
+	})
+
+	t.Run("gzip sidecar", func(t *testing.T) {
+		w := get(t, "/assets/app.js", "gzip, br;q=0")
+
+		require.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+		require.Equal(t, "gzip bytes", w.Body.String())
+	})
+
+	t.Run("gzip sidecar when brotli is missing", func(t *testing.T) {
+		w := get(t, "/assets/only-gz.js", "br, gzip")
+
+		require.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+		require.Equal(t, "gzip bytes", w.Body.String())
+	})
+
+	t.Run("uncompressed file without accepted encoding", func(t *testing.T) {
+		w := get(t, "/assets/app.js", "")
+
+		require.Empty(t, w.Header().Get("Content-Encoding"))
+		require.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+		require.Equal(t, js, w.Body.String())
+	})
+
+	t.Run("without sidecar compresses on the fly", func(t *testing.T) {
+		w := get(t, "/assets/folder.js", "br, gzip")
+
+		require.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+		gz, err := gzip.NewReader(w.Body)
+		require.NoError(t, err)
+		body, err := io.ReadAll(gz)
+		require.NoError(t, err)
+		require.Equal(t, js, string(body))
+	})
+}
+
+func TestAcceptsGzip(t *testing.T) {
+	for accept, expected := range map[string]bool{
+		"":                  false,
+		"gzip":              true,
+		"GZIP":              true,
+		"deflate, gzip":     true,
+		"*":                 true,
+		"gzip;q=0":          false,
+		"gzip;q=0.5":        true,
+		"br, identity;q=1":  false,
+		"x-gzip, identity":  true,
+		"gzip;q=0, *;q=0.1": false,
+		"br, *;q=0.1":       true,
+	} {
+		header := http.Header{}
+		if accept != "" {
+			header.Set("Accept-Encoding", accept)
+		}
+		require.Equal(t, expected, acceptsGzip(header), accept)
+	}
+
+	header := http.Header{"Accept-Encoding": {"gzip, br;q=0.5"}}
+	require.True(t, acceptsEncoding(header, "br"))
+	require.False(t, acceptsEncoding(header, "zstd"))
+}
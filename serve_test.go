@@ -713,6 +713,17 @@ func TestFlow(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 		assert.Equal(t, crlf(`null`), w.Body.String())
 	})
+	t.Run("returns the error of the controller", func(t *testing.T) {
+		e := NewEngine()
+		w := httptest.NewRecorder()
+		ctx := newTestCtx(w, httptest.NewRequest("GET", "/", nil))
+		err := FlowWithError(e, ctx, testControllerWithError)
+		require.ErrorContains(t, err, "error happened!")
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+		ctx = newTestCtx(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+		require.NoError(t, FlowWithError(e, ctx, testController))
+	})
 	t.Run("ensure context is passed to ErrorHandler", func(t *testing.T) {
 		var receivedCtx context.Context
 		e := NewEngine(WithErrorHandler(func(ctx context.Context, err error) error {
@@ -38,10 +38,35 @@ type Server struct {
 
 	template *template.Template // TODO: use preparsed templates
 
+	markdownRenderer MarkdownRenderer
+
+	featureFlags FeatureFlagProvider
+
+	rateLimiter RateLimiter
+
+	apiVersioning APIVersionConfig
+
+	// Validates the requests expecting "100 Continue" before their body. See [WithExpectContinueCheck].
+	expectContinueCheck func(r *http.Request) error
+
+	// Begins a transaction for each request. See [WithTxProvider].
+	txProvider TxProvider
+
+	// Base logger of [Context.Logger]. See [WithLogger].
+	logger *slog.Logger
+
+	// Query parameter of the JSONP callback, empty if disabled. See [WithJSONP].
+	jsonpParam string
+
+	// Request headers hidden by [Context.DebugInfo]. See [WithDebugRedactedHeaders].
+	debugRedactedHeaders []string
+
 	// Custom serializer that overrides the default one.
 	Serialize Sender
 	// Used to serialize the error response. Defaults to [SendError].
 	SerializeError ErrorSender
+	// Error serializers by media type, negotiated from the Accept header. See [WithErrorSerializers].
+	errorSerializers map[string]ErrorSender
 
 	startTime time.Time
 
@@ -62,8 +87,43 @@ type Server struct {
 	middlewares []func(http.Handler) http.Handler
 
 	maxBodySize int64
+	// Maximum nesting depth of the JSON request bodies. See [WithMaxJSONDepth].
+	maxJSONDepth int
+	// Paths of the named routes, shared with the groups. See [OptionName].
+	routeNames map[string]string
+	// Reject the text/plain and JSON request bodies that are not valid UTF-8. See [WithUTF8Validation].
+	validateUTF8 bool
+	// Codec of the protobuf bodies and responses. See [WithProtoCodec].
+	protoCodec ProtoCodec
+	// Codec of the protobuf messages read and sent as JSON. See [WithProtoJSONCodec].
+	protoJSONCodec ProtoCodec
+	// Send the numbers of the JSON responses in decimal notation. See [WithDecimalJSONFloats].
+	decimalJSONFloats bool
+	// Maximum number of parts of the multipart/form-data request bodies. See [WithMaxMultipartParts].
+	maxMultipartParts int
+	// Maximum number of fields of the form request bodies. See [WithMaxFormFields].
+	maxFormFields int
+	// Maximum duration allowed to read the whole request body. See [WithBodyReadTimeout].
+	bodyReadTimeout time.Duration
+	// Report the size of the request bodies in the X-Body-Bytes header. See [WithBodySizeReport].
+	reportBodySize bool
+	// Maximum size of the response bodies. See [WithResponseSizeLimit].
+	responseSizeLimit ResponseSizeLimit
+	// Buffer the responses to send their Content-Length. See [WithBufferedResponses].
+	bufferResponses         bool
+	maxBufferedResponseSize int64
+	// Compute the ETag of the responses and send 304 Not Modified when it matches. See [WithAutoETag].
+	autoETag bool
+	// Share the execution of identical concurrent GET requests. See [WithSingleflight].
+	singleflight *singleflightGroup
 	// If true, the server will return an error if the request body contains unknown fields. Useful for quick debugging in development.
-	DisallowUnknownFields  bool
+	DisallowUnknownFields bool
+	// Trim leading and trailing whitespace of query and path params. See [WithTrimParamWhitespace].
+	trimParamWhitespace bool
+	// Rewrites the Content-Type of the requests before decoding. See [WithContentTypeNormalizer].
+	contentTypeNormalizer func(string) string
+	// Decrypts the fields tagged `encrypted:"true"` of the request bodies. See [WithFieldEncryption].
+	fieldKeys              FieldKeyProvider
 	disableStartupMessages bool
 	disableAutoGroupTags   bool
 	isTLS                  bool
@@ -96,6 +156,7 @@ func NewServer(options ...func(*Server)) *Server {
 		Security: NewSecurity(),
 
 		loggingConfig: defaultLoggingConfig,
+		routeNames:    make(map[string]string),
 	}
 
 	// Default options that can be overridden
@@ -302,6 +363,14 @@ func WithMaxBodySize(maxBodySize int64) func(*Server) {
 	return func(c *Server) { c.maxBodySize = maxBodySize }
 }
 
+// WithBodyReadTimeout sets the maximum duration allowed to read the whole request body,
+// independently of the request deadline.
+// Slow uploads (Slowloris-style) exceeding this duration are rejected with a 408 [RequestTimeoutError].
+// Defaults to 0 (no timeout).
+func WithBodyReadTimeout(timeout time.Duration) func(*Server) {
+	return func(c *Server) { c.bodyReadTimeout = timeout }
+}
+
 func WithAutoAuth(verifyUserInfo func(user, password string) (jwt.Claims, error)) func(*Server) {
 	return func(c *Server) {
 		c.autoAuth.Enabled = true
@@ -317,6 +386,27 @@ func WithDisallowUnknownFields(b bool) func(*Server) {
 	return func(c *Server) { c.DisallowUnknownFields = b }
 }
 
+// WithTrimParamWhitespace trims leading and trailing whitespace of query and path params,
+// so that ?name=%20foo%20 is read as "foo" by [Context.QueryParam], [Context.PathParam] and [Context.Params].
+// Defaults to false: params are read as sent by the client.
+func WithTrimParamWhitespace() func(*Server) {
+	return func(c *Server) { c.trimParamWhitespace = true }
+}
+
+// WithContentTypeNormalizer rewrites the Content-Type of the requests before decoding their body,
+// to map non-standard media types sent by some clients to the supported decoders.
+// The function receives the Content-Type header as sent by the client, possibly empty.
+//
+//	fuego.WithContentTypeNormalizer(func(contentType string) string {
+//		if contentType == "text/json" || contentType == "application/json5" {
+//			return "application/json"
+//		}
+//		return contentType
+//	})
+func WithContentTypeNormalizer(normalize func(contentType string) string) func(*Server) {
+	return func(c *Server) { c.contentTypeNormalizer = normalize }
+}
+
 // WithAddr optionally specifies the TCP address for the server to listen on, in the form "host:port".
 // If not specified addr ':9999' will be used.
 // If a listener is explicitly set using WithListener, the provided address will be ignored,
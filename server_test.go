@@ -8,6 +8,7 @@ import (
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/getkin/kin-openapi/openapi3"
@@ -165,6 +166,50 @@ func TestWithMaxBodySize(t *testing.T) {
 	require.Equal(t, int64(1024), s.maxBodySize)
 }
 
+func TestWithContentTypeNormalizer(t *testing.T) {
+	type recipe struct {
+		Name string `json:"name"`
+	}
+
+	var normalized []string
+	s := NewServer(
+		WithContentTypeNormalizer(func(contentType string) string {
+			normalized = append(normalized, contentType)
+			switch contentType {
+			case "text/json", "application/json5":
+				return "application/json"
+			case "text/x-yaml":
+				return "application/yaml"
+			}
+			return contentType
+		}),
+	)
+	Post(s, "/recipes", func(c ContextWithBody[recipe]) (recipe, error) {
+		return c.Body()
+	})
+
+	t.Run("maps text/json to the JSON decoder", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/recipes", strings.NewReader(`{"name":"Pizza"}`))
+		r.Header.Set("Content-Type", "text/json")
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.JSONEq(t, `{"name":"Pizza"}`, w.Body.String())
+		require.Equal(t, []string{"text/json"}, normalized)
+	})
+
+	t.Run("maps a non-standard type to another decoder", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/recipes", strings.NewReader(`name: Pizza`))
+		r.Header.Set("Content-Type", "text/x-yaml")
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.JSONEq(t, `{"name":"Pizza"}`, w.Body.String())
+	})
+}
+
 func TestWithAutoAuth(t *testing.T) {
 	s := NewServer(
 		WithAutoAuth(nil),
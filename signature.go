@@ -0,0 +1,84 @@
+package fuego
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" // #nosec G505 (HMAC-SHA1 is still used by some webhook providers)
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"net/http"
+	"strings"
+)
+
+// VerifySignature checks the HMAC of the request body, computed with the secret, against the signature
+// sent in the given header, as done by webhook providers. algo is "sha256", "sha512" or "sha1".
+// The signature is hex or base64-encoded, with an optional "<algo>=" prefix,
+// like GitHub's "X-Hub-Signature-256: sha256=6f1c...". Signatures are compared in constant time.
+// It returns a 401 [UnauthorizedError] if the header is missing or does not match.
+// The body is buffered, so it can still be read afterwards.
+// Customizable by modifying ReadOptions.
+func VerifySignature(r *http.Request, header string, secret []byte, algo string) error {
+	return verifySignature(r, ReadOptions, header, secret, algo)
+}
+
+func verifySignature(r *http.Request, options readOptions, header string, secret []byte, algo string) error {
+	newHash, err := hmacHash(algo)
+	if err != nil {
+		return err
+	}
+
+	signature := strings.TrimSpace(r.Header.Get(header))
+	if prefix, value, found := strings.Cut(signature, "="); found && strings.EqualFold(prefix, algo) {
+		signature = value
+	}
+	if signature == "" {
+		return UnauthorizedError{
+			Title:  "Missing Signature",
+			Err:    errors.New("missing " + header + " header"),
+			Detail: fmt.Sprintf("the %s header is required to verify the request", header),
+		}
+	}
+
+	body, err := bufferBody(r, options)
+	if err != nil {
+		return err
+	}
+
+	mac := hmac.New(newHash, secret)
+	mac.Write(body)
+	sum := mac.Sum(nil)
+
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		expected, err = base64.StdEncoding.DecodeString(signature)
+	}
+	if err != nil || !hmac.Equal(sum, expected) {
+		return UnauthorizedError{
+			Title:  "Invalid Signature",
+			Err:    fmt.Errorf("request body does not match the %s header", header),
+			Detail: fmt.Sprintf("the signature of the %s header is invalid", header),
+		}
+	}
+	return nil
+}
+
+func hmacHash(algo string) (func() hash.Hash, error) {
+	switch strings.ToLower(strings.ReplaceAll(algo, "-", "")) {
+	case "sha256":
+		return sha256.New, nil
+	case "sha512":
+		return sha512.New, nil
+	case "sha1":
+		return sha1.New, nil
+	}
+	return nil, fmt.Errorf("unsupported signature algorithm %q", algo)
+}
+
+// VerifySignature checks the HMAC of the request body against the signature of the given header, see [VerifySignature].
+func (c netHttpContext[B, P]) VerifySignature(header string, secret []byte, algo string) error {
+	return verifySignature(c.Req, c.readOptions, header, secret, algo)
+}
@@ -0,0 +1,106 @@
+package fuego
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" // #nosec G505
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifySignature(t *testing.T) {
+	secret := []byte("webhook-secret")
+	payload := `{"action":"opened","number":42}`
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(payload))
+	signature := mac.Sum(nil)
+
+	type event struct {
+		Action string `json:"action"`
+		Number int    `json:"number"`
+	}
+	s := NewServer()
+	Post(s, "/webhooks/github", func(c ContextWithBody[event]) (event, error) {
+		if err := c.VerifySignature("X-Hub-Signature-256", secret, "sha256"); err != nil {
+			return event{}, err
+		}
+		return c.Body()
+	})
+
+	post := func(t *testing.T, body, header string) *httptest.ResponseRecorder {
+		t.Helper()
+		r := httptest.NewRequest(http.MethodPost, "/webhooks/github", strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		if header != "" {
+			r.Header.Set("X-Hub-Signature-256", header)
+		}
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("valid signature, body still readable", func(t *testing.T) {
+		w := post(t, payload, "sha256="+hex.EncodeToString(signature))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.JSONEq(t, payload, w.Body.String())
+	})
+
+	t.Run("forged signature", func(t *testing.T) {
+		forged := hmac.New(sha256.New, []byte("guessed-secret"))
+		forged.Write([]byte(payload))
+
+		w := post(t, payload, "sha256="+hex.EncodeToString(forged.Sum(nil)))
+		require.Equal(t, http.StatusUnauthorized, w.Code)
+		require.Contains(t, w.Body.String(), "Invalid Signature")
+	})
+
+	t.Run("tampered body", func(t *testing.T) {
+		w := post(t, `{"action":"closed","number":42}`, "sha256="+hex.EncodeToString(signature))
+		require.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("missing or garbage header", func(t *testing.T) {
+		require.Equal(t, http.StatusUnauthorized, post(t, payload, "").Code)
+		require.Equal(t, http.StatusUnauthorized, post(t, payload, "sha256=").Code)
+		require.Equal(t, http.StatusUnauthorized, post(t, payload, "not a signature").Code)
+	})
+
+	t.Run("encodings and algorithms", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			algo, header string
+		}{
+			"hex without prefix": {"sha256", hex.EncodeToString(signature)},
+			"base64":             {"sha256", base64.StdEncoding.EncodeToString(signature)},
+			"uppercase prefix":   {"SHA256", "SHA256=" + hex.EncodeToString(signature)},
+			"sha1": {"sha1", func() string {
+				mac := hmac.New(sha1.New, secret)
+				mac.Write([]byte(payload))
+				return "sha1=" + hex.EncodeToString(mac.Sum(nil))
+			}()},
+		} {
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(payload))
+			r.Header.Set("X-Signature", tc.header)
+			require.NoError(t, VerifySignature(r, "X-Signature", secret, tc.algo), name)
+		}
+	})
+
+	t.Run("typed 401", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(payload))
+		r.Header.Set("X-Signature", "sha256=00")
+		var unauthorized UnauthorizedError
+		require.ErrorAs(t, VerifySignature(r, "X-Signature", secret, "sha256"), &unauthorized)
+		require.Equal(t, "Invalid Signature", unauthorized.Title)
+	})
+
+	t.Run("unsupported algorithm", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(payload))
+		require.ErrorContains(t, VerifySignature(r, "X-Signature", secret, "md5"), "unsupported")
+	})
+}
@@ -0,0 +1,176 @@
+package fuego
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// WithSingleflight shares the execution of identical concurrent GET requests, to protect hot endpoints
+// from cache stampedes: while a request is handled, identical requests wait for its response instead of
+// calling the controller again. Once the response is sent, the next request executes the controller again.
+//
+// Requests are identical if they have the same canonical URL (host, path and sorted query params),
+// and the same headers used for authentication and content negotiation, like Authorization, Cookie,
+// Accept or Accept-Language, so that responses are never shared between users.
+// A waiting request is also handled on its own if the shared response varies on a header,
+// see [Context.Vary], with another value in the waiting request.
+//
+// The shared response is buffered before being sent to all the clients: streamed responses are sent at once.
+// The controller is not canceled when the client that triggered it disconnects, as other clients may be waiting for it.
+func WithSingleflight() func(*Server) {
+	return func(s *Server) {
+		s.singleflight = &singleflightGroup{}
+	}
+}
+
+// singleflightGroup tracks the requests being handled, by key, see [WithSingleflight].
+type singleflightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*singleflightCall
+}
+
+// singleflightCall is a request being handled, and its response once done.
+type singleflightCall struct {
+	done     chan struct{}
+	header   http.Header
+	response *recordedResponse
+	// completed is false if the handler panicked: the response must not be shared.
+	completed bool
+	// dups is the number of requests waiting for the response.
+	dups int
+}
+
+// singleflightHeaders are the request headers of the key of [singleflightKey]:
+// the framework negotiates the response on some of them.
+var singleflightHeaders = []string{
+	"Accept",
+	"Accept-Charset",
+	"Accept-Encoding",
+	"Accept-Language",
+	"Authorization",
+	"Cookie",
+	"HX-Request",
+}
+
+// singleflightKey returns the key identifying identical requests.
+func singleflightKey(r *http.Request) string {
+	var key strings.Builder
+	key.WriteString(r.Host)
+	key.WriteString(r.URL.EscapedPath())
+	key.WriteByte('?')
+	key.WriteString(r.URL.Query().Encode())
+	for _, header := range singleflightHeaders {
+		key.WriteByte('\n')
+		key.WriteString(strings.Join(r.Header.Values(header), ", "))
+	}
+	return key.String()
+}
+
+// sameVaryHeaders reports whether the response, sent for a request with the given headers,
+// can be shared with a request with the other headers, given the Vary header of the response.
+func sameVaryHeaders(response, header, other http.Header) bool {
+	for _, line := range response.Values("Vary") {
+		for name := range strings.SplitSeq(line, ",") {
+			name = strings.TrimSpace(name)
+			if name == "*" {
+				return false
+			}
+			if strings.Join(header.Values(name), ", ") != strings.Join(other.Values(name), ", ") {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// serveHTTP handles the request with the handler, unless an identical request is being handled:
+// its response is sent instead.
+func (g *singleflightGroup) serveHTTP(w http.ResponseWriter, r *http.Request, handler http.HandlerFunc) {
+	key := singleflightKey(r)
+
+	g.mu.Lock()
+	if call, ok := g.calls[key]; ok {
+		call.dups++
+		g.mu.Unlock()
+
+		select {
+		case <-call.done:
+		case <-r.Context().Done():
+			return
+		}
+		if !call.completed || !sameVaryHeaders(call.response.header, call.header, r.Header) {
+			// The shared execution panicked, or its response varies on a header of this request.
+			handler(w, r)
+			return
+		}
+		call.response.writeTo(w)
+		return
+	}
+	if g.calls == nil {
+		g.calls = make(map[string]*singleflightCall)
+	}
+	call := &singleflightCall{
+		done:     make(chan struct{}),
+		header:   r.Header.Clone(),
+		response: newRecordedResponse(),
+	}
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	defer func() {
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		close(call.done)
+	}()
+
+	handler(call.response, r.WithContext(context.WithoutCancel(r.Context())))
+	call.completed = true
+	call.response.writeTo(w)
+}
+
+// recordedResponse buffers a response, to send it to several clients.
+type recordedResponse struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newRecordedResponse() *recordedResponse {
+	return &recordedResponse{header: make(http.Header)}
+}
+
+func (r *recordedResponse) Header() http.Header {
+	return r.header
+}
+
+func (r *recordedResponse) WriteHeader(code int) {
+	// Informational responses cannot be shared, only the final status is kept.
+	if r.status == 0 && code >= http.StatusOK {
+		r.status = code
+	}
+}
+
+func (r *recordedResponse) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return r.body.Write(b)
+}
+
+// writeTo sends the recorded response.
+func (r *recordedResponse) writeTo(w http.ResponseWriter) {
+	header := w.Header()
+	for key, values := range r.header {
+		header[key] = append([]string(nil), values...)
+	}
+	status := r.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.WriteHeader(status)
+	_, _ = w.Write(r.body.Bytes())
+}
@@ -0,0 +1,177 @@
+package fuego
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// waitForDups waits until the given number of requests wait for the shared execution of the key.
+func waitForDups(t *testing.T, g *singleflightGroup, key string, dups int) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		call, ok := g.calls[key]
+		return ok && call.dups == dups
+	}, time.Second, time.Millisecond)
+}
+
+func TestWithSingleflight(t *testing.T) {
+	const n = 10
+
+	t.Run("identical concurrent requests share a single execution", func(t *testing.T) {
+		s := NewServer(WithSingleflight())
+
+		var executions atomic.Int32
+		release := make(chan struct{})
+		Get(s, "/recipes", func(c ContextNoBody) (ans, error) {
+			executions.Add(1)
+			<-release
+			c.SetHeader("X-Recipes", "all")
+			return ans{Ans: "pizza"}, nil
+		})
+
+		recorders := make([]*httptest.ResponseRecorder, n)
+		var wg sync.WaitGroup
+		for i := range recorders {
+			recorders[i] = httptest.NewRecorder()
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				// Query params in another order are the same request.
+				target := "/recipes?a=1&b=2"
+				if i%2 == 0 {
+					target = "/recipes?b=2&a=1"
+				}
+				s.Mux.ServeHTTP(recorders[i], httptest.NewRequest(http.MethodGet, target, nil))
+			}()
+		}
+
+		waitForDups(t, s.singleflight, singleflightKey(httptest.NewRequest(http.MethodGet, "/recipes?a=1&b=2", nil)), n-1)
+		close(release)
+		wg.Wait()
+
+		require.Equal(t, int32(1), executions.Load())
+		for _, w := range recorders {
+			require.Equal(t, http.StatusOK, w.Code)
+			require.Equal(t, "all", w.Header().Get("X-Recipes"))
+			require.JSONEq(t, `{"ans":"pizza"}`, w.Body.String())
+		}
+
+		t.Run("the next request executes the controller again", func(t *testing.T) {
+			w := httptest.NewRecorder()
+			s.Mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/recipes?a=1&b=2", nil))
+
+			require.Equal(t, http.StatusOK, w.Code)
+			require.Equal(t, int32(2), executions.Load())
+		})
+	})
+
+	t.Run("different concurrent requests are executed separately", func(t *testing.T) {
+		s := NewServer(WithSingleflight())
+
+		var executions atomic.Int32
+		release := make(chan struct{})
+		Get(s, "/recipes", func(c ContextNoBody) (string, error) {
+			executions.Add(1)
+			<-release
+			return c.QueryParam("name") + " " + c.Header("Authorization"), nil
+		})
+		Post(s, "/recipes", func(c ContextNoBody) (string, error) {
+			executions.Add(1)
+			<-release
+			return "created", nil
+		})
+
+		requests := []*http.Request{
+			httptest.NewRequest(http.MethodGet, "/recipes?name=pizza", nil),
+			httptest.NewRequest(http.MethodGet, "/recipes?name=pasta", nil),
+			httptest.NewRequest(http.MethodGet, "/recipes?name=pizza", nil),
+			httptest.NewRequest(http.MethodPost, "/recipes", nil),
+			httptest.NewRequest(http.MethodPost, "/recipes", nil),
+		}
+		requests[2].Header.Set("Authorization", "Bearer other-user")
+
+		recorders := make([]*httptest.ResponseRecorder, len(requests))
+		var wg sync.WaitGroup
+		for i, r := range requests {
+			recorders[i] = httptest.NewRecorder()
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				s.Mux.ServeHTTP(recorders[i], r)
+			}()
+		}
+
+		require.Eventually(t, func() bool {
+			return executions.Load() == int32(len(requests))
+		}, time.Second, time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		require.Equal(t, "pizza ", recorders[0].Body.String())
+		require.Equal(t, "pasta ", recorders[1].Body.String())
+		require.Equal(t, "pizza Bearer other-user", recorders[2].Body.String())
+		require.Equal(t, "created", recorders[3].Body.String())
+		require.Equal(t, "created", recorders[4].Body.String())
+	})
+
+	t.Run("negotiated headers are part of the key", func(t *testing.T) {
+		key := singleflightKey(httptest.NewRequest(http.MethodGet, "/recipes", nil))
+		for _, header := range []string{"Accept-Language", "Accept-Charset", "Accept-Encoding"} {
+			r := httptest.NewRequest(http.MethodGet, "/recipes", nil)
+			r.Header.Set(header, "other")
+			require.NotEqual(t, key, singleflightKey(r), header)
+		}
+	})
+
+	t.Run("responses varying on a header are not shared with other values", func(t *testing.T) {
+		s := NewServer(WithSingleflight())
+
+		var executions atomic.Int32
+		release := make(chan struct{})
+		Get(s, "/recipes", func(c ContextNoBody) (string, error) {
+			executions.Add(1)
+			<-release
+			c.Vary("X-Tenant")
+			return c.Header("X-Tenant"), nil
+		})
+
+		requests := []*http.Request{
+			httptest.NewRequest(http.MethodGet, "/recipes", nil),
+			httptest.NewRequest(http.MethodGet, "/recipes", nil),
+		}
+		requests[0].Header.Set("X-Tenant", "acme")
+		requests[1].Header.Set("X-Tenant", "globex")
+
+		recorders := make([]*httptest.ResponseRecorder, len(requests))
+		var wg sync.WaitGroup
+		recorders[0] = httptest.NewRecorder()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.Mux.ServeHTTP(recorders[0], requests[0])
+		}()
+		require.Eventually(t, func() bool { return executions.Load() == 1 }, time.Second, time.Millisecond)
+
+		recorders[1] = httptest.NewRecorder()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.Mux.ServeHTTP(recorders[1], requests[1])
+		}()
+		waitForDups(t, s.singleflight, singleflightKey(requests[0]), 1)
+		close(release)
+		wg.Wait()
+
+		require.Equal(t, int32(2), executions.Load())
+		require.Equal(t, "acme", recorders[0].Body.String())
+		require.Equal(t, "globex", recorders[1].Body.String())
+	})
+}
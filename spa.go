@@ -0,0 +1,64 @@
+package fuego
+
+import (
+	"errors"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// ServeSPA serves a Single Page Application from the filesystem: the requested file if it exists,
+// otherwise the index file, so client-side routing works for deep links like "/settings/profile".
+// Missing files with an extension, like "/missing.js", get a [NotFoundError] instead of the index.
+// The index is sent with "Cache-Control: no-cache", so new releases are picked up right away,
+// and hashed assets, like "app.3f2a1b9c.js" or "index-BXk3a9_Z.css", are cached for a year as immutable.
+// Files are served with [ServeFileCompressed].
+// Example:
+//
+//	fuego.Get(s, "/app/{path...}", func(c fuego.ContextNoBody) (any, error) {
+//		return nil, fuego.ServeSPA(c.Response(), c.Request(), app, c.PathParam("path"), "index.html")
+//	})
+func ServeSPA(w http.ResponseWriter, r *http.Request, fsys fs.FS, name, indexFile string) error {
+	name = strings.TrimPrefix(path.Clean("/"+name), "/")
+
+	if name != "" && name != indexFile {
+		info, err := fs.Stat(fsys, name)
+		switch {
+		case err == nil && !info.IsDir():
+			if isHashedAsset(name) {
+				w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+			} else {
+				w.Header().Set("Cache-Control", "no-cache")
+			}
+			return ServeFileCompressed(w, r, fsys, name)
+		case err != nil && !errors.Is(err, fs.ErrNotExist):
+			return err
+		case path.Ext(name) != "":
+			return NotFoundError{Title: "File Not Found", Detail: "file " + name + " not found"}
+		}
+	}
+
+	w.Header().Set("Cache-Control", "no-cache")
+	return ServeFileCompressed(w, r, fsys, indexFile)
+}
+
+// isHashedAsset reports whether the file name contains a content hash, as added by bundlers:
+// a segment of at least 8 alphanumeric characters, with at least one digit, before the extension.
+func isHashedAsset(name string) bool {
+	base := strings.TrimSuffix(path.Base(name), path.Ext(name))
+	i := strings.LastIndexAny(base, ".-")
+	if i < 0 {
+		return false
+	}
+	hash := base[i+1:]
+	if len(hash) < 8 || !strings.ContainsAny(hash, "0123456789") {
+		return false
+	}
+	for _, c := range hash {
+		if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '_') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,87 @@
+package fuego
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestServeSPA(t *testing.T) {
+	index := `<!DOCTYPE html><html><body><div id="app"></div><script src="/app/assets/app.3f2a1b9c.js"></script></body></html>`
+	app := fstest.MapFS{
+		"index.html":                {Data: []byte(index)},
+		"assets/app.3f2a1b9c.js":    {Data: []byte("console.log('app')")},
+		"assets/index-BXk3a9_Z.css": {Data: []byte("body{}")},
+		"favicon.ico":               {Data: []byte("\x00\x00\x01\x00")},
+	}
+	s := NewServer()
+	Get(s, "/app/{path...}", func(c ContextNoBody) (any, error) {
+		return nil, ServeSPA(c.Response(), c.Request(), app, c.PathParam("path"), "index.html")
+	})
+
+	get := func(t *testing.T, path string) *httptest.ResponseRecorder {
+		t.Helper()
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	t.Run("existing hashed asset", func(t *testing.T) {
+		w := get(t, "/app/assets/app.3f2a1b9c.js")
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "text/javascript; charset=utf-8", w.Header().Get("Content-Type"))
+		require.Equal(t, "public, max-age=31536000, immutable", w.Header().Get("Cache-Control"))
+		require.Equal(t, "console.log('app')", w.Body.String())
+
+		w = get(t, "/app/assets/index-BXk3a9_Z.css")
+		require.Equal(t, "text/css; charset=utf-8", w.Header().Get("Content-Type"))
+		require.Equal(t, "public, max-age=31536000, immutable", w.Header().Get("Cache-Control"))
+	})
+
+	t.Run("existing asset without hash", func(t *testing.T) {
+		w := get(t, "/app/favicon.ico")
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "no-cache", w.Header().Get("Cache-Control"))
+	})
+
+	t.Run("deep link falls back to index", func(t *testing.T) {
+		for _, path := range []string{"/app/", "/app/settings/profile", "/app/index.html", "/app/assets"} {
+			w := get(t, path)
+
+			require.Equal(t, http.StatusOK, w.Code, path)
+			require.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"), path)
+			require.Equal(t, "no-cache", w.Header().Get("Cache-Control"), path)
+			require.Equal(t, index, w.Body.String(), path)
+		}
+	})
+
+	t.Run("missing file with an extension", func(t *testing.T) {
+		require.Equal(t, http.StatusNotFound, get(t, "/app/assets/missing.js").Code)
+	})
+
+	t.Run("path traversal stays in the filesystem", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		err := ServeSPA(w, httptest.NewRequest(http.MethodGet, "/", nil), app, "../../etc/passwd", "index.html")
+		require.NoError(t, err)
+		require.Equal(t, index, w.Body.String())
+	})
+}
+
+func TestIsHashedAsset(t *testing.T) {
+	for name, expected := range map[string]bool{
+		"app.3f2a1b9c.js":           true,
+		"assets/index-BXk3a9_Z.css": true,
+		"chunk-5K2ZQWXA.mjs":        true,
+		"app.js":                    false,
+		"app-settings.js":           false,
+		"jquery-3.7.1.min.js":       false,
+		"logo.png":                  false,
+	} {
+		require.Equal(t, expected, isHashedAsset(name), name)
+	}
+}
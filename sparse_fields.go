@@ -0,0 +1,110 @@
+package fuego
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// FilterFields marshals the data to JSON, keeping only the given top-level fields (sparse fieldsets).
+// For arrays, the fields are filtered in each element. Other values are returned as is.
+// Fields are matched with the JSON names, the order of the serialized fields is kept, unknown fields are ignored.
+//
+//	FilterFields(Recipe{ID: 1, Name: "Pizza", Steps: steps}, []string{"id", "name"})
+//	-> {"id":1,"name":"Pizza"}
+func FilterFields(data any, fields []string) (json.RawMessage, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	keep := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		if field = strings.TrimSpace(field); field != "" {
+			keep[field] = true
+		}
+	}
+	return filterJSONFields(raw, keep)
+}
+
+func filterJSONFields(raw json.RawMessage, keep map[string]bool) (json.RawMessage, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return raw, nil
+	}
+
+	switch raw[0] {
+	case '[':
+		var elements []json.RawMessage
+		if err := json.Unmarshal(raw, &elements); err != nil {
+			return nil, err
+		}
+		for i, element := range elements {
+			filtered, err := filterJSONFields(element, keep)
+			if err != nil {
+				return nil, err
+			}
+			elements[i] = filtered
+		}
+		return json.Marshal(elements)
+	case '{':
+		return filterJSONObject(raw, keep)
+	default:
+		return raw, nil
+	}
+}
+
+// filterJSONObject keeps the given fields of a JSON object, in their original order.
+func filterJSONObject(raw json.RawMessage, keep map[string]bool) (json.RawMessage, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	if _, err := decoder.Token(); err != nil { // {
+		return nil, err
+	}
+
+	var filtered bytes.Buffer
+	filtered.WriteByte('{')
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := token.(string)
+
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil, err
+		}
+		if !keep[key] {
+			continue
+		}
+
+		if filtered.Len() > 1 {
+			filtered.WriteByte(',')
+		}
+		encodedKey, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		filtered.Write(encodedKey)
+		filtered.WriteByte(':')
+		filtered.Write(value)
+	}
+	filtered.WriteByte('}')
+
+	return filtered.Bytes(), nil
+}
+
+// SerializeFields sends the data as JSON with only the given top-level fields, see [FilterFields].
+// Without fields, the data is serialized as usual.
+func (c netHttpContext[B, P]) SerializeFields(data any, fields []string) error {
+	if len(fields) == 0 {
+		return c.Serialize(data)
+	}
+
+	filtered, err := FilterFields(data, fields)
+	if err != nil {
+		return err
+	}
+	c.SetDefaultStatusCode()
+	return SendJSON(c.Res, c.Req, filtered)
+}
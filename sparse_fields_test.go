@@ -0,0 +1,81 @@
+package fuego
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type sparseRecipe struct {
+	ID          int      `json:"id"`
+	Name        string   `json:"name"`
+	Ingredients []string `json:"ingredients"`
+	Secret      string   `json:"-"`
+}
+
+func TestFilterFields(t *testing.T) {
+	recipe := sparseRecipe{ID: 1, Name: "Pizza", Ingredients: []string{"dough", "tomato"}, Secret: "x"}
+
+	t.Run("keeps the requested fields in order", func(t *testing.T) {
+		filtered, err := FilterFields(recipe, []string{"name", " id"})
+		require.NoError(t, err)
+		require.Equal(t, `{"id":1,"name":"Pizza"}`, string(filtered))
+	})
+
+	t.Run("ignores unknown fields", func(t *testing.T) {
+		filtered, err := FilterFields(recipe, []string{"ingredients", "unknown", "Secret"})
+		require.NoError(t, err)
+		require.Equal(t, `{"ingredients":["dough","tomato"]}`, string(filtered))
+	})
+
+	t.Run("filters each element of collections", func(t *testing.T) {
+		filtered, err := FilterFields([]sparseRecipe{recipe, {ID: 2, Name: "Pasta"}}, []string{"id"})
+		require.NoError(t, err)
+		require.Equal(t, `[{"id":1},{"id":2}]`, string(filtered))
+	})
+
+	t.Run("maps", func(t *testing.T) {
+		filtered, err := FilterFields(map[string]any{"a": 1, "b": map[string]int{"c": 2}}, []string{"b"})
+		require.NoError(t, err)
+		require.Equal(t, `{"b":{"c":2}}`, string(filtered))
+	})
+
+	t.Run("other values are kept", func(t *testing.T) {
+		filtered, err := FilterFields("hello", []string{"id"})
+		require.NoError(t, err)
+		require.Equal(t, `"hello"`, string(filtered))
+	})
+}
+
+func TestContext_SerializeFields(t *testing.T) {
+	s := NewServer()
+	Get(s, "/recipes", func(c ContextNoBody) (any, error) {
+		recipes := []sparseRecipe{{ID: 1, Name: "Pizza", Ingredients: []string{"dough"}}}
+		var fields []string
+		if c.QueryParam("fields") != "" {
+			fields = strings.Split(c.QueryParam("fields"), ",")
+		}
+		return nil, c.SerializeFields(recipes, fields)
+	}, OptionQuery("fields", "Fields to return"))
+
+	t.Run("subset of fields", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/recipes?fields=id,name,unknown", nil)
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		require.Equal(t, crlf(`[{"id":1,"name":"Pizza"}]`), w.Body.String())
+	})
+
+	t.Run("all fields without filter", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/recipes", nil)
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.JSONEq(t, `[{"id":1,"name":"Pizza","ingredients":["dough"]}]`, w.Body.String())
+	})
+}
@@ -0,0 +1,57 @@
+package fuego
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// WriteLines writes each string received from the channel as a line, flushing it to the client,
+// until the channel is closed or the context is done (client disconnected).
+// The response is sent as "text/plain; charset=utf-8" with the given status code, if not 0.
+// Lines are read from the channel only once the previous one has been written:
+// a slow client slows down the producer instead of accumulating lines in memory.
+// The producer should stop when the context is done, as the channel is no longer read.
+func WriteLines(ctx context.Context, w http.ResponseWriter, status int, ch <-chan string) error {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Del("Content-Length")
+	if status != 0 {
+		w.WriteHeader(status)
+	}
+
+	// Sends the headers right away, so clients do not wait for the first line.
+	controller := http.NewResponseController(w)
+	if err := controller.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			// The client is gone: nothing more can be sent.
+			return nil
+		case line, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			if !strings.HasSuffix(line, "\n") {
+				line += "\n"
+			}
+			if _, err := io.WriteString(w, line); err != nil {
+				return err
+			}
+			if err := controller.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+				return err
+			}
+		}
+	}
+}
+
+// TailStream writes the lines received from the channel to the response, see [WriteLines].
+func (c netHttpContext[B, P]) TailStream(ch <-chan string) error {
+	return WriteLines(c.Req.Context(), c.Res, c.DefaultStatusCode, ch)
+}
@@ -0,0 +1,82 @@
+package fuego
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTailStream(t *testing.T) {
+	t.Run("writes the lines in order", func(t *testing.T) {
+		s := NewServer()
+		Get(s, "/logs", func(c ContextNoBody) (any, error) {
+			lines := make(chan string)
+			go func() {
+				defer close(lines)
+				for i := range 5 {
+					lines <- "line " + strconv.Itoa(i)
+				}
+				lines <- "already terminated\n"
+			}()
+			return nil, c.TailStream(lines)
+		})
+
+		r := httptest.NewRequest(http.MethodGet, "/logs", nil)
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "text/plain; charset=utf-8", w.Header().Get("Content-Type"))
+		require.True(t, w.Flushed)
+		require.Equal(t, "line 0\nline 1\nline 2\nline 3\nline 4\nalready terminated\n", w.Body.String())
+	})
+
+	t.Run("lines are delivered as they are produced", func(t *testing.T) {
+		lines := make(chan string)
+		s := NewServer()
+		Get(s, "/logs", func(c ContextNoBody) (any, error) {
+			return nil, c.TailStream(lines)
+		})
+		server := httptest.NewServer(s.Mux)
+		defer server.Close()
+
+		resp, err := http.Get(server.URL + "/logs")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		reader := bufio.NewReader(resp.Body)
+		for _, line := range []string{"first", "second"} {
+			lines <- line
+			received, err := reader.ReadString('\n')
+			require.NoError(t, err)
+			require.Equal(t, line+"\n", received)
+		}
+		close(lines)
+	})
+
+	t.Run("stops when the client disconnects", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		r := httptest.NewRequest(http.MethodGet, "/logs", nil).WithContext(ctx)
+		c := NewNetHTTPContext[any, any](BaseRoute{}, httptest.NewRecorder(), r, readOptions{})
+
+		lines := make(chan string) // never closed
+		done := make(chan error)
+		go func() { done <- c.TailStream(lines) }()
+
+		lines <- "hello"
+		cancel()
+
+		select {
+		case err := <-done:
+			require.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("TailStream did not return after the client disconnected")
+		}
+	})
+}
@@ -0,0 +1,114 @@
+package fuego
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ThrottledWriter wraps [http.ResponseWriter] to cap the rate of the response body, in bytes per second,
+// with a token bucket holding up to a tenth of a second of bytes: short bursts are sent at once,
+// and the average rate never exceeds the limit. Writes wait for the bucket to refill,
+// until the context is done (client disconnected).
+// Useful for media servers, to prevent a single download from saturating the link. See [Context.SetBandwidthLimit].
+type ThrottledWriter struct {
+	http.ResponseWriter
+	ctx context.Context
+
+	mu          sync.Mutex
+	bytesPerSec int64
+	tokens      float64
+	last        time.Time
+}
+
+// NewThrottledWriter caps the rate of w to bytesPerSec. A limit of 0 or less means no limit.
+func NewThrottledWriter(ctx context.Context, w http.ResponseWriter, bytesPerSec int64) *ThrottledWriter {
+	t := &ThrottledWriter{ResponseWriter: w, ctx: ctx}
+	t.SetLimit(bytesPerSec)
+	return t
+}
+
+// SetLimit changes the rate limit, in bytes per second, for the next writes. A limit of 0 or less means no limit.
+func (t *ThrottledWriter) SetLimit(bytesPerSec int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.bytesPerSec = bytesPerSec
+	t.tokens = float64(t.burst())
+	t.last = time.Now()
+}
+
+// burst is the size of the bucket: the maximum number of bytes sent at once.
+func (t *ThrottledWriter) burst() int {
+	return int(max(t.bytesPerSec/10, 1))
+}
+
+func (t *ThrottledWriter) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	written := 0
+	for len(p) > 0 {
+		if t.bytesPerSec <= 0 {
+			n, err := t.ResponseWriter.Write(p)
+			return written + n, err
+		}
+
+		chunk := min(len(p), t.burst())
+		if err := t.wait(chunk); err != nil {
+			return written, err
+		}
+		n, err := t.ResponseWriter.Write(p[:chunk])
+		written += n
+		if err != nil {
+			return written, err
+		}
+		p = p[chunk:]
+	}
+	return written, nil
+}
+
+// wait blocks until the bucket holds n tokens, and takes them.
+func (t *ThrottledWriter) wait(n int) error {
+	now := time.Now()
+	t.tokens = min(t.tokens+now.Sub(t.last).Seconds()*float64(t.bytesPerSec), float64(t.burst()))
+	t.last = now
+
+	if missing := float64(n) - t.tokens; missing > 0 {
+		timer := time.NewTimer(time.Duration(missing / float64(t.bytesPerSec) * float64(time.Second)))
+		defer timer.Stop()
+		select {
+		case <-t.ctx.Done():
+			return t.ctx.Err()
+		case now = <-timer.C:
+		}
+		t.tokens += now.Sub(t.last).Seconds() * float64(t.bytesPerSec)
+		t.last = now
+	}
+	t.tokens -= float64(n)
+	return nil
+}
+
+func (t *ThrottledWriter) Flush() {
+	if flusher, ok := t.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap returns the underlying [http.ResponseWriter], for [http.ResponseController].
+func (t *ThrottledWriter) Unwrap() http.ResponseWriter {
+	return t.ResponseWriter
+}
+
+// SetBandwidthLimit caps the rate of the rest of the response body, see [ThrottledWriter].
+func (c netHttpContext[B, P]) SetBandwidthLimit(bytesPerSec int64) {
+	w, ok := c.Res.(*responseSizeWriter)
+	if !ok {
+		return
+	}
+	if throttled, ok := w.ResponseWriter.(*ThrottledWriter); ok {
+		throttled.SetLimit(bytesPerSec)
+		return
+	}
+	w.ResponseWriter = NewThrottledWriter(c.Req.Context(), w.ResponseWriter, bytesPerSec)
+}
@@ -0,0 +1,80 @@
+package fuego
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestThrottledWriter(t *testing.T) {
+	t.Run("approximate rate", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		throttled := NewThrottledWriter(context.Background(), w, 20_000)
+
+		start := time.Now()
+		for range 10 {
+			n, err := throttled.Write(bytes.Repeat([]byte("a"), 1_000))
+			require.NoError(t, err)
+			require.Equal(t, 1_000, n)
+		}
+		elapsed := time.Since(start)
+
+		// 10 kB at 20 kB/s, minus the initial burst of 2 kB: 400ms.
+		require.Greater(t, elapsed, 300*time.Millisecond)
+		require.Less(t, elapsed, 800*time.Millisecond)
+		require.Equal(t, 10_000, w.Body.Len())
+	})
+
+	t.Run("no limit", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		throttled := NewThrottledWriter(context.Background(), w, 0)
+
+		start := time.Now()
+		_, err := throttled.Write(make([]byte, 1<<20))
+		require.NoError(t, err)
+		require.Less(t, time.Since(start), 100*time.Millisecond)
+	})
+
+	t.Run("client disconnected", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		w := httptest.NewRecorder()
+		throttled := NewThrottledWriter(ctx, w, 1_000)
+
+		n, err := throttled.Write(make([]byte, 10_000))
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Less(t, n, 1_000)
+		require.Equal(t, n, w.Body.Len())
+	})
+}
+
+func TestContext_SetBandwidthLimit(t *testing.T) {
+	s := NewServer()
+	Get(s, "/download", func(c ContextNoBody) (any, error) {
+		c.SetBandwidthLimit(50_000)
+		_, err := c.Response().Write(bytes.Repeat([]byte("a"), 20_000))
+		return nil, err
+	})
+
+	w := httptest.NewRecorder()
+	start := time.Now()
+	s.Mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/download", nil))
+	elapsed := time.Since(start)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, 20_000, w.Body.Len())
+	// 20 kB at 50 kB/s, minus the initial burst of 5 kB: 300ms.
+	require.Greater(t, elapsed, 200*time.Millisecond)
+	require.Less(t, elapsed, 700*time.Millisecond)
+
+	t.Run("mock context", func(t *testing.T) {
+		c := NewMockContextNoBody()
+		c.SetBandwidthLimit(1 << 20)
+		require.Equal(t, int64(1<<20), c.BandwidthLimit)
+	})
+}
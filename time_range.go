@@ -0,0 +1,83 @@
+package fuego
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultTimeRange is the span of a time range when its start is not provided, see [ParseTimeRange].
+const DefaultTimeRange = 24 * time.Hour
+
+// TimeRangeOption customizes [ParseTimeRange] and [Context.TimeRange].
+type TimeRangeOption func(*timeRangeOptions)
+
+type timeRangeOptions struct {
+	defaultSpan time.Duration
+	maxSpan     time.Duration
+}
+
+// TimeRangeDefault sets the span of the range when its start is not provided: it starts this long before its end.
+// Defaults to [DefaultTimeRange] (the last 24 hours).
+func TimeRangeDefault(span time.Duration) TimeRangeOption {
+	return func(o *timeRangeOptions) {
+		o.defaultSpan = span
+	}
+}
+
+// TimeRangeMax rejects ranges longer than span with a [BadRequestError],
+// to protect expensive reporting queries. No limit by default.
+func TimeRangeMax(span time.Duration) TimeRangeOption {
+	return func(o *timeRangeOptions) {
+		o.maxSpan = span
+	}
+}
+
+// ParseTimeRange parses the start and end of a time range with the given layout, [time.RFC3339] if empty.
+// An empty end means now, and an empty start means [DefaultTimeRange] before the end, see [TimeRangeDefault].
+// It returns a [BadRequestError] if a bound is malformed, or if the start is after the end.
+func ParseTimeRange(fromValue, toValue, layout string, options ...TimeRangeOption) (from, to time.Time, err error) {
+	opts := timeRangeOptions{defaultSpan: DefaultTimeRange}
+	for _, option := range options {
+		option(&opts)
+	}
+	layout = cmp.Or(layout, time.RFC3339)
+
+	to = time.Now()
+	if toValue != "" {
+		to, err = time.Parse(layout, toValue)
+		if err != nil {
+			return time.Time{}, time.Time{}, invalidTimeRangeError(err, fmt.Sprintf("end %q is not a time of layout %q", toValue, layout))
+		}
+	}
+
+	from = to.Add(-opts.defaultSpan)
+	if fromValue != "" {
+		from, err = time.Parse(layout, fromValue)
+		if err != nil {
+			return time.Time{}, time.Time{}, invalidTimeRangeError(err, fmt.Sprintf("start %q is not a time of layout %q", fromValue, layout))
+		}
+	}
+
+	if from.After(to) {
+		return time.Time{}, time.Time{}, invalidTimeRangeError(errors.New("start after end"), "start of the time range must not be after its end")
+	}
+	if opts.maxSpan > 0 && to.Sub(from) > opts.maxSpan {
+		return time.Time{}, time.Time{}, invalidTimeRangeError(errors.New("range too long"), "time range must not be longer than "+opts.maxSpan.String())
+	}
+	return from, to, nil
+}
+
+func invalidTimeRangeError(err error, detail string) error {
+	return BadRequestError{
+		Title:  "Invalid Time Range",
+		Err:    err,
+		Detail: detail,
+	}
+}
+
+// TimeRange parses the time range given by the two query parameters, see [ParseTimeRange].
+func (c netHttpContext[B, P]) TimeRange(fromParam, toParam, layout string, options ...TimeRangeOption) (from, to time.Time, err error) {
+	return ParseTimeRange(c.QueryParam(fromParam), c.QueryParam(toParam), layout, options...)
+}
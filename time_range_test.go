@@ -0,0 +1,96 @@
+package fuego
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTimeRange(t *testing.T) {
+	t.Run("valid range", func(t *testing.T) {
+		from, to, err := ParseTimeRange("2024-01-01T00:00:00Z", "2024-01-31T12:00:00Z", "")
+		require.NoError(t, err)
+		require.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), from)
+		require.Equal(t, time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC), to)
+
+		from, to, err = ParseTimeRange("2024-03-10", "2024-03-10", time.DateOnly)
+		require.NoError(t, err)
+		require.Equal(t, from, to)
+	})
+
+	t.Run("inverted range", func(t *testing.T) {
+		_, _, err := ParseTimeRange("2024-02-01", "2024-01-01", time.DateOnly)
+		var badRequest BadRequestError
+		require.ErrorAs(t, err, &badRequest)
+		require.Equal(t, "Invalid Time Range", badRequest.Title)
+	})
+
+	t.Run("malformed bounds", func(t *testing.T) {
+		for _, bounds := range [][2]string{
+			{"yesterday", "2024-01-01"},
+			{"2024-01-01", "2024-13-01"},
+			{"2024-01-01T00:00:00Z", ""},
+		} {
+			_, _, err := ParseTimeRange(bounds[0], bounds[1], time.DateOnly)
+			var badRequest BadRequestError
+			require.ErrorAs(t, err, &badRequest, bounds)
+		}
+	})
+
+	t.Run("defaults to the last 24 hours", func(t *testing.T) {
+		before := time.Now()
+		from, to, err := ParseTimeRange("", "", "")
+		require.NoError(t, err)
+		require.WithinRange(t, to, before, time.Now())
+		require.Equal(t, DefaultTimeRange, to.Sub(from))
+	})
+
+	t.Run("default start before the given end", func(t *testing.T) {
+		from, to, err := ParseTimeRange("", "2024-01-08", time.DateOnly, TimeRangeDefault(7*24*time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), from)
+		require.Equal(t, time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), to)
+	})
+
+	t.Run("start in the future without an end", func(t *testing.T) {
+		_, _, err := ParseTimeRange(time.Now().Add(time.Hour).Format(time.RFC3339), "", "")
+		require.ErrorAs(t, err, &BadRequestError{})
+	})
+
+	t.Run("maximum span", func(t *testing.T) {
+		_, _, err := ParseTimeRange("2024-01-01", "2024-03-01", time.DateOnly, TimeRangeMax(31*24*time.Hour))
+		require.ErrorAs(t, err, &BadRequestError{})
+
+		_, _, err = ParseTimeRange("2024-01-01", "2024-02-01", time.DateOnly, TimeRangeMax(31*24*time.Hour))
+		require.NoError(t, err)
+	})
+}
+
+func TestContext_TimeRange(t *testing.T) {
+	s := NewServer()
+	Get(s, "/revenue", func(c ContextNoBody) ([]time.Time, error) {
+		from, to, err := c.TimeRange("from", "to", time.DateOnly)
+		return []time.Time{from, to}, err
+	})
+
+	w := httptest.NewRecorder()
+	s.Mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/revenue?from=2024-01-01&to=2024-01-31", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `["2024-01-01T00:00:00Z","2024-01-31T00:00:00Z"]`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	s.Mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/revenue?from=2024-01-31&to=2024-01-01", nil))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "Invalid Time Range")
+
+	t.Run("mock context", func(t *testing.T) {
+		c := NewMockContextNoBody()
+		c.UrlValues.Set("since", "2024-01-01")
+		from, _, err := c.TimeRange("since", "until", time.DateOnly)
+		require.NoError(t, err)
+		require.Equal(t, 2024, from.Year())
+	})
+}
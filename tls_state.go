@@ -0,0 +1,36 @@
+package fuego
+
+import (
+	"crypto/x509"
+	"net/http"
+)
+
+// ClientCertificate returns the client certificate of the request, verified by the server during the TLS handshake,
+// for certificate-based authentication (mTLS). The server must verify client certificates,
+// with [tls.Config.ClientAuth] set to [tls.VerifyClientCertIfGiven] or [tls.RequireAndVerifyClientCert].
+// It returns false for non-TLS requests, and if no certificate was sent or verified.
+func ClientCertificate(r *http.Request) (*x509.Certificate, bool) {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return nil, false
+	}
+	return r.TLS.VerifiedChains[0][0], true
+}
+
+// TLSVersion returns the TLS version of the connection of the request, like [tls.VersionTLS13], or 0 for non-TLS requests.
+// Use [tls.VersionName] to get its name.
+func TLSVersion(r *http.Request) uint16 {
+	if r.TLS == nil {
+		return 0
+	}
+	return r.TLS.Version
+}
+
+// ClientCertificate returns the verified client certificate of the request, see [ClientCertificate].
+func (c netHttpContext[B, P]) ClientCertificate() (*x509.Certificate, bool) {
+	return ClientCertificate(c.Req)
+}
+
+// TLSVersion returns the TLS version of the connection of the request, see [TLSVersion].
+func (c netHttpContext[B, P]) TLSVersion() uint16 {
+	return TLSVersion(c.Req)
+}
@@ -0,0 +1,76 @@
+package fuego
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContext_ClientCertificate(t *testing.T) {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "billing-service"}}
+	ca := &x509.Certificate{Subject: pkix.Name{CommonName: "internal-ca"}, IsCA: true}
+
+	s := NewServer()
+	Get(s, "/whoami", func(c ContextNoBody) (string, error) {
+		cert, ok := c.ClientCertificate()
+		if !ok {
+			return "", UnauthorizedError{Title: "Client Certificate Required"}
+		}
+		return cert.Subject.CommonName + " " + tls.VersionName(c.TLSVersion()), nil
+	})
+
+	request := func(t *testing.T, state *tls.ConnectionState) *httptest.ResponseRecorder {
+		t.Helper()
+		r := httptest.NewRequest(http.MethodGet, "/whoami", nil)
+		r.Header.Set("Accept", "text/plain")
+		r.TLS = state
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("verified client certificate", func(t *testing.T) {
+		w := request(t, &tls.ConnectionState{
+			Version:          tls.VersionTLS13,
+			PeerCertificates: []*x509.Certificate{cert},
+			VerifiedChains:   [][]*x509.Certificate{{cert, ca}},
+		})
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "billing-service TLS 1.3", w.Body.String())
+	})
+
+	t.Run("unverified client certificate", func(t *testing.T) {
+		w := request(t, &tls.ConnectionState{
+			Version:          tls.VersionTLS12,
+			PeerCertificates: []*x509.Certificate{cert},
+		})
+		require.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("non-TLS request", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.TLS = nil
+
+		_, ok := ClientCertificate(r)
+		require.False(t, ok)
+		require.Zero(t, TLSVersion(r))
+		require.Equal(t, http.StatusUnauthorized, request(t, nil).Code)
+	})
+
+	t.Run("mock context", func(t *testing.T) {
+		c := NewMockContextNoBody()
+		_, ok := c.ClientCertificate()
+		require.False(t, ok)
+
+		c.TLS = &tls.ConnectionState{Version: tls.VersionTLS12, VerifiedChains: [][]*x509.Certificate{{cert}}}
+		got, ok := c.ClientCertificate()
+		require.True(t, ok)
+		require.Equal(t, cert, got)
+		require.Equal(t, uint16(tls.VersionTLS12), c.TLSVersion())
+	})
+}
@@ -0,0 +1,117 @@
+package fuego
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+// TraceContext is the W3C Trace Context (https://www.w3.org/TR/trace-context/) of a request,
+// to correlate the logs of the services handling it without a full OpenTelemetry setup. See [NewTraceContext].
+type TraceContext struct {
+	// TraceID identifies the whole trace, shared by all the services: 32 lowercase hex characters.
+	TraceID string
+	// SpanID identifies the handling of the request by this server: 16 lowercase hex characters.
+	SpanID string
+	// ParentID is the span of the caller, from the incoming traceparent header. Empty for a new trace.
+	ParentID string
+	// Flags are the trace flags, like the sampled flag (0x01).
+	Flags byte
+	// State is the vendor-specific tracestate header, propagated as is.
+	State string
+}
+
+// NewTraceContext continues the trace of the incoming traceparent header with a new span,
+// or starts a new sampled trace if the header is absent or malformed.
+func NewTraceContext(header http.Header) TraceContext {
+	trace, ok := ParseTraceparent(header.Get("traceparent"))
+	if !ok {
+		return TraceContext{TraceID: randomHex(16), SpanID: randomHex(8), Flags: 0x01}
+	}
+	trace.ParentID = trace.SpanID
+	trace.SpanID = randomHex(8)
+	trace.State = header.Get("tracestate")
+	return trace
+}
+
+// ParseTraceparent parses a traceparent header, like "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01".
+// The span of the returned [TraceContext] is the span of the caller.
+// It returns false if the header is malformed, or if the trace or span ID is all zeros.
+func ParseTraceparent(value string) (TraceContext, bool) {
+	value = strings.TrimSpace(value)
+	parts := strings.Split(value, "-")
+	if len(parts) < 4 || !isLowerHex(parts[0], 2) || parts[0] == "ff" ||
+		!isLowerHex(parts[1], 32) || !isLowerHex(parts[2], 16) || !isLowerHex(parts[3], 2) {
+		return TraceContext{}, false
+	}
+	// Version 00 has exactly 4 fields, future versions may add more.
+	if parts[0] == "00" && len(parts) != 4 {
+		return TraceContext{}, false
+	}
+	if strings.Trim(parts[1], "0") == "" || strings.Trim(parts[2], "0") == "" {
+		return TraceContext{}, false
+	}
+
+	flags, _ := hex.DecodeString(parts[3])
+	return TraceContext{TraceID: parts[1], SpanID: parts[2], Flags: flags[0]}, true
+}
+
+// Traceparent returns the traceparent header of the requests sent by this span to other services.
+func (t TraceContext) Traceparent() string {
+	return "00-" + t.TraceID + "-" + t.SpanID + "-" + hex.EncodeToString([]byte{t.Flags})
+}
+
+// Inject sets the traceparent and tracestate headers of an outgoing request, so the service called
+// continues the trace as a child of this span.
+func (t TraceContext) Inject(header http.Header) {
+	header.Set("traceparent", t.Traceparent())
+	if t.State != "" {
+		header.Set("tracestate", t.State)
+	} else {
+		header.Del("tracestate")
+	}
+}
+
+func isLowerHex(s string, length int) bool {
+	if len(s) != length {
+		return false
+	}
+	for _, c := range s {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b) // Never fails, see [rand.Read].
+	return hex.EncodeToString(b)
+}
+
+// traceContext returns the trace context of the request, created on first use, see [NewTraceContext].
+func (c netHttpContext[B, P]) traceContext() TraceContext {
+	if trace, ok := ContextValue[TraceContext](c); ok {
+		return trace
+	}
+	trace := NewTraceContext(c.Req.Header)
+	SetContextValue(c, trace)
+	return trace
+}
+
+// TraceID returns the W3C trace ID of the request, see [NewTraceContext].
+func (c netHttpContext[B, P]) TraceID() string {
+	return c.traceContext().TraceID
+}
+
+// SpanID returns the W3C span ID of the handling of the request, see [NewTraceContext].
+func (c netHttpContext[B, P]) SpanID() string {
+	return c.traceContext().SpanID
+}
+
+// PropagateTrace sets the trace headers of an outgoing request, see [TraceContext.Inject].
+func (c netHttpContext[B, P]) PropagateTrace(req *http.Request) {
+	c.traceContext().Inject(req.Header)
+}
@@ -0,0 +1,130 @@
+package fuego
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTraceparent(t *testing.T) {
+	trace, ok := ParseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	require.True(t, ok)
+	require.Equal(t, TraceContext{
+		TraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+		SpanID:  "00f067aa0ba902b7",
+		Flags:   0x01,
+	}, trace)
+	require.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", trace.Traceparent())
+
+	t.Run("future version with more fields", func(t *testing.T) {
+		trace, ok := ParseTraceparent("cc-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00-whatever")
+		require.True(t, ok)
+		require.Equal(t, byte(0), trace.Flags)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		for _, value := range []string{
+			"",
+			"garbage",
+			"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+			"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+			"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+			"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+			"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+			"00-4bf92f3577b34da6a3ce929d0e0e473-00f067aa0ba902b7-01",
+			"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-x1",
+		} {
+			_, ok := ParseTraceparent(value)
+			require.False(t, ok, value)
+		}
+	})
+}
+
+func TestNewTraceContext(t *testing.T) {
+	t.Run("continues the incoming trace", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+		header.Set("tracestate", "congo=t61rcWkgMzE")
+
+		trace := NewTraceContext(header)
+		require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", trace.TraceID)
+		require.Equal(t, "00f067aa0ba902b7", trace.ParentID)
+		require.Regexp(t, "^[0-9a-f]{16}$", trace.SpanID)
+		require.NotEqual(t, trace.ParentID, trace.SpanID)
+		require.Equal(t, byte(0x01), trace.Flags)
+		require.Equal(t, "congo=t61rcWkgMzE", trace.State)
+	})
+
+	t.Run("starts a new trace", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("traceparent", "invalid")
+
+		trace := NewTraceContext(header)
+		require.Regexp(t, "^[0-9a-f]{32}$", trace.TraceID)
+		require.Regexp(t, "^[0-9a-f]{16}$", trace.SpanID)
+		require.Empty(t, trace.ParentID)
+		require.Equal(t, byte(0x01), trace.Flags)
+		require.NotEqual(t, trace.TraceID, NewTraceContext(header).TraceID)
+	})
+}
+
+func TestContext_TraceID(t *testing.T) {
+	s := NewServer()
+	Get(s, "/trace", func(c ContextNoBody) (map[string]string, error) {
+		outgoing := httptest.NewRequest(http.MethodGet, "http://inventory/stock/42", nil)
+		c.PropagateTrace(outgoing)
+		return map[string]string{
+			"trace_id":    c.TraceID(),
+			"span_id":     c.SpanID(),
+			"span_id_2":   c.SpanID(),
+			"traceparent": outgoing.Header.Get("traceparent"),
+			"tracestate":  outgoing.Header.Get("tracestate"),
+		}, nil
+	})
+
+	get := func(t *testing.T, traceparent string) map[string]string {
+		t.Helper()
+		r := httptest.NewRequest(http.MethodGet, "/trace", nil)
+		if traceparent != "" {
+			r.Header.Set("traceparent", traceparent)
+			r.Header.Set("tracestate", "vendor=value")
+		}
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var ids map[string]string
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &ids))
+		return ids
+	}
+
+	t.Run("incoming traceparent", func(t *testing.T) {
+		ids := get(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+		require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", ids["trace_id"])
+		require.NotEqual(t, "00f067aa0ba902b7", ids["span_id"])
+		require.Equal(t, ids["span_id"], ids["span_id_2"])
+		require.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-"+ids["span_id"]+"-01", ids["traceparent"])
+		require.Equal(t, "vendor=value", ids["tracestate"])
+	})
+
+	t.Run("generated", func(t *testing.T) {
+		ids := get(t, "")
+		require.Regexp(t, "^[0-9a-f]{32}$", ids["trace_id"])
+		require.Equal(t, "00-"+ids["trace_id"]+"-"+ids["span_id"]+"-01", ids["traceparent"])
+		require.Empty(t, ids["tracestate"])
+	})
+
+	t.Run("mock context", func(t *testing.T) {
+		c := NewMockContextNoBody()
+		SetContextValue(c, TraceContext{TraceID: "4bf92f3577b34da6a3ce929d0e0e4736", SpanID: "00f067aa0ba902b7"})
+		require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", c.TraceID())
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		c.PropagateTrace(req)
+		require.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00", req.Header.Get("traceparent"))
+	})
+}
@@ -0,0 +1,30 @@
+package fuego
+
+import (
+	"io"
+	"net/http"
+)
+
+// RequestTrailers returns the trailer headers of the request, sent after a chunked body.
+// Trailers are only received after the body: the rest of the body is read and discarded
+// so that they are available, so the body must be read before, with [Context.Body] for example.
+// It returns nil if the request has no trailers.
+func RequestTrailers(r *http.Request) http.Header {
+	if r.Trailer == nil {
+		return nil
+	}
+	if r.Body != nil {
+		_, _ = io.Copy(io.Discard, r.Body)
+	}
+	return r.Trailer
+}
+
+// Trailers returns the trailer headers of the request, see [RequestTrailers].
+func (c netHttpContext[B, P]) Trailers() http.Header {
+	return RequestTrailers(c.Req)
+}
+
+// Trailer returns the value of the given trailer header of the request, see [RequestTrailers].
+func (c netHttpContext[B, P]) Trailer(key string) string {
+	return RequestTrailers(c.Req).Get(key)
+}
@@ -0,0 +1,63 @@
+package fuego
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrailers(t *testing.T) {
+	type upload struct {
+		Name string `json:"name"`
+	}
+
+	s := NewServer()
+	Post(s, "/upload", func(c ContextWithBody[upload]) (map[string]string, error) {
+		body, err := c.Body()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{
+			"name":     body.Name,
+			"checksum": c.Trailer("X-Checksum"),
+			"trailers": strings.Join(c.Trailers().Values("X-Signature"), ","),
+		}, nil
+	})
+	Post(s, "/no-trailer", func(c ContextNoBody) (any, error) {
+		return c.Trailers() == nil, nil
+	})
+
+	server := httptest.NewServer(s.Mux)
+	defer server.Close()
+
+	t.Run("trailers after a chunked body", func(t *testing.T) {
+		// io.MultiReader hides the length of the body, so it is sent chunked.
+		req, err := http.NewRequest(http.MethodPost, server.URL+"/upload", io.MultiReader(strings.NewReader(`{"name":"report.pdf"}`)))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Trailer = http.Header{"X-Checksum": {"abc123"}, "X-Signature": {"sig"}}
+
+		res, err := server.Client().Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		response, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode, string(response))
+		require.JSONEq(t, `{"name":"report.pdf","checksum":"abc123","trailers":"sig"}`, string(response))
+	})
+
+	t.Run("no trailers", func(t *testing.T) {
+		res, err := server.Client().Post(server.URL+"/no-trailer", "application/json", strings.NewReader(`{}`))
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		response, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.Equal(t, "true", strings.TrimSpace(string(response)))
+	})
+}
@@ -0,0 +1,82 @@
+package fuego
+
+import (
+	"log/slog"
+	"net/http"
+)
+
+// Tx is a request-scoped transaction, like [*sql.Tx], see [WithTxProvider].
+type Tx interface {
+	Commit() error
+	Rollback() error
+}
+
+// TxProvider begins the transaction of a request, see [WithTxProvider].
+type TxProvider func(r *http.Request) (Tx, error)
+
+// WithTxProvider begins a transaction before each controller, available with [Context.Tx].
+// The transaction is committed when the controller succeeds, before the response is serialized:
+// if the commit fails, an error is sent instead of the response.
+// Otherwise, it is rolled back once the request is finished, including when the controller panics,
+// see [Context.OnFinish], and rollback errors are logged.
+// If the provider fails, its error is sent and the controller is not called.
+// Only the net/http server begins transactions: the gin and echo adaptors do not use the server options.
+//
+//	s := fuego.NewServer(
+//		fuego.WithTxProvider(func(r *http.Request) (fuego.Tx, error) {
+//			return db.BeginTx(r.Context(), nil)
+//		}),
+//	)
+//
+//	fuego.Post(s, "/transfers", func(c fuego.ContextWithBody[Transfer]) (any, error) {
+//		tx, _ := c.Tx()
+//		_, err := tx.(*sql.Tx).ExecContext(c, "UPDATE accounts ...")
+//		return nil, err
+//	})
+func WithTxProvider(provider TxProvider) func(*Server) {
+	return func(s *Server) { s.txProvider = provider }
+}
+
+// withTransaction wraps the controller to run it in the transaction of [WithTxProvider].
+func withTransaction[T, B, P any](provider TxProvider, controller func(c Context[B, P]) (T, error)) func(c Context[B, P]) (T, error) {
+	return func(c Context[B, P]) (T, error) {
+		tx, err := provider(c.Request())
+		if err != nil {
+			var zero T
+			return zero, err
+		}
+		SetContextValue(c, tx)
+
+		// Rolls back if the controller failed or panicked, as the finish callbacks also run on panics.
+		done := false
+		c.OnFinish(func(error) {
+			if done {
+				return
+			}
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				slog.ErrorContext(c, "Cannot rollback transaction", "error", rollbackErr)
+			}
+		})
+
+		ans, err := controller(c)
+		if err != nil {
+			return ans, err
+		}
+
+		// Committed before the response is sent, so the client is not told about changes that are lost.
+		done = true
+		if err := tx.Commit(); err != nil {
+			var zero T
+			return zero, InternalServerError{
+				Err:    err,
+				Detail: "cannot commit transaction",
+			}
+		}
+		return ans, nil
+	}
+}
+
+// Tx returns the transaction of the request, see [WithTxProvider].
+func (c netHttpContext[B, P]) Tx() (any, bool) {
+	return ContextValue[Tx](c)
+}
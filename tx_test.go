@@ -0,0 +1,136 @@
+package fuego
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mockTx struct {
+	committed  bool
+	rolledBack bool
+	commitErr  error
+}
+
+func (tx *mockTx) Commit() error {
+	tx.committed = true
+	return tx.commitErr
+}
+
+func (tx *mockTx) Rollback() error {
+	tx.rolledBack = true
+	return nil
+}
+
+// committedResponse reports whether the transaction is committed when the response is serialized.
+type committedResponse struct {
+	tx func() *mockTx
+}
+
+func (r committedResponse) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]bool{"committed": r.tx().committed})
+}
+
+func TestWithTxProvider(t *testing.T) {
+	var tx *mockTx
+	s := NewServer(
+		WithTxProvider(func(r *http.Request) (Tx, error) {
+			if r.URL.Query().Has("unavailable") {
+				return nil, HTTPError{Status: http.StatusServiceUnavailable, Title: "Database Unavailable"}
+			}
+			tx = &mockTx{}
+			if r.URL.Query().Has("conflict") {
+				tx.commitErr = errors.New("serialization failure")
+			}
+			return tx, nil
+		}),
+	)
+	Get(s, "/ok", func(c ContextNoBody) (string, error) {
+		current, ok := c.Tx()
+		require.True(t, ok)
+		require.Same(t, tx, current)
+		require.False(t, tx.committed, "not committed before the controller returns")
+		return "ok", nil
+	})
+	Get(s, "/committed", func(c ContextNoBody) (committedResponse, error) {
+		return committedResponse{tx: func() *mockTx { return tx }}, nil
+	})
+	Get(s, "/fail", func(c ContextNoBody) (string, error) {
+		return "", errors.New("insert failed")
+	})
+	Get(s, "/panic", func(c ContextNoBody) (string, error) {
+		var rows map[string]int
+		rows["recipes"]++ // assignment to entry in nil map
+		return "ok", nil
+	})
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	t.Run("commit on success", func(t *testing.T) {
+		w := serve("/ok")
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.True(t, tx.committed)
+		require.False(t, tx.rolledBack)
+	})
+
+	t.Run("commit before serialization", func(t *testing.T) {
+		w := serve("/committed")
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.JSONEq(t, `{"committed":true}`, w.Body.String())
+	})
+
+	t.Run("commit error", func(t *testing.T) {
+		w := serve("/ok?conflict")
+
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+		require.NotContains(t, w.Body.String(), "ok")
+		require.True(t, tx.committed)
+		require.False(t, tx.rolledBack)
+	})
+
+	t.Run("rollback on handler error", func(t *testing.T) {
+		w := serve("/fail")
+
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+		require.False(t, tx.committed)
+		require.True(t, tx.rolledBack)
+	})
+
+	t.Run("rollback on panic", func(t *testing.T) {
+		require.Panics(t, func() { serve("/panic") })
+		require.False(t, tx.committed)
+		require.True(t, tx.rolledBack)
+	})
+
+	t.Run("provider error", func(t *testing.T) {
+		tx = nil
+		w := serve("/ok?unavailable")
+
+		require.Equal(t, http.StatusServiceUnavailable, w.Code)
+		require.Nil(t, tx)
+	})
+
+	t.Run("without provider", func(t *testing.T) {
+		c := NewNetHTTPContext[any, any](BaseRoute{}, httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil), readOptions{})
+		_, ok := c.Tx()
+		require.False(t, ok)
+	})
+
+	t.Run("mock context", func(t *testing.T) {
+		c := NewMockContextNoBody()
+		SetContextValue[Tx](c, &mockTx{})
+		current, ok := c.Tx()
+		require.True(t, ok)
+		require.IsType(t, &mockTx{}, current)
+	})
+}
@@ -0,0 +1,82 @@
+package fuego
+
+import (
+	"errors"
+	"io"
+	"unicode/utf8"
+)
+
+// errInvalidUTF8 is returned by [utf8Reader] when the request body is not valid UTF-8.
+var errInvalidUTF8 = errors.New("request body is not valid UTF-8")
+
+// WithUTF8Validation rejects the text/plain and JSON request bodies that are not valid UTF-8
+// with a 400 [BadRequestError], instead of silently replacing the invalid sequences with U+FFFD,
+// as encoding/json does. Useful for data-integrity-sensitive ingestion.
+// The body is checked while it is decoded, without being buffered.
+func WithUTF8Validation() func(*Server) {
+	return func(s *Server) { s.validateUTF8 = true }
+}
+
+// utf8Reader checks that the bytes read through it are valid UTF-8,
+// failing with [errInvalidUTF8] at the first invalid sequence.
+// Runes split across reads are completed with the next read.
+type utf8Reader struct {
+	r io.Reader
+	// Incomplete rune at the end of the previous read.
+	pending    [utf8.UTFMax]byte
+	pendingLen int
+}
+
+func newUTF8Reader(r io.Reader) *utf8Reader {
+	return &utf8Reader{r: r}
+}
+
+func (r *utf8Reader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	data := p[:n]
+
+	// Complete the rune split across the previous read.
+	for r.pendingLen > 0 && len(data) > 0 {
+		r.pending[r.pendingLen] = data[0]
+		r.pendingLen++
+		data = data[1:]
+		if utf8.FullRune(r.pending[:r.pendingLen]) {
+			if !utf8.Valid(r.pending[:r.pendingLen]) {
+				return 0, errInvalidUTF8
+			}
+			r.pendingLen = 0
+		}
+	}
+
+	// Keep the incomplete rune at the end for the next read.
+	if r.pendingLen == 0 {
+		start := len(data)
+		for i := len(data) - 1; i >= 0 && i >= len(data)-utf8.UTFMax+1; i-- {
+			if utf8.RuneStart(data[i]) {
+				if !utf8.FullRune(data[i:]) {
+					start = i
+				}
+				break
+			}
+		}
+		if !utf8.Valid(data[:start]) {
+			return 0, errInvalidUTF8
+		}
+		r.pendingLen = copy(r.pending[:], data[start:])
+	}
+
+	// The body ends with an incomplete rune.
+	if err == io.EOF && r.pendingLen > 0 {
+		return 0, errInvalidUTF8
+	}
+	return n, err
+}
+
+// invalidUTF8Error is the 400 sent for bodies rejected by [WithUTF8Validation].
+func invalidUTF8Error(err error) error {
+	return BadRequestError{
+		Title:  "Invalid UTF-8",
+		Err:    err,
+		Detail: "cannot decode request body: " + err.Error(),
+	}
+}
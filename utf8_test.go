@@ -0,0 +1,97 @@
+package fuego
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUTF8Reader(t *testing.T) {
+	t.Run("valid text read byte by byte", func(t *testing.T) {
+		text := "héllo wörld, 日本語 🍝"
+		read, err := io.ReadAll(newUTF8Reader(iotest.OneByteReader(strings.NewReader(text))))
+		require.NoError(t, err)
+		require.Equal(t, text, string(read))
+	})
+
+	t.Run("invalid sequences", func(t *testing.T) {
+		for name, text := range map[string]string{
+			"invalid byte":             "abc\xffdef",
+			"lone continuation byte":   "abc\x80",
+			"overlong encoding":        "\xc0\xaf",
+			"surrogate":                "\xed\xa0\x80",
+			"truncated rune at end":    "abc\xe6\x97",
+			"truncated rune in text":   "\xe6\x97abc",
+			"invalid after valid rune": "日\xe6\x97\x00",
+		} {
+			for _, reader := range []io.Reader{strings.NewReader(text), iotest.OneByteReader(strings.NewReader(text))} {
+				_, err := io.ReadAll(newUTF8Reader(reader))
+				require.ErrorIs(t, err, errInvalidUTF8, name)
+			}
+		}
+	})
+}
+
+func TestValidateUTF8(t *testing.T) {
+	options := readOptions{ValidateUTF8: true}
+
+	t.Run("JSON", func(t *testing.T) {
+		_, err := readJSON[map[string]string](context.Background(), strings.NewReader("{\"name\":\"caf\xe9\"}"), options)
+
+		var badRequest BadRequestError
+		require.ErrorAs(t, err, &badRequest)
+		require.Equal(t, "Invalid UTF-8", badRequest.Title)
+		require.ErrorIs(t, err, errInvalidUTF8)
+
+		body, err := readJSON[map[string]string](context.Background(), strings.NewReader(`{"name":"café"}`), options)
+		require.NoError(t, err)
+		require.Equal(t, "café", body["name"])
+	})
+
+	t.Run("text", func(t *testing.T) {
+		_, err := readString[string](context.Background(), strings.NewReader("caf\xe9"), options)
+		require.ErrorIs(t, err, errInvalidUTF8)
+		require.ErrorAs(t, err, &BadRequestError{})
+	})
+
+	t.Run("replaced by default", func(t *testing.T) {
+		body, err := readJSON[map[string]string](context.Background(), strings.NewReader("{\"name\":\"caf\xe9\"}"), readOptions{})
+		require.NoError(t, err)
+		require.Equal(t, "caf�", body["name"])
+	})
+
+	t.Run("with server option", func(t *testing.T) {
+		s := NewServer(WithUTF8Validation())
+		Post(s, "/json", func(c ContextWithBody[map[string]string]) (any, error) {
+			return c.Body()
+		})
+		Post(s, "/text", func(c ContextWithBody[string]) (string, error) {
+			return c.Body()
+		})
+
+		for _, tc := range []struct {
+			path, contentType, body string
+			status                  int
+		}{
+			{"/json", "application/json", `{"name":"café"}`, http.StatusOK},
+			{"/json", "application/json", "{\"name\":\"caf\xe9\"}", http.StatusBadRequest},
+			{"/text", "text/plain", "café", http.StatusOK},
+			{"/text", "text/plain", "caf\xe9", http.StatusBadRequest},
+		} {
+			r := httptest.NewRequest(http.MethodPost, tc.path, strings.NewReader(tc.body))
+			r.Header.Set("Content-Type", tc.contentType)
+			w := httptest.NewRecorder()
+			s.Mux.ServeHTTP(w, r)
+			require.Equal(t, tc.status, w.Code, w.Body.String())
+			if tc.status == http.StatusBadRequest {
+				require.Contains(t, w.Body.String(), "Invalid UTF-8")
+			}
+		}
+	})
+}
@@ -0,0 +1,47 @@
+package fuego
+
+import (
+	"net/http"
+	"strings"
+)
+
+// AddVary adds the given request headers to the Vary response header, so caches store a response
+// per value of these headers. Headers already listed, in any case, are not repeated,
+// and "*" (the response varies on anything) replaces all the others.
+//
+//	AddVary(header, "Accept", "Accept-Language")
+//	AddVary(header, "accept")
+//	// Vary: Accept, Accept-Language
+func AddVary(header http.Header, names ...string) {
+	var vary []string
+	seen := make(map[string]bool)
+	add := func(name string) {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[strings.ToLower(name)] {
+			return
+		}
+		seen[strings.ToLower(name)] = true
+		vary = append(vary, name)
+	}
+
+	for _, line := range header.Values("Vary") {
+		for name := range strings.SplitSeq(line, ",") {
+			add(name)
+		}
+	}
+	for _, name := range names {
+		add(name)
+	}
+
+	if seen["*"] {
+		vary = []string{"*"}
+	}
+	if len(vary) > 0 {
+		header.Set("Vary", strings.Join(vary, ", "))
+	}
+}
+
+// Vary adds the given request headers to the Vary response header, see [AddVary].
+func (c netHttpContext[B, P]) Vary(headers ...string) {
+	AddVary(c.Res.Header(), headers...)
+}
@@ -0,0 +1,66 @@
+package fuego
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddVary(t *testing.T) {
+	t.Run("accumulates without duplicates", func(t *testing.T) {
+		header := http.Header{}
+		AddVary(header, "Accept", "Accept-Language")
+		AddVary(header, "accept", "X-Tenant")
+		AddVary(header, "X-Tenant")
+
+		require.Equal(t, []string{"Accept, Accept-Language, X-Tenant"}, header.Values("Vary"))
+	})
+
+	t.Run("merges existing values", func(t *testing.T) {
+		header := http.Header{}
+		header.Add("Vary", "Origin")
+		header.Add("Vary", "Accept-Encoding, Origin")
+		AddVary(header, "Accept-Encoding", "Accept")
+
+		require.Equal(t, []string{"Origin, Accept-Encoding, Accept"}, header.Values("Vary"))
+	})
+
+	t.Run("wildcard replaces everything", func(t *testing.T) {
+		header := http.Header{}
+		AddVary(header, "Accept")
+		AddVary(header, "*")
+		AddVary(header, "Origin")
+
+		require.Equal(t, "*", header.Get("Vary"))
+	})
+
+	t.Run("nothing to add", func(t *testing.T) {
+		header := http.Header{}
+		AddVary(header, "", " ")
+
+		require.Empty(t, header.Values("Vary"))
+	})
+}
+
+func TestContextVary(t *testing.T) {
+	s := NewServer(
+		WithAPIVersioning(APIVersionConfig{Sources: []APIVersionSource{APIVersionFromHeader}}),
+	)
+	Get(s, "/negotiated", func(c ContextNoBody) (string, error) {
+		c.Vary("X-Tenant", "Accept")
+		_ = c.MainLocale()
+		_ = c.ResponseCharset()
+		_, _ = c.APIVersion()
+		c.Vary("x-tenant")
+		return "ok", nil
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/negotiated", nil)
+	w := httptest.NewRecorder()
+	s.Mux.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "X-Tenant, Accept, Accept-Language, Accept-Charset, X-Api-Version", w.Header().Get("Vary"))
+}
@@ -0,0 +1,21 @@
+package fuego
+
+import "net/http"
+
+// WrapHTTP adapts an existing [http.HandlerFunc] into a Fuego controller, to migrate a codebase incrementally:
+// the handler can be registered with the route functions like [Get] or [Post], and benefits from
+// the route options and the OpenAPI documentation. The handler writes the response directly
+// to the underlying [http.ResponseWriter], so the controller returns no data.
+// Unlike [GetStd] and the like, it works with every router adaptor.
+//
+//	func legacyHealth(w http.ResponseWriter, r *http.Request) {
+//		w.Write([]byte("ok"))
+//	}
+//
+//	fuego.Get(s, "/health", fuego.WrapHTTP(legacyHealth), option.Summary("Health check"))
+func WrapHTTP(h http.HandlerFunc) func(c Context[any, any]) (any, error) {
+	return func(c Context[any, any]) (any, error) {
+		h(c.Response(), c.Request())
+		return nil, nil
+	}
+}
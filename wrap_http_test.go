@@ -0,0 +1,46 @@
+package fuego
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrapHTTP(t *testing.T) {
+	s := NewServer()
+	Get(s, "/legacy/{id}", WrapHTTP(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte("legacy " + r.PathValue("id")))
+	}))
+	Post(s, "/legacy/echo", WrapHTTP(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, "", time.Time{}, strings.NewReader(r.FormValue("message")))
+	}))
+
+	t.Run("plain handler through a fuego route", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/legacy/42", nil))
+
+		require.Equal(t, http.StatusAccepted, w.Code)
+		require.Equal(t, "text/plain", w.Header().Get("Content-Type"))
+		require.Equal(t, "legacy 42", w.Body.String())
+	})
+
+	t.Run("reads the request", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/legacy/echo", strings.NewReader("message=hello"))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "hello", w.Body.String())
+	})
+
+	t.Run("documented in OpenAPI", func(t *testing.T) {
+		require.NotNil(t, s.OpenAPI.Description().Paths.Find("/legacy/{id}").Get)
+	})
+}
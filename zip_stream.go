@@ -0,0 +1,73 @@
+package fuego
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"net/http"
+)
+
+// WriteZip streams a ZIP archive to the response: fn adds the entries to the [zip.Writer],
+// which writes them to the client as they are compressed, without buffering the whole archive.
+// The response is sent as "application/zip" with the given status code, if not 0, and as an attachment
+// named "archive.zip", unless a Content-Disposition header is already set.
+// If fn fails before anything is written, the headers are removed and its error is returned, to be sent instead.
+// If the context is done (client disconnected), the archive is abandoned and nil is returned:
+// nothing more can be sent.
+func WriteZip(ctx context.Context, w http.ResponseWriter, status int, fn func(zw *zip.Writer) error) error {
+	header := w.Header()
+	header.Set("Content-Type", "application/zip")
+	if header.Get("Content-Disposition") == "" {
+		header.Set("Content-Disposition", `attachment; filename="archive.zip"`)
+	}
+	header.Del("Content-Length")
+
+	zipWriter := &zipResponseWriter{ctx: ctx, w: w, status: status}
+	zw := zip.NewWriter(zipWriter)
+	err := fn(zw)
+	if err == nil {
+		err = zw.Close()
+	}
+	if ctx.Err() != nil {
+		return nil
+	}
+	if err != nil {
+		if !zipWriter.wroteHeader {
+			header.Del("Content-Type")
+			header.Del("Content-Disposition")
+		}
+		return err
+	}
+
+	if err := http.NewResponseController(w).Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
+
+// zipResponseWriter writes the status code of the response with the first bytes of the archive,
+// and stops writing once the client is gone.
+type zipResponseWriter struct {
+	ctx         context.Context
+	w           http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (z *zipResponseWriter) Write(p []byte) (int, error) {
+	if err := z.ctx.Err(); err != nil {
+		return 0, err
+	}
+	if !z.wroteHeader {
+		z.wroteHeader = true
+		if z.status != 0 {
+			z.w.WriteHeader(z.status)
+		}
+	}
+	return z.w.Write(p)
+}
+
+// ZipStream streams a ZIP archive with the entries added by fn to the response, see [WriteZip].
+func (c netHttpContext[B, P]) ZipStream(fn func(zw *zip.Writer) error) (any, error) {
+	return nil, WriteZip(c.Req.Context(), c.Res, c.DefaultStatusCode, fn)
+}
@@ -0,0 +1,116 @@
+package fuego
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func addZipEntry(zw *zip.Writer, name, content string) error {
+	f, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(f, content)
+	return err
+}
+
+func TestZipStream(t *testing.T) {
+	s := NewServer()
+	Get(s, "/all.zip", func(c ContextNoBody) (any, error) {
+		return c.ZipStream(func(zw *zip.Writer) error {
+			if err := addZipEntry(zw, "a.txt", "first file"); err != nil {
+				return err
+			}
+			return addZipEntry(zw, "folder/b.json", `{"second":true}`)
+		})
+	})
+	Get(s, "/named.zip", func(c ContextNoBody) (any, error) {
+		c.SetHeader("Content-Disposition", `attachment; filename="named.zip"`)
+		return c.ZipStream(func(zw *zip.Writer) error { return nil })
+	})
+	Get(s, "/failing.zip", func(c ContextNoBody) (any, error) {
+		return c.ZipStream(func(zw *zip.Writer) error {
+			return BadRequestError{Title: "No Files"}
+		})
+	})
+
+	t.Run("two-file archive", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/all.zip", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+		require.Equal(t, `attachment; filename="archive.zip"`, w.Header().Get("Content-Disposition"))
+		require.True(t, w.Flushed)
+
+		archive, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+		require.NoError(t, err)
+		require.Len(t, archive.File, 2)
+
+		contents := map[string]string{}
+		for _, file := range archive.File {
+			f, err := file.Open()
+			require.NoError(t, err)
+			content, err := io.ReadAll(f)
+			require.NoError(t, err)
+			contents[file.Name] = string(content)
+		}
+		require.Equal(t, map[string]string{
+			"a.txt":         "first file",
+			"folder/b.json": `{"second":true}`,
+		}, contents)
+	})
+
+	t.Run("keeps the Content-Disposition set by the controller", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/named.zip", nil))
+
+		require.Equal(t, `attachment; filename="named.zip"`, w.Header().Get("Content-Disposition"))
+		_, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+		require.NoError(t, err)
+	})
+
+	t.Run("error before writing is sent instead", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/failing.zip", nil))
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Empty(t, w.Header().Get("Content-Disposition"))
+		require.Contains(t, w.Body.String(), "No Files")
+	})
+
+	t.Run("client disconnected", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		w := httptest.NewRecorder()
+
+		err := WriteZip(ctx, w, http.StatusOK, func(zw *zip.Writer) error {
+			if err := addZipEntry(zw, "a.txt", "first file"); err != nil {
+				return err
+			}
+			require.NoError(t, zw.Flush())
+			cancel()
+			err := addZipEntry(zw, "b.txt", "never sent")
+			if err == nil {
+				err = zw.Flush()
+			}
+			require.ErrorIs(t, err, context.Canceled)
+			return err
+		})
+		require.NoError(t, err)
+		require.NotContains(t, w.Body.String(), "never sent")
+	})
+
+	t.Run("mock context without response", func(t *testing.T) {
+		c := NewMockContextNoBody()
+		_, err := c.ZipStream(func(zw *zip.Writer) error { return errors.New("boom") })
+		require.EqualError(t, err, "boom")
+	})
+}